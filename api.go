@@ -11,13 +11,18 @@ const (
 	APIBaseURL                      = "https://api.notion.com"
 	APIUsersListEndpoint            = "/v1/users"
 	APIUsersRetrieveEndpoint        = "/v1/users/{user_id}"
+	APIBlocksRetrieveEndpoint       = "/v1/blocks/{block_id}"
+	APIBlocksUpdateEndpoint         = "/v1/blocks/{block_id}"
+	APIBlocksDeleteEndpoint         = "/v1/blocks/{block_id}"
 	APIBlocksListChildrenEndpoint   = "/v1/blocks/{block_id}/children"
 	APIBlocksAppendChildrenEndpoint = "/v1/blocks/{block_id}/children"
 	APIPagesCreateEndpoint          = "/v1/pages"
 	APIPagesRetrieveEndpoint        = "/v1/pages/{page_id}"
 	APIPagesUpdateEndpoint          = "/v1/pages/{page_id}"
 	APIDatabasesListEndpoint        = "/v1/databases"
+	APIDatabasesCreateEndpoint      = "/v1/databases"
 	APIDatabasesRetrieveEndpoint    = "/v1/databases/{database_id}"
+	APIDatabasesUpdateEndpoint      = "/v1/databases/{database_id}"
 	APIDatabasesQueryEndpoint       = "/v1/databases/{database_id}/query"
 	APISearchEndpoint               = "/v1/search"
 )
@@ -56,17 +61,45 @@ func New(authToken string, setters ...APISetting) *API {
 		BaseURL(settings.baseURL).
 		UserAgent(settings.userAgent).
 		Client(settings.httpClient).
+		RetryPolicy(settings.retryPolicy).
+		RateLimiter(settings.rateLimiter).
 		Header("Notion-Version", settings.notionVersion)
 
+	databasesClient := newDatabasesClient(restClient)
+	if settings.validateQueries {
+		databasesClient.schemas = newSchemaCache()
+	}
+
 	return &API{
 		searchClient:    newSearchClient(restClient),
 		usersClient:     newUsersClient(restClient),
-		databasesClient: newDatabasesClient(restClient),
+		databasesClient: databasesClient,
 		pagesClient:     newPagesClient(restClient),
 		blocksClient:    newBlocksClient(restClient),
 	}
 }
 
+// Clients are the clients of the endpoints of the API, see NewWithClients.
+type Clients struct {
+	Users     UsersInterface
+	Databases DatabasesInterface
+	Pages     PagesInterface
+	Blocks    BlocksInterface
+	Search    SearchInterface
+}
+
+// NewWithClients returns an API using the given clients instead of sending requests, so that fakes such as the mocks
+// of the notionmock package can be injected into code using an API.
+func NewWithClients(clients Clients) *API {
+	return &API{
+		searchClient:    clients.Search,
+		usersClient:     clients.Users,
+		databasesClient: clients.Databases,
+		pagesClient:     clients.Pages,
+		blocksClient:    clients.Blocks,
+	}
+}
+
 func (c *API) Users() UsersInterface {
 	return c.usersClient
 }
@@ -87,11 +120,19 @@ func (c *API) Search(ctx context.Context, params SearchParameters) (*SearchRespo
 	return c.searchClient.Search(ctx, params)
 }
 
+func (c *API) SearchAll(ctx context.Context, params SearchParameters) *SearchIterator {
+	return c.searchClient.SearchAll(ctx, params)
+}
+
 type apiSettings struct {
 	baseURL       string
 	notionVersion string
 	userAgent     string
 	httpClient    *http.Client
+	retryPolicy   rest.RetryPolicy
+	rateLimiter   *rest.RateLimiter
+	// Whether the database queries are checked by ValidateQuery.
+	validateQueries bool
 }
 
 type APISetting func(o *apiSettings)
@@ -119,3 +160,29 @@ func WithHTTPClient(httpClient *http.Client) APISetting {
 		o.httpClient = httpClient
 	}
 }
+
+// WithRetryPolicy replays requests which failed because of rate limiting or a server error.
+// Only requests which are safe to repeat are replayed after a server error. See rest.DefaultRetryPolicy.
+func WithRetryPolicy(retryPolicy rest.RetryPolicy) APISetting {
+	return func(o *apiSettings) {
+		o.retryPolicy = retryPolicy
+	}
+}
+
+// WithRateLimiter throttles the requests of every endpoint with the given rate limiter.
+// Pass the same rate limiter to several clients using the same token to share one budget.
+func WithRateLimiter(rateLimiter *rest.RateLimiter) APISetting {
+	return func(o *apiSettings) {
+		o.rateLimiter = rateLimiter
+	}
+}
+
+// WithQueryValidation checks the filter and the sorts of the database queries with ValidateQuery before sending
+// them, so that mistakes are reported with their path rather than as an opaque validation error. The database is
+// retrieved once and kept. It is retrieved again when a query is invalid against the kept database, in case
+// properties were added or changed since, and when Notion rejects a query which was valid against it.
+func WithQueryValidation() APISetting {
+	return func(o *apiSettings) {
+		o.validateQueries = true
+	}
+}
@@ -3,7 +3,9 @@ package notion
 import (
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/mkfsn/notion-go/rest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,3 +48,50 @@ func TestHTTPClient(t *testing.T) {
 
 	assert.Same(t, settings.httpClient, httpClient)
 }
+
+func TestWithRetryPolicy(t *testing.T) {
+	var settings apiSettings
+
+	retryPolicy := rest.RetryPolicy{MaxRetries: 5, MinBackoff: time.Second, MaxBackoff: time.Minute}
+
+	WithRetryPolicy(retryPolicy)(&settings)
+
+	assert.Equal(t, settings.retryPolicy, retryPolicy)
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	var settings apiSettings
+
+	rateLimiter := rest.NewRateLimiter(3, 3)
+
+	WithRateLimiter(rateLimiter)(&settings)
+
+	assert.Same(t, settings.rateLimiter, rateLimiter)
+}
+
+func TestWithQueryValidation(t *testing.T) {
+	var settings apiSettings
+
+	WithQueryValidation()(&settings)
+
+	assert.True(t, settings.validateQueries)
+}
+
+func TestNewWithClients(t *testing.T) {
+	restClient := rest.New()
+	clients := Clients{
+		Users:     newUsersClient(restClient),
+		Databases: newDatabasesClient(restClient),
+		Pages:     newPagesClient(restClient),
+		Blocks:    newBlocksClient(restClient),
+		Search:    newSearchClient(restClient),
+	}
+
+	api := NewWithClients(clients)
+
+	assert.Same(t, clients.Users, api.Users())
+	assert.Same(t, clients.Databases, api.Databases())
+	assert.Same(t, clients.Pages, api.Pages())
+	assert.Same(t, clients.Blocks, api.Blocks())
+	assert.Same(t, clients.Search, api.searchClient)
+}
@@ -12,6 +12,8 @@ import (
 
 type Block interface {
 	isBlock()
+	// GetBase returns the fields which are common to every type of block.
+	GetBase() BlockBase
 }
 
 type BlockBase struct {
@@ -27,10 +29,16 @@ type BlockBase struct {
 	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
 	// Whether or not the block has children blocks nested within it.
 	HasChildren bool `json:"has_children,omitempty"`
+	// The archived status of the block.
+	Archived bool `json:"archived,omitempty"`
 }
 
 func (b BlockBase) isBlock() {}
 
+func (b BlockBase) GetBase() BlockBase {
+	return b
+}
+
 type ParagraphBlock struct {
 	BlockBase
 	Paragraph RichTextBlock `json:"paragraph"`
@@ -114,14 +122,42 @@ type NumberedListItemBlock struct {
 }
 
 type RichTextWithCheckBlock struct {
-	Text     []RichText  `json:"text"`
-	Checked  bool        `json:"checked"`
-	Children []BlockBase `json:"children"`
+	Text     []RichText `json:"text"`
+	Checked  bool       `json:"checked"`
+	Children []Block    `json:"children,omitempty"`
+}
+
+func (r *RichTextWithCheckBlock) UnmarshalJSON(data []byte) error {
+	var alias struct {
+		Text     []richTextDecoder `json:"text"`
+		Checked  bool              `json:"checked"`
+		Children []blockDecoder    `json:"children"`
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal RichTextWithCheckBlock: %w", err)
+	}
+
+	r.Text = make([]RichText, 0, len(alias.Text))
+
+	for _, decoder := range alias.Text {
+		r.Text = append(r.Text, decoder.RichText)
+	}
+
+	r.Checked = alias.Checked
+
+	r.Children = make([]Block, 0, len(alias.Children))
+
+	for _, decoder := range alias.Children {
+		r.Children = append(r.Children, decoder.Block)
+	}
+
+	return nil
 }
 
 type ToDoBlock struct {
 	BlockBase
-	ToDo RichTextWithCheckBlock `json:"todo"`
+	ToDo RichTextWithCheckBlock `json:"to_do"`
 }
 
 type ToggleBlock struct {
@@ -138,20 +174,475 @@ type ChildPageBlock struct {
 	ChildPage TitleBlock `json:"child_page"`
 }
 
+type ChildDatabaseBlock struct {
+	BlockBase
+	ChildDatabase TitleBlock `json:"child_database"`
+}
+
+type ExternalFile struct {
+	// Link to the externally hosted content.
+	URL string `json:"url"`
+}
+
+type HostedFile struct {
+	// Authenticated S3 URL to the file. The file URL will be valid for 1 hour.
+	URL string `json:"url"`
+	// Date and time when the link expires.
+	ExpiryTime *time.Time `json:"expiry_time,omitempty"`
+}
+
+// FileObject is a file hosted by Notion, or an external file.
+type FileObject struct {
+	// Type of the file, either "external" or "file".
+	Type     FileType      `json:"type"`
+	External *ExternalFile `json:"external,omitempty"`
+	File     *HostedFile   `json:"file,omitempty"`
+	Caption  []RichText    `json:"caption,omitempty"`
+}
+
+func (f *FileObject) UnmarshalJSON(data []byte) error {
+	type Alias FileObject
+
+	alias := struct {
+		*Alias
+		Caption []richTextDecoder `json:"caption"`
+	}{
+		Alias: (*Alias)(f),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal FileObject: %w", err)
+	}
+
+	f.Caption = decodeRichTexts(alias.Caption)
+
+	return nil
+}
+
+// Icon is an emoji, a file hosted by Notion, or an external file.
+type Icon struct {
+	// Type of the icon, either "emoji", "external" or "file".
+	Type     IconType      `json:"type"`
+	Emoji    string        `json:"emoji,omitempty"`
+	External *ExternalFile `json:"external,omitempty"`
+	File     *HostedFile   `json:"file,omitempty"`
+}
+
+type CalloutObject struct {
+	Text     []RichText `json:"text"`
+	Icon     *Icon      `json:"icon,omitempty"`
+	Color    Color      `json:"color,omitempty"`
+	Children []Block    `json:"children,omitempty"`
+}
+
+func (c *CalloutObject) UnmarshalJSON(data []byte) error {
+	type Alias CalloutObject
+
+	alias := struct {
+		*Alias
+		Text     []richTextDecoder `json:"text"`
+		Children []blockDecoder    `json:"children"`
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal CalloutObject: %w", err)
+	}
+
+	c.Text = decodeRichTexts(alias.Text)
+	c.Children = decodeBlocks(alias.Children)
+
+	return nil
+}
+
+type CalloutBlock struct {
+	BlockBase
+	Callout CalloutObject `json:"callout"`
+}
+
+type QuoteBlock struct {
+	BlockBase
+	Quote RichTextBlock `json:"quote"`
+}
+
+type CodeObject struct {
+	Text     []RichText `json:"text"`
+	Caption  []RichText `json:"caption,omitempty"`
+	Language string     `json:"language"`
+}
+
+func (c *CodeObject) UnmarshalJSON(data []byte) error {
+	type Alias CodeObject
+
+	alias := struct {
+		*Alias
+		Text    []richTextDecoder `json:"text"`
+		Caption []richTextDecoder `json:"caption"`
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal CodeObject: %w", err)
+	}
+
+	c.Text = decodeRichTexts(alias.Text)
+	c.Caption = decodeRichTexts(alias.Caption)
+
+	return nil
+}
+
+type CodeBlock struct {
+	BlockBase
+	Code CodeObject `json:"code"`
+}
+
+type ImageBlock struct {
+	BlockBase
+	Image FileObject `json:"image"`
+}
+
+type VideoBlock struct {
+	BlockBase
+	Video FileObject `json:"video"`
+}
+
+type FileBlock struct {
+	BlockBase
+	File FileObject `json:"file"`
+}
+
+type PDFBlock struct {
+	BlockBase
+	PDF FileObject `json:"pdf"`
+}
+
+type URLObject struct {
+	URL     string     `json:"url"`
+	Caption []RichText `json:"caption,omitempty"`
+}
+
+func (u *URLObject) UnmarshalJSON(data []byte) error {
+	type Alias URLObject
+
+	alias := struct {
+		*Alias
+		Caption []richTextDecoder `json:"caption"`
+	}{
+		Alias: (*Alias)(u),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal URLObject: %w", err)
+	}
+
+	u.Caption = decodeRichTexts(alias.Caption)
+
+	return nil
+}
+
+type BookmarkBlock struct {
+	BlockBase
+	Bookmark URLObject `json:"bookmark"`
+}
+
+type EmbedBlock struct {
+	BlockBase
+	Embed URLObject `json:"embed"`
+}
+
+type LinkPreviewBlock struct {
+	BlockBase
+	LinkPreview URLObject `json:"link_preview"`
+}
+
+type EquationBlock struct {
+	BlockBase
+	Equation EquationObject `json:"equation"`
+}
+
+// EmptyObject is the content of the blocks which have nothing but a type, e.g. a divider.
+type EmptyObject struct{}
+
+type DividerBlock struct {
+	BlockBase
+	Divider EmptyObject `json:"divider"`
+}
+
+type TableOfContentsBlock struct {
+	BlockBase
+	TableOfContents EmptyObject `json:"table_of_contents"`
+}
+
+type BreadcrumbBlock struct {
+	BlockBase
+	Breadcrumb EmptyObject `json:"breadcrumb"`
+}
+
+type ChildrenObject struct {
+	Children []Block `json:"children,omitempty"`
+}
+
+func (c *ChildrenObject) UnmarshalJSON(data []byte) error {
+	var alias struct {
+		Children []blockDecoder `json:"children"`
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal ChildrenObject: %w", err)
+	}
+
+	c.Children = decodeBlocks(alias.Children)
+
+	return nil
+}
+
+type ColumnListBlock struct {
+	BlockBase
+	ColumnList ChildrenObject `json:"column_list"`
+}
+
+type ColumnBlock struct {
+	BlockBase
+	Column ChildrenObject `json:"column"`
+}
+
+type SyncedFrom struct {
+	// Always "block_id".
+	Type    string `json:"type"`
+	BlockID string `json:"block_id"`
+}
+
+type SyncedBlockObject struct {
+	// The original synced block, nil if this block is the original one.
+	SyncedFrom *SyncedFrom `json:"synced_from"`
+	Children   []Block     `json:"children,omitempty"`
+}
+
+func (s *SyncedBlockObject) UnmarshalJSON(data []byte) error {
+	type Alias SyncedBlockObject
+
+	alias := struct {
+		*Alias
+		Children []blockDecoder `json:"children"`
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal SyncedBlockObject: %w", err)
+	}
+
+	s.Children = decodeBlocks(alias.Children)
+
+	return nil
+}
+
+type SyncedBlock struct {
+	BlockBase
+	SyncedBlock SyncedBlockObject `json:"synced_block"`
+}
+
+type TemplateBlock struct {
+	BlockBase
+	Template RichTextBlock `json:"template"`
+}
+
+type TableObject struct {
+	// Number of columns in the table, it cannot be changed once the table is created.
+	TableWidth      int  `json:"table_width"`
+	HasColumnHeader bool `json:"has_column_header"`
+	HasRowHeader    bool `json:"has_row_header"`
+	// The rows of the table, only used when creating a table.
+	Children []Block `json:"children,omitempty"`
+}
+
+func (t *TableObject) UnmarshalJSON(data []byte) error {
+	type Alias TableObject
+
+	alias := struct {
+		*Alias
+		Children []blockDecoder `json:"children"`
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal TableObject: %w", err)
+	}
+
+	t.Children = decodeBlocks(alias.Children)
+
+	return nil
+}
+
+type TableBlock struct {
+	BlockBase
+	Table TableObject `json:"table"`
+}
+
+type TableRowObject struct {
+	// The content of each cell of the row.
+	Cells [][]RichText `json:"cells"`
+}
+
+func (t *TableRowObject) UnmarshalJSON(data []byte) error {
+	var alias struct {
+		Cells [][]richTextDecoder `json:"cells"`
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal TableRowObject: %w", err)
+	}
+
+	t.Cells = make([][]RichText, 0, len(alias.Cells))
+
+	for _, cell := range alias.Cells {
+		t.Cells = append(t.Cells, decodeRichTexts(cell))
+	}
+
+	return nil
+}
+
+type TableRowBlock struct {
+	BlockBase
+	TableRow TableRowObject `json:"table_row"`
+}
+
+type LinkToPageObject struct {
+	// Type of the linked object, either "page_id" or "database_id".
+	Type       LinkToPageType `json:"type"`
+	PageID     string         `json:"page_id,omitempty"`
+	DatabaseID string         `json:"database_id,omitempty"`
+}
+
+type LinkToPageBlock struct {
+	BlockBase
+	LinkToPage LinkToPageObject `json:"link_to_page"`
+}
+
 type UnsupportedBlock struct {
 	BlockBase
 }
 
+type BlocksRetrieveParameters struct {
+	// Identifier for a block
+	BlockID string `json:"-" url:"-"`
+}
+
+type BlocksRetrieveResponse struct {
+	Block
+}
+
+func (b *BlocksRetrieveResponse) UnmarshalJSON(data []byte) error {
+	var decoder blockDecoder
+
+	if err := json.Unmarshal(data, &decoder); err != nil {
+		return fmt.Errorf("failed to unmarshal BlocksRetrieveResponse: %w", err)
+	}
+
+	b.Block = decoder.Block
+
+	return nil
+}
+
+type BlocksUpdateParameters struct {
+	// Identifier for a block
+	BlockID string `json:"-" url:"-"`
+	// The block with the updated content. Only the content of its type is sent, e.g. the text of a paragraph
+	// or the text and the checked state of a to-do. The type of the block cannot be changed.
+	Block Block `json:"-" url:"-"`
+	// Set to true to archive (delete) a block. Set to false to un-archive (restore) a block.
+	Archived *bool `json:"-" url:"-"`
+}
+
+func (b BlocksUpdateParameters) MarshalJSON() ([]byte, error) {
+	body := make(map[string]interface{})
+
+	if b.Block != nil {
+		data, err := json.Marshal(b.Block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal Block: %w", err)
+		}
+
+		var fields map[string]json.RawMessage
+
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("failed to marshal Block: %w", err)
+		}
+
+		var blockType BlockType
+
+		if err := json.Unmarshal(fields["type"], &blockType); err != nil || blockType == "" {
+			return nil, fmt.Errorf("failed to marshal Block: %w", ErrUnknown)
+		}
+
+		if content, ok := fields[string(blockType)]; ok {
+			body[string(blockType)] = content
+		}
+	}
+
+	if b.Archived != nil {
+		body["archived"] = *b.Archived
+	}
+
+	return json.Marshal(body)
+}
+
+type BlocksUpdateResponse struct {
+	Block
+}
+
+func (b *BlocksUpdateResponse) UnmarshalJSON(data []byte) error {
+	var decoder blockDecoder
+
+	if err := json.Unmarshal(data, &decoder); err != nil {
+		return fmt.Errorf("failed to unmarshal BlocksUpdateResponse: %w", err)
+	}
+
+	b.Block = decoder.Block
+
+	return nil
+}
+
+type BlocksDeleteParameters struct {
+	// Identifier for a block
+	BlockID string `json:"-" url:"-"`
+}
+
+type BlocksDeleteResponse struct {
+	Block
+}
+
+func (b *BlocksDeleteResponse) UnmarshalJSON(data []byte) error {
+	var decoder blockDecoder
+
+	if err := json.Unmarshal(data, &decoder); err != nil {
+		return fmt.Errorf("failed to unmarshal BlocksDeleteResponse: %w", err)
+	}
+
+	b.Block = decoder.Block
+
+	return nil
+}
+
 type BlocksInterface interface {
 	Children() BlocksChildrenInterface
+	Tree(ctx context.Context, params BlocksTreeParameters) (*BlocksTreeResponse, error)
+	Retrieve(ctx context.Context, params BlocksRetrieveParameters) (*BlocksRetrieveResponse, error)
+	Update(ctx context.Context, params BlocksUpdateParameters) (*BlocksUpdateResponse, error)
+	Delete(ctx context.Context, params BlocksDeleteParameters) (*BlocksDeleteResponse, error)
 }
 
 type blocksClient struct {
+	restClient     rest.Interface
 	childrenClient *blocksChildrenClient
 }
 
 func newBlocksClient(restClient rest.Interface) *blocksClient {
 	return &blocksClient{
+		restClient:     restClient,
 		childrenClient: newBlocksChildrenClient(restClient),
 	}
 }
@@ -164,6 +655,45 @@ func (b *blocksClient) Children() BlocksChildrenInterface {
 	return b.childrenClient
 }
 
+func (b *blocksClient) Retrieve(ctx context.Context, params BlocksRetrieveParameters) (*BlocksRetrieveResponse, error) {
+	var result BlocksRetrieveResponse
+
+	var failure HTTPError
+
+	err := b.restClient.New().Get().
+		Endpoint(strings.Replace(APIBlocksRetrieveEndpoint, "{block_id}", params.BlockID, 1)).
+		Receive(ctx, &result, &failure)
+
+	return &result, err // nolint:wrapcheck
+}
+
+func (b *blocksClient) Update(ctx context.Context, params BlocksUpdateParameters) (*BlocksUpdateResponse, error) {
+	var result BlocksUpdateResponse
+
+	var failure HTTPError
+
+	err := b.restClient.New().Patch().
+		Idempotent().
+		Endpoint(strings.Replace(APIBlocksUpdateEndpoint, "{block_id}", params.BlockID, 1)).
+		QueryStruct(params).
+		BodyJSON(params).
+		Receive(ctx, &result, &failure)
+
+	return &result, err // nolint:wrapcheck
+}
+
+func (b *blocksClient) Delete(ctx context.Context, params BlocksDeleteParameters) (*BlocksDeleteResponse, error) {
+	var result BlocksDeleteResponse
+
+	var failure HTTPError
+
+	err := b.restClient.New().Delete().
+		Endpoint(strings.Replace(APIBlocksDeleteEndpoint, "{block_id}", params.BlockID, 1)).
+		Receive(ctx, &result, &failure)
+
+	return &result, err // nolint:wrapcheck
+}
+
 type BlocksChildrenListParameters struct {
 	PaginationParameters
 
@@ -222,9 +752,31 @@ func (b *BlocksChildrenAppendResponse) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// BlocksIterator iterates over every child block, starting at the cursor of the parameters.
+type BlocksIterator struct {
+	*pager
+}
+
+// Value returns the current block.
+func (b *BlocksIterator) Value() Block {
+	return b.value.(Block)
+}
+
+// Collect returns all the remaining blocks, or at most maxItems blocks if maxItems is positive.
+func (b *BlocksIterator) Collect(maxItems int) ([]Block, error) {
+	var blocks []Block
+
+	for (maxItems <= 0 || len(blocks) < maxItems) && b.Next() {
+		blocks = append(blocks, b.Value())
+	}
+
+	return blocks, b.Err()
+}
+
 type BlocksChildrenInterface interface {
 	List(ctx context.Context, params BlocksChildrenListParameters) (*BlocksChildrenListResponse, error)
 	Append(ctx context.Context, params BlocksChildrenAppendParameters) (*BlocksChildrenAppendResponse, error)
+	ListAll(ctx context.Context, params BlocksChildrenListParameters) *BlocksIterator
 }
 
 type blocksChildrenClient struct {
@@ -264,12 +816,37 @@ func (b *blocksChildrenClient) Append(ctx context.Context, params BlocksChildren
 	return &result, err // nolint:wrapcheck
 }
 
+func (b *blocksChildrenClient) ListAll(ctx context.Context, params BlocksChildrenListParameters) *BlocksIterator {
+	return NewBlocksIterator(ctx, b, params)
+}
+
+// NewBlocksIterator returns an iterator over every child of a block listed by children.
+func NewBlocksIterator(ctx context.Context, children BlocksChildrenInterface, params BlocksChildrenListParameters) *BlocksIterator {
+	return &BlocksIterator{
+		pager: newPager(ctx, params.StartCursor, func(ctx context.Context, startCursor string) ([]interface{}, PaginatedList, error) {
+			params.StartCursor = startCursor
+
+			resp, err := children.List(ctx, params)
+			if err != nil {
+				return nil, PaginatedList{}, err
+			}
+
+			results := make([]interface{}, 0, len(resp.Results))
+			for _, block := range resp.Results {
+				results = append(results, block)
+			}
+
+			return results, resp.PaginatedList, nil
+		}),
+	}
+}
+
 type blockDecoder struct {
 	Block
 }
 
 // UnmarshalJSON implements json.Unmarshaler
-// nolint: cyclop
+// nolint: cyclop, funlen
 func (b *blockDecoder) UnmarshalJSON(data []byte) error {
 	var decoder struct {
 		Type BlockType `json:"type"`
@@ -307,9 +884,86 @@ func (b *blockDecoder) UnmarshalJSON(data []byte) error {
 	case BlockTypeChildPage:
 		b.Block = &ChildPageBlock{}
 
-	case BlockTypeUnsupported:
+	case BlockTypeChildDatabase:
+		b.Block = &ChildDatabaseBlock{}
+
+	case BlockTypeCallout:
+		b.Block = &CalloutBlock{}
+
+	case BlockTypeQuote:
+		b.Block = &QuoteBlock{}
+
+	case BlockTypeCode:
+		b.Block = &CodeBlock{}
+
+	case BlockTypeImage:
+		b.Block = &ImageBlock{}
+
+	case BlockTypeVideo:
+		b.Block = &VideoBlock{}
+
+	case BlockTypeFile:
+		b.Block = &FileBlock{}
+
+	case BlockTypePDF:
+		b.Block = &PDFBlock{}
+
+	case BlockTypeBookmark:
+		b.Block = &BookmarkBlock{}
+
+	case BlockTypeEmbed:
+		b.Block = &EmbedBlock{}
+
+	case BlockTypeEquation:
+		b.Block = &EquationBlock{}
+
+	case BlockTypeDivider:
+		b.Block = &DividerBlock{}
+
+	case BlockTypeTableOfContents:
+		b.Block = &TableOfContentsBlock{}
+
+	case BlockTypeBreadcrumb:
+		b.Block = &BreadcrumbBlock{}
+
+	case BlockTypeColumnList:
+		b.Block = &ColumnListBlock{}
+
+	case BlockTypeColumn:
+		b.Block = &ColumnBlock{}
+
+	case BlockTypeLinkPreview:
+		b.Block = &LinkPreviewBlock{}
+
+	case BlockTypeSyncedBlock:
+		b.Block = &SyncedBlock{}
+
+	case BlockTypeTemplate:
+		b.Block = &TemplateBlock{}
+
+	case BlockTypeTable:
+		b.Block = &TableBlock{}
+
+	case BlockTypeTableRow:
+		b.Block = &TableRowBlock{}
+
+	case BlockTypeLinkToPage:
+		b.Block = &LinkToPageBlock{}
+
+	default:
+		// Blocks of a type unknown to this client are decoded with their common fields only.
 		b.Block = &UnsupportedBlock{}
 	}
 
 	return json.Unmarshal(data, &b.Block)
 }
+
+func decodeBlocks(decoders []blockDecoder) []Block {
+	blocks := make([]Block, 0, len(decoders))
+
+	for _, decoder := range decoders {
+		blocks = append(blocks, decoder.Block)
+	}
+
+	return blocks
+}
@@ -2,6 +2,7 @@ package notion
 
 import (
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -549,6 +550,822 @@ func Test_blocksChildrenClient_Append(t *testing.T) {
 	}
 }
 
+func Test_blocksClient_Retrieve(t *testing.T) {
+	type fields struct {
+		restClient      rest.Interface
+		mockHTTPHandler http.Handler
+		authToken       string
+	}
+
+	type args struct {
+		ctx    context.Context
+		params BlocksRetrieveParameters
+	}
+
+	type wants struct {
+		response *BlocksRetrieveResponse
+		err      error
+	}
+
+	type test struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}
+
+	tests := []test{
+		{
+			name: "Retrieve a to-do block",
+			fields: fields{
+				restClient: rest.New(),
+				authToken:  "3e83b541-190b-4450-bfcc-835a7804d5b1",
+				mockHTTPHandler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					assert.Equal(t, DefaultNotionVersion, request.Header.Get("Notion-Version"))
+					assert.Equal(t, DefaultUserAgent, request.Header.Get("User-Agent"))
+					assert.Equal(t, "Bearer 3e83b541-190b-4450-bfcc-835a7804d5b1", request.Header.Get("Authorization"))
+
+					assert.Equal(t, http.MethodGet, request.Method)
+					assert.Equal(t, "/v1/blocks/0c940186-ab70-4351-bb34-2d16f0635d49", request.RequestURI)
+
+					writer.WriteHeader(http.StatusOK)
+
+					_, err := writer.Write([]byte(`{
+					  "object": "block",
+					  "id": "0c940186-ab70-4351-bb34-2d16f0635d49",
+					  "created_time": "2021-03-16T16:31:00.000Z",
+					  "last_edited_time": "2021-03-16T16:32:00.000Z",
+					  "has_children": false,
+					  "archived": false,
+					  "type": "to_do",
+					  "to_do": {
+					    "text": [
+					      {
+					        "type": "text",
+					        "text": {
+					          "content": "Buy Lacinato kale",
+					          "link": null
+					        },
+					        "annotations": {
+					          "bold": false,
+					          "italic": false,
+					          "strikethrough": false,
+					          "underline": false,
+					          "code": false,
+					          "color": "default"
+					        },
+					        "plain_text": "Buy Lacinato kale",
+					        "href": null
+					      }
+					    ],
+					    "checked": false
+					  }
+					}`))
+					assert.NoError(t, err)
+				}),
+			},
+			args: args{
+				ctx: context.Background(),
+				params: BlocksRetrieveParameters{
+					BlockID: "0c940186-ab70-4351-bb34-2d16f0635d49",
+				},
+			},
+			wants: wants{
+				response: &BlocksRetrieveResponse{
+					Block: &ToDoBlock{
+						BlockBase: BlockBase{
+							Object:         ObjectTypeBlock,
+							ID:             "0c940186-ab70-4351-bb34-2d16f0635d49",
+							Type:           BlockTypeToDo,
+							CreatedTime:    newTime(time.Date(2021, 3, 16, 16, 31, 0, 0, time.UTC)),
+							LastEditedTime: newTime(time.Date(2021, 3, 16, 16, 32, 0, 0, time.UTC)),
+							HasChildren:    false,
+							Archived:       false,
+						},
+						ToDo: RichTextWithCheckBlock{
+							Text: []RichText{
+								&RichTextText{
+									BaseRichText: BaseRichText{
+										PlainText: "Buy Lacinato kale",
+										Href:      "",
+										Type:      RichTextTypeText,
+										Annotations: &Annotations{
+											Bold:          false,
+											Italic:        false,
+											Strikethrough: false,
+											Underline:     false,
+											Code:          false,
+											Color:         ColorDefault,
+										},
+									},
+									Text: TextObject{
+										Content: "Buy Lacinato kale",
+										Link:    nil,
+									},
+								},
+							},
+							Checked:  false,
+							Children: []Block{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Block not found",
+			fields: fields{
+				restClient: rest.New(),
+				authToken:  "3e83b541-190b-4450-bfcc-835a7804d5b1",
+				mockHTTPHandler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					assert.Equal(t, http.MethodGet, request.Method)
+					assert.Equal(t, "/v1/blocks/5d0e5c0b-3f3c-44b3-8e4a-54d9d5b8fa1e", request.RequestURI)
+
+					writer.WriteHeader(http.StatusNotFound)
+
+					_, err := writer.Write([]byte(`{
+					  "object": "error",
+					  "status": 404,
+					  "code": "object_not_found",
+					  "message": "Could not find block with ID: 5d0e5c0b-3f3c-44b3-8e4a-54d9d5b8fa1e."
+					}`))
+					assert.NoError(t, err)
+				}),
+			},
+			args: args{
+				ctx: context.Background(),
+				params: BlocksRetrieveParameters{
+					BlockID: "5d0e5c0b-3f3c-44b3-8e4a-54d9d5b8fa1e",
+				},
+			},
+			wants: wants{
+				err: ErrObjectNotFound,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPServer := httptest.NewServer(tt.fields.mockHTTPHandler)
+			defer mockHTTPServer.Close()
+
+			sut := New(
+				tt.fields.authToken,
+				WithBaseURL(mockHTTPServer.URL),
+			)
+
+			got, err := sut.Blocks().Retrieve(tt.args.ctx, tt.args.params)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants.response, got)
+		})
+	}
+}
+
+func Test_blocksClient_Update(t *testing.T) {
+	type fields struct {
+		restClient      rest.Interface
+		mockHTTPHandler http.Handler
+		authToken       string
+	}
+
+	type args struct {
+		ctx    context.Context
+		params BlocksUpdateParameters
+	}
+
+	type wants struct {
+		response *BlocksUpdateResponse
+		err      error
+	}
+
+	type test struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}
+
+	tests := []test{
+		{
+			name: "Check a to-do block",
+			fields: fields{
+				restClient: rest.New(),
+				authToken:  "3e83b541-190b-4450-bfcc-835a7804d5b1",
+				mockHTTPHandler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					assert.Equal(t, DefaultNotionVersion, request.Header.Get("Notion-Version"))
+					assert.Equal(t, DefaultUserAgent, request.Header.Get("User-Agent"))
+					assert.Equal(t, "Bearer 3e83b541-190b-4450-bfcc-835a7804d5b1", request.Header.Get("Authorization"))
+
+					assert.Equal(t, http.MethodPatch, request.Method)
+					assert.Equal(t, "/v1/blocks/0c940186-ab70-4351-bb34-2d16f0635d49", request.RequestURI)
+					assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+
+					expectedData := `{
+						"to_do": {
+							"text": [{ "type": "text", "text": { "content": "Buy Lacinato kale" } }],
+							"checked": true
+						}
+					}`
+					b, err := ioutil.ReadAll(request.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, expectedData, string(b))
+
+					writer.WriteHeader(http.StatusOK)
+
+					_, err = writer.Write([]byte(`{
+					  "object": "block",
+					  "id": "0c940186-ab70-4351-bb34-2d16f0635d49",
+					  "created_time": "2021-03-16T16:31:00.000Z",
+					  "last_edited_time": "2021-03-16T16:40:00.000Z",
+					  "has_children": false,
+					  "archived": false,
+					  "type": "to_do",
+					  "to_do": {
+					    "text": [
+					      {
+					        "type": "text",
+					        "text": {
+					          "content": "Buy Lacinato kale",
+					          "link": null
+					        },
+					        "annotations": {
+					          "bold": false,
+					          "italic": false,
+					          "strikethrough": false,
+					          "underline": false,
+					          "code": false,
+					          "color": "default"
+					        },
+					        "plain_text": "Buy Lacinato kale",
+					        "href": null
+					      }
+					    ],
+					    "checked": true
+					  }
+					}`))
+					assert.NoError(t, err)
+				}),
+			},
+			args: args{
+				ctx: context.Background(),
+				params: BlocksUpdateParameters{
+					BlockID: "0c940186-ab70-4351-bb34-2d16f0635d49",
+					Block: &ToDoBlock{
+						BlockBase: BlockBase{
+							Object: ObjectTypeBlock,
+							Type:   BlockTypeToDo,
+						},
+						ToDo: RichTextWithCheckBlock{
+							Text: []RichText{
+								&RichTextText{
+									BaseRichText: BaseRichText{
+										Type: RichTextTypeText,
+									},
+									Text: TextObject{
+										Content: "Buy Lacinato kale",
+									},
+								},
+							},
+							Checked: true,
+						},
+					},
+				},
+			},
+			wants: wants{
+				response: &BlocksUpdateResponse{
+					Block: &ToDoBlock{
+						BlockBase: BlockBase{
+							Object:         ObjectTypeBlock,
+							ID:             "0c940186-ab70-4351-bb34-2d16f0635d49",
+							Type:           BlockTypeToDo,
+							CreatedTime:    newTime(time.Date(2021, 3, 16, 16, 31, 0, 0, time.UTC)),
+							LastEditedTime: newTime(time.Date(2021, 3, 16, 16, 40, 0, 0, time.UTC)),
+							HasChildren:    false,
+							Archived:       false,
+						},
+						ToDo: RichTextWithCheckBlock{
+							Text: []RichText{
+								&RichTextText{
+									BaseRichText: BaseRichText{
+										PlainText: "Buy Lacinato kale",
+										Href:      "",
+										Type:      RichTextTypeText,
+										Annotations: &Annotations{
+											Bold:          false,
+											Italic:        false,
+											Strikethrough: false,
+											Underline:     false,
+											Code:          false,
+											Color:         ColorDefault,
+										},
+									},
+									Text: TextObject{
+										Content: "Buy Lacinato kale",
+										Link:    nil,
+									},
+								},
+							},
+							Checked:  true,
+							Children: []Block{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Restore an archived block",
+			fields: fields{
+				restClient: rest.New(),
+				authToken:  "3e83b541-190b-4450-bfcc-835a7804d5b1",
+				mockHTTPHandler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					assert.Equal(t, http.MethodPatch, request.Method)
+					assert.Equal(t, "/v1/blocks/0c940186-ab70-4351-bb34-2d16f0635d49", request.RequestURI)
+
+					b, err := ioutil.ReadAll(request.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"archived": false}`, string(b))
+
+					writer.WriteHeader(http.StatusOK)
+
+					_, err = writer.Write([]byte(`{
+					  "object": "block",
+					  "id": "0c940186-ab70-4351-bb34-2d16f0635d49",
+					  "has_children": false,
+					  "archived": false,
+					  "type": "unsupported",
+					  "unsupported": {}
+					}`))
+					assert.NoError(t, err)
+				}),
+			},
+			args: args{
+				ctx: context.Background(),
+				params: BlocksUpdateParameters{
+					BlockID:  "0c940186-ab70-4351-bb34-2d16f0635d49",
+					Archived: newBool(false),
+				},
+			},
+			wants: wants{
+				response: &BlocksUpdateResponse{
+					Block: &UnsupportedBlock{
+						BlockBase: BlockBase{
+							Object: ObjectTypeBlock,
+							ID:     "0c940186-ab70-4351-bb34-2d16f0635d49",
+							Type:   BlockTypeUnsupported,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPServer := httptest.NewServer(tt.fields.mockHTTPHandler)
+			defer mockHTTPServer.Close()
+
+			sut := New(
+				tt.fields.authToken,
+				WithBaseURL(mockHTTPServer.URL),
+			)
+
+			got, err := sut.Blocks().Update(tt.args.ctx, tt.args.params)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants.response, got)
+		})
+	}
+}
+
+func Test_blocksClient_Delete(t *testing.T) {
+	type fields struct {
+		restClient      rest.Interface
+		mockHTTPHandler http.Handler
+		authToken       string
+	}
+
+	type args struct {
+		ctx    context.Context
+		params BlocksDeleteParameters
+	}
+
+	type wants struct {
+		response *BlocksDeleteResponse
+		err      error
+	}
+
+	type test struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}
+
+	tests := []test{
+		{
+			name: "Delete a paragraph block",
+			fields: fields{
+				restClient: rest.New(),
+				authToken:  "3e83b541-190b-4450-bfcc-835a7804d5b1",
+				mockHTTPHandler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					assert.Equal(t, DefaultNotionVersion, request.Header.Get("Notion-Version"))
+					assert.Equal(t, DefaultUserAgent, request.Header.Get("User-Agent"))
+					assert.Equal(t, "Bearer 3e83b541-190b-4450-bfcc-835a7804d5b1", request.Header.Get("Authorization"))
+
+					assert.Equal(t, http.MethodDelete, request.Method)
+					assert.Equal(t, "/v1/blocks/7face6fd-3ef4-4b38-b1dc-c5044988eec0", request.RequestURI)
+
+					writer.WriteHeader(http.StatusOK)
+
+					_, err := writer.Write([]byte(`{
+					  "object": "block",
+					  "id": "7face6fd-3ef4-4b38-b1dc-c5044988eec0",
+					  "created_time": "2021-03-16T16:34:00.000Z",
+					  "last_edited_time": "2021-03-16T16:50:00.000Z",
+					  "has_children": false,
+					  "archived": true,
+					  "type": "paragraph",
+					  "paragraph": {
+					    "text": []
+					  }
+					}`))
+					assert.NoError(t, err)
+				}),
+			},
+			args: args{
+				ctx: context.Background(),
+				params: BlocksDeleteParameters{
+					BlockID: "7face6fd-3ef4-4b38-b1dc-c5044988eec0",
+				},
+			},
+			wants: wants{
+				response: &BlocksDeleteResponse{
+					Block: &ParagraphBlock{
+						BlockBase: BlockBase{
+							Object:         ObjectTypeBlock,
+							ID:             "7face6fd-3ef4-4b38-b1dc-c5044988eec0",
+							Type:           BlockTypeParagraph,
+							CreatedTime:    newTime(time.Date(2021, 3, 16, 16, 34, 0, 0, time.UTC)),
+							LastEditedTime: newTime(time.Date(2021, 3, 16, 16, 50, 0, 0, time.UTC)),
+							HasChildren:    false,
+							Archived:       true,
+						},
+						Paragraph: RichTextBlock{
+							Text:     []RichText{},
+							Children: []Block{},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPServer := httptest.NewServer(tt.fields.mockHTTPHandler)
+			defer mockHTTPServer.Close()
+
+			sut := New(
+				tt.fields.authToken,
+				WithBaseURL(mockHTTPServer.URL),
+			)
+
+			got, err := sut.Blocks().Delete(tt.args.ctx, tt.args.params)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants.response, got)
+		})
+	}
+}
+
 func newTime(t time.Time) *time.Time {
 	return &t
 }
+
+func newBool(b bool) *bool {
+	return &b
+}
+
+func Test_blockDecoder(t *testing.T) {
+	text := func(content string) RichText {
+		return &RichTextText{
+			BaseRichText: BaseRichText{Type: RichTextTypeText},
+			Text:         TextObject{Content: content},
+		}
+	}
+
+	paragraph := &ParagraphBlock{
+		BlockBase: BlockBase{Object: ObjectTypeBlock, Type: BlockTypeParagraph},
+		Paragraph: RichTextBlock{Text: []RichText{text("Lacinato kale")}, Children: []Block{}},
+	}
+
+	paragraphJSON := `{"object": "block", "type": "paragraph", "paragraph": {"text": [{"type": "text", "text": {"content": "Lacinato kale"}}]}}`
+
+	tests := []struct {
+		name string
+		data string
+		want Block
+	}{
+		{
+			name: "callout",
+			data: `{
+				"object": "block",
+				"id": "b1",
+				"type": "callout",
+				"callout": {
+					"text": [{"type": "text", "text": {"content": "Kale is healthy"}}],
+					"icon": {"type": "emoji", "emoji": "💡"},
+					"color": "gray_background"
+				}
+			}`,
+			want: &CalloutBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeCallout},
+				Callout: CalloutObject{
+					Text:     []RichText{text("Kale is healthy")},
+					Icon:     &Icon{Type: IconTypeEmoji, Emoji: "💡"},
+					Color:    BackgroundColorGray,
+					Children: []Block{},
+				},
+			},
+		},
+		{
+			name: "quote",
+			data: `{"object": "block", "id": "b1", "type": "quote", "quote": {"text": [{"type": "text", "text": {"content": "Eat your greens"}}]}}`,
+			want: &QuoteBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeQuote},
+				Quote:     RichTextBlock{Text: []RichText{text("Eat your greens")}, Children: []Block{}},
+			},
+		},
+		{
+			name: "code",
+			data: `{"object": "block", "id": "b1", "type": "code", "code": {"text": [{"type": "text", "text": {"content": "fmt.Println(\"kale\")"}}], "language": "go"}}`,
+			want: &CodeBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeCode},
+				Code: CodeObject{
+					Text:     []RichText{text(`fmt.Println("kale")`)},
+					Caption:  []RichText{},
+					Language: "go",
+				},
+			},
+		},
+		{
+			name: "image",
+			data: `{
+				"object": "block",
+				"id": "b1",
+				"type": "image",
+				"image": {
+					"type": "external",
+					"external": {"url": "https://example.com/kale.png"},
+					"caption": [{"type": "text", "text": {"content": "Lacinato kale"}}]
+				}
+			}`,
+			want: &ImageBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeImage},
+				Image: FileObject{
+					Type:     FileTypeExternal,
+					External: &ExternalFile{URL: "https://example.com/kale.png"},
+					Caption:  []RichText{text("Lacinato kale")},
+				},
+			},
+		},
+		{
+			name: "video",
+			data: `{"object": "block", "id": "b1", "type": "video", "video": {"type": "external", "external": {"url": "https://example.com/kale.mp4"}}}`,
+			want: &VideoBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeVideo},
+				Video: FileObject{
+					Type:     FileTypeExternal,
+					External: &ExternalFile{URL: "https://example.com/kale.mp4"},
+					Caption:  []RichText{},
+				},
+			},
+		},
+		{
+			name: "file",
+			data: `{
+				"object": "block",
+				"id": "b1",
+				"type": "file",
+				"file": {"type": "file", "file": {"url": "https://s3.example.com/kale.txt", "expiry_time": "2021-05-19T12:00:00Z"}}
+			}`,
+			want: &FileBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeFile},
+				File: FileObject{
+					Type: FileTypeFile,
+					File: &HostedFile{
+						URL:        "https://s3.example.com/kale.txt",
+						ExpiryTime: newTime(time.Date(2021, 5, 19, 12, 0, 0, 0, time.UTC)),
+					},
+					Caption: []RichText{},
+				},
+			},
+		},
+		{
+			name: "pdf",
+			data: `{"object": "block", "id": "b1", "type": "pdf", "pdf": {"type": "external", "external": {"url": "https://example.com/kale.pdf"}}}`,
+			want: &PDFBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypePDF},
+				PDF: FileObject{
+					Type:     FileTypeExternal,
+					External: &ExternalFile{URL: "https://example.com/kale.pdf"},
+					Caption:  []RichText{},
+				},
+			},
+		},
+		{
+			name: "bookmark",
+			data: `{
+				"object": "block",
+				"id": "b1",
+				"type": "bookmark",
+				"bookmark": {"url": "https://en.wikipedia.org/wiki/Lacinato_kale", "caption": [{"type": "text", "text": {"content": "Wikipedia"}}]}
+			}`,
+			want: &BookmarkBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeBookmark},
+				Bookmark:  URLObject{URL: "https://en.wikipedia.org/wiki/Lacinato_kale", Caption: []RichText{text("Wikipedia")}},
+			},
+		},
+		{
+			name: "embed",
+			data: `{"object": "block", "id": "b1", "type": "embed", "embed": {"url": "https://example.com/kale"}}`,
+			want: &EmbedBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeEmbed},
+				Embed:     URLObject{URL: "https://example.com/kale", Caption: []RichText{}},
+			},
+		},
+		{
+			name: "link_preview",
+			data: `{"object": "block", "id": "b1", "type": "link_preview", "link_preview": {"url": "https://github.com/mkfsn/notion-go"}}`,
+			want: &LinkPreviewBlock{
+				BlockBase:   BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeLinkPreview},
+				LinkPreview: URLObject{URL: "https://github.com/mkfsn/notion-go", Caption: []RichText{}},
+			},
+		},
+		{
+			name: "equation",
+			data: `{"object": "block", "id": "b1", "type": "equation", "equation": {"expression": "e=mc^2"}}`,
+			want: &EquationBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeEquation},
+				Equation:  EquationObject{Expression: "e=mc^2"},
+			},
+		},
+		{
+			name: "divider",
+			data: `{"object": "block", "id": "b1", "type": "divider", "divider": {}}`,
+			want: &DividerBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeDivider},
+			},
+		},
+		{
+			name: "table_of_contents",
+			data: `{"object": "block", "id": "b1", "type": "table_of_contents", "table_of_contents": {}}`,
+			want: &TableOfContentsBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeTableOfContents},
+			},
+		},
+		{
+			name: "breadcrumb",
+			data: `{"object": "block", "id": "b1", "type": "breadcrumb", "breadcrumb": {}}`,
+			want: &BreadcrumbBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeBreadcrumb},
+			},
+		},
+		{
+			name: "column_list",
+			data: `{
+				"object": "block",
+				"id": "b1",
+				"type": "column_list",
+				"has_children": true,
+				"column_list": {
+					"children": [{"object": "block", "type": "column", "column": {"children": [` + paragraphJSON + `]}}]
+				}
+			}`,
+			want: &ColumnListBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeColumnList, HasChildren: true},
+				ColumnList: ChildrenObject{
+					Children: []Block{
+						&ColumnBlock{
+							BlockBase: BlockBase{Object: ObjectTypeBlock, Type: BlockTypeColumn},
+							Column:    ChildrenObject{Children: []Block{paragraph}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "synced_block original",
+			data: `{"object": "block", "id": "b1", "type": "synced_block", "synced_block": {"synced_from": null, "children": [` + paragraphJSON + `]}}`,
+			want: &SyncedBlock{
+				BlockBase:   BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeSyncedBlock},
+				SyncedBlock: SyncedBlockObject{SyncedFrom: nil, Children: []Block{paragraph}},
+			},
+		},
+		{
+			name: "synced_block reference",
+			data: `{"object": "block", "id": "b2", "type": "synced_block", "synced_block": {"synced_from": {"type": "block_id", "block_id": "b1"}}}`,
+			want: &SyncedBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b2", Type: BlockTypeSyncedBlock},
+				SyncedBlock: SyncedBlockObject{
+					SyncedFrom: &SyncedFrom{Type: "block_id", BlockID: "b1"},
+					Children:   []Block{},
+				},
+			},
+		},
+		{
+			name: "template",
+			data: `{
+				"object": "block",
+				"id": "b1",
+				"type": "template",
+				"template": {"text": [{"type": "text", "text": {"content": "Add a recipe"}}], "children": [` + paragraphJSON + `]}
+			}`,
+			want: &TemplateBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeTemplate},
+				Template:  RichTextBlock{Text: []RichText{text("Add a recipe")}, Children: []Block{paragraph}},
+			},
+		},
+		{
+			name: "table",
+			data: `{
+				"object": "block",
+				"id": "b1",
+				"type": "table",
+				"table": {
+					"table_width": 2,
+					"has_column_header": true,
+					"has_row_header": false,
+					"children": [{
+						"object": "block",
+						"type": "table_row",
+						"table_row": {"cells": [[{"type": "text", "text": {"content": "Name"}}], [{"type": "text", "text": {"content": "Price"}}]]}
+					}]
+				}
+			}`,
+			want: &TableBlock{
+				BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeTable},
+				Table: TableObject{
+					TableWidth:      2,
+					HasColumnHeader: true,
+					HasRowHeader:    false,
+					Children: []Block{
+						&TableRowBlock{
+							BlockBase: BlockBase{Object: ObjectTypeBlock, Type: BlockTypeTableRow},
+							TableRow:  TableRowObject{Cells: [][]RichText{{text("Name")}, {text("Price")}}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "child_database",
+			data: `{"object": "block", "id": "b1", "type": "child_database", "child_database": {"title": "Grocery List"}}`,
+			want: &ChildDatabaseBlock{
+				BlockBase:     BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeChildDatabase},
+				ChildDatabase: TitleBlock{Title: "Grocery List"},
+			},
+		},
+		{
+			name: "link_to_page",
+			data: `{"object": "block", "id": "b1", "type": "link_to_page", "link_to_page": {"type": "page_id", "page_id": "p1"}}`,
+			want: &LinkToPageBlock{
+				BlockBase:  BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockTypeLinkToPage},
+				LinkToPage: LinkToPageObject{Type: LinkToPageTypePage, PageID: "p1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoder blockDecoder
+
+			assert.NoError(t, json.Unmarshal([]byte(tt.data), &decoder))
+			assert.Equal(t, tt.want, decoder.Block)
+
+			b, err := json.Marshal(decoder.Block)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.data, string(b))
+		})
+	}
+}
+
+func Test_blockDecoder_UnknownType(t *testing.T) {
+	var decoder blockDecoder
+
+	err := json.Unmarshal([]byte(`{"object": "block", "id": "b1", "type": "ai_block", "ai_block": {}}`), &decoder)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &UnsupportedBlock{
+		BlockBase: BlockBase{Object: ObjectTypeBlock, ID: "b1", Type: BlockType("ai_block")},
+	}, decoder.Block)
+}
@@ -0,0 +1,179 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type BlocksTreeParameters struct {
+	// Identifier for the root block or page
+	BlockID string
+	// The maximum depth of the tree, the children of the root block are at depth 1. Zero means no limit.
+	MaxDepth int
+	// The maximum number of concurrent requests. Defaults to 1.
+	Concurrency int
+	// The number of children fetched per request. Maximum: 100
+	PageSize int32
+	// Whether to skip the content of child pages.
+	SkipChildPages bool
+	// Whether to skip the content of child databases.
+	SkipChildDatabases bool
+}
+
+// BlockNode is a block of a tree with its children attached.
+type BlockNode struct {
+	Block Block
+	// The children of the block, empty if the block has no children or they were not fetched.
+	Children []*BlockNode
+	// The error which occurred while fetching the children, if any.
+	Err error
+}
+
+type BlocksTreeResponse struct {
+	// The children of the root block.
+	Children []*BlockNode
+}
+
+// BlockTreeFailure is a subtree which could not be fetched.
+type BlockTreeFailure struct {
+	BlockID string
+	Err     error
+}
+
+// BlocksTreeError is returned with a partial tree when some subtrees could not be fetched.
+// The nodes whose children are missing have their Err set.
+type BlocksTreeError struct {
+	Failures []BlockTreeFailure
+}
+
+func (e *BlocksTreeError) Error() string {
+	messages := make([]string, 0, len(e.Failures))
+
+	for _, failure := range e.Failures {
+		messages = append(messages, fmt.Sprintf("%s: %s", failure.BlockID, failure.Err))
+	}
+
+	return fmt.Sprintf("failed to fetch %d subtrees: %s", len(e.Failures), strings.Join(messages, "; "))
+}
+
+// Tree fetches the children of a block recursively. The root children are always fetched, an error is returned
+// without a tree if they cannot be fetched. When deeper subtrees fail, the partial tree is returned
+// along with a *BlocksTreeError.
+func (b *blocksClient) Tree(ctx context.Context, params BlocksTreeParameters) (*BlocksTreeResponse, error) {
+	return FetchBlocksTree(ctx, b.childrenClient, params)
+}
+
+// FetchBlocksTree fetches the children of a block recursively with client, as BlocksInterface.Tree does.
+func FetchBlocksTree(ctx context.Context, client BlocksChildrenInterface, params BlocksTreeParameters) (*BlocksTreeResponse, error) {
+	concurrency := params.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	walker := &blocksTreeWalker{
+		client:    client,
+		params:    params,
+		semaphore: make(chan struct{}, concurrency),
+	}
+
+	children, err := walker.walk(ctx, params.BlockID, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	walker.wg.Wait()
+
+	if len(walker.failures) > 0 {
+		return &BlocksTreeResponse{Children: children}, &BlocksTreeError{Failures: walker.failures}
+	}
+
+	return &BlocksTreeResponse{Children: children}, nil
+}
+
+type blocksTreeWalker struct {
+	client    BlocksChildrenInterface
+	params    BlocksTreeParameters
+	semaphore chan struct{}
+	wg        sync.WaitGroup
+
+	mu       sync.Mutex
+	failures []BlockTreeFailure
+}
+
+// walk fetches the children of the block, which are at the given depth, and walks their subtrees in the background.
+func (w *blocksTreeWalker) walk(ctx context.Context, blockID string, depth int) ([]*BlockNode, error) {
+	blocks, err := w.fetch(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*BlockNode, 0, len(blocks))
+
+	for _, block := range blocks {
+		node := &BlockNode{Block: block}
+		nodes = append(nodes, node)
+
+		if !w.shouldExpand(block, depth) {
+			continue
+		}
+
+		w.wg.Add(1)
+
+		go func(node *BlockNode) {
+			defer w.wg.Done()
+
+			id := node.Block.GetBase().ID
+
+			node.Children, node.Err = w.walk(ctx, id, depth+1)
+			if node.Err != nil {
+				w.fail(id, node.Err)
+			}
+		}(node)
+	}
+
+	return nodes, nil
+}
+
+func (w *blocksTreeWalker) fetch(ctx context.Context, blockID string) ([]Block, error) {
+	select {
+	case w.semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err() // nolint:wrapcheck
+	}
+
+	defer func() { <-w.semaphore }()
+
+	iterator := w.client.ListAll(ctx, BlocksChildrenListParameters{
+		PaginationParameters: PaginationParameters{PageSize: w.params.PageSize},
+		BlockID:              blockID,
+	})
+
+	return iterator.Collect(0)
+}
+
+func (w *blocksTreeWalker) shouldExpand(block Block, depth int) bool {
+	base := block.GetBase()
+
+	if !base.HasChildren || (w.params.MaxDepth > 0 && depth >= w.params.MaxDepth) {
+		return false
+	}
+
+	switch base.Type {
+	case BlockTypeChildPage:
+		return !w.params.SkipChildPages
+
+	case BlockTypeChildDatabase:
+		return !w.params.SkipChildDatabases
+	}
+
+	return true
+}
+
+func (w *blocksTreeWalker) fail(blockID string, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.failures = append(w.failures, BlockTreeFailure{BlockID: blockID, Err: err})
+}
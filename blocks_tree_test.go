@@ -0,0 +1,159 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newBlocksTreeHandler serves the children of each block, a block without entry fails with an internal server error.
+func newBlocksTreeHandler(t *testing.T, children map[string][]string, requested *sync.Map) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodGet, request.Method)
+
+		blockID := strings.TrimSuffix(strings.TrimPrefix(request.URL.Path, "/v1/blocks/"), "/children")
+		requested.Store(blockID, true)
+
+		blocks, ok := children[blockID]
+		if !ok {
+			writer.WriteHeader(http.StatusInternalServerError)
+			_, _ = writer.Write([]byte(`{"object": "error", "code": "internal_server_error", "message": "Unexpected error."}`))
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(writer, `{"object": "list", "results": [%s], "next_cursor": null, "has_more": false}`, strings.Join(blocks, ","))
+	})
+}
+
+func treeBlockJSON(id string, blockType BlockType, hasChildren bool) string {
+	return fmt.Sprintf(`{"object": "block", "id": %q, "type": %q, "has_children": %t, %q: {}}`, id, blockType, hasChildren, blockType)
+}
+
+// treeIDs describes a tree as nested lists of block IDs, e.g. "a(b c(d))".
+func treeIDs(nodes []*BlockNode) string {
+	ids := make([]string, 0, len(nodes))
+
+	for _, node := range nodes {
+		id := node.Block.GetBase().ID
+		if len(node.Children) > 0 {
+			id += "(" + treeIDs(node.Children) + ")"
+		}
+
+		ids = append(ids, id)
+	}
+
+	return strings.Join(ids, " ")
+}
+
+func Test_blocksClient_Tree(t *testing.T) {
+	children := map[string][]string{
+		"root": {
+			treeBlockJSON("a", BlockTypeToggle, true),
+			treeBlockJSON("b", BlockTypeDivider, false),
+			treeBlockJSON("c", BlockTypeChildPage, true),
+			treeBlockJSON("d", BlockTypeColumnList, true),
+		},
+		"a":  {treeBlockJSON("a1", BlockTypeToggle, true)},
+		"a1": {treeBlockJSON("a11", BlockTypeDivider, false)},
+		"c":  {treeBlockJSON("c1", BlockTypeDivider, false)},
+		"d":  {treeBlockJSON("d1", BlockTypeColumn, true), treeBlockJSON("d2", BlockTypeColumn, true)},
+		"d1": {treeBlockJSON("d11", BlockTypeDivider, false)},
+		"d2": {treeBlockJSON("d21", BlockTypeDivider, false)},
+	}
+
+	tests := []struct {
+		name          string
+		params        BlocksTreeParameters
+		wantTree      string
+		wantRequested []string
+	}{
+		{
+			name:          "Fetch the whole tree",
+			params:        BlocksTreeParameters{BlockID: "root", Concurrency: 3},
+			wantTree:      "a(a1(a11)) b c(c1) d(d1(d11) d2(d21))",
+			wantRequested: []string{"a", "a1", "c", "d", "d1", "d2", "root"},
+		},
+		{
+			name:          "Stop at the maximum depth",
+			params:        BlocksTreeParameters{BlockID: "root", MaxDepth: 2},
+			wantTree:      "a(a1) b c(c1) d(d1 d2)",
+			wantRequested: []string{"a", "c", "d", "root"},
+		},
+		{
+			name:          "Skip the content of child pages",
+			params:        BlocksTreeParameters{BlockID: "root", MaxDepth: 2, SkipChildPages: true},
+			wantTree:      "a(a1) b c d(d1 d2)",
+			wantRequested: []string{"a", "d", "root"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested sync.Map
+
+			mockHTTPServer := httptest.NewServer(newBlocksTreeHandler(t, children, &requested))
+			defer mockHTTPServer.Close()
+
+			sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+			got, err := sut.Blocks().Tree(context.Background(), tt.params)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTree, treeIDs(got.Children))
+
+			var requestedIDs []string
+
+			requested.Range(func(key, value interface{}) bool {
+				requestedIDs = append(requestedIDs, key.(string))
+				return true
+			})
+
+			assert.ElementsMatch(t, tt.wantRequested, requestedIDs)
+		})
+	}
+}
+
+func Test_blocksClient_TreePartialFailure(t *testing.T) {
+	var requested sync.Map
+
+	mockHTTPServer := httptest.NewServer(newBlocksTreeHandler(t, map[string][]string{
+		"root": {treeBlockJSON("a", BlockTypeToggle, true), treeBlockJSON("broken", BlockTypeToggle, true)},
+		"a":    {treeBlockJSON("a1", BlockTypeDivider, false)},
+	}, &requested))
+	defer mockHTTPServer.Close()
+
+	sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+	got, err := sut.Blocks().Tree(context.Background(), BlocksTreeParameters{BlockID: "root", Concurrency: 2})
+
+	var treeError *BlocksTreeError
+
+	assert.ErrorAs(t, err, &treeError)
+	assert.Len(t, treeError.Failures, 1)
+	assert.Equal(t, "broken", treeError.Failures[0].BlockID)
+	assert.ErrorIs(t, treeError.Failures[0].Err, ErrInternalServerError)
+
+	assert.Equal(t, "a(a1) broken", treeIDs(got.Children))
+	assert.ErrorIs(t, got.Children[1].Err, ErrInternalServerError)
+}
+
+func Test_blocksClient_TreeRootFailure(t *testing.T) {
+	var requested sync.Map
+
+	mockHTTPServer := httptest.NewServer(newBlocksTreeHandler(t, map[string][]string{}, &requested))
+	defer mockHTTPServer.Close()
+
+	sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+	got, err := sut.Blocks().Tree(context.Background(), BlocksTreeParameters{BlockID: "root"})
+
+	assert.Nil(t, got)
+	assert.ErrorIs(t, err, ErrInternalServerError)
+}
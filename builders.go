@@ -0,0 +1,568 @@
+package notion
+
+// Text returns a text rich text with the given content, e.g. Text("Lacinato kale").Bold().Link(url).
+func Text(content string) RichTextText {
+	return RichTextText{
+		BaseRichText: BaseRichText{Type: RichTextTypeText},
+		Text:         TextObject{Content: content},
+	}
+}
+
+// Bold returns a copy of the rich text in bold.
+func (r RichTextText) Bold() RichTextText {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Bold = true })
+
+	return r
+}
+
+// Italic returns a copy of the rich text in italic.
+func (r RichTextText) Italic() RichTextText {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Italic = true })
+
+	return r
+}
+
+// Strikethrough returns a copy of the rich text struck through.
+func (r RichTextText) Strikethrough() RichTextText {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Strikethrough = true })
+
+	return r
+}
+
+// Underline returns a copy of the rich text underlined.
+func (r RichTextText) Underline() RichTextText {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Underline = true })
+
+	return r
+}
+
+// Code returns a copy of the rich text in code style.
+func (r RichTextText) Code() RichTextText {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Code = true })
+
+	return r
+}
+
+// Color returns a copy of the rich text with the given text or background color.
+func (r RichTextText) Color(color Color) RichTextText {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Color = color })
+
+	return r
+}
+
+// Link returns a copy of the rich text linking to the URL.
+func (r RichTextText) Link(url string) RichTextText {
+	r.Text.Link = &Link{URL: url}
+
+	return r
+}
+
+// InlineEquation returns an equation rich text, the expression is a KaTeX compatible string.
+func InlineEquation(expression string) RichTextEquation {
+	return RichTextEquation{
+		BaseRichText: BaseRichText{Type: RichTextTypeEquation},
+		Equation:     EquationObject{Expression: expression},
+	}
+}
+
+// Bold returns a copy of the rich text in bold.
+func (r RichTextEquation) Bold() RichTextEquation {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Bold = true })
+
+	return r
+}
+
+// Italic returns a copy of the rich text in italic.
+func (r RichTextEquation) Italic() RichTextEquation {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Italic = true })
+
+	return r
+}
+
+// Strikethrough returns a copy of the rich text struck through.
+func (r RichTextEquation) Strikethrough() RichTextEquation {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Strikethrough = true })
+
+	return r
+}
+
+// Underline returns a copy of the rich text underlined.
+func (r RichTextEquation) Underline() RichTextEquation {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Underline = true })
+
+	return r
+}
+
+// Color returns a copy of the rich text with the given text or background color.
+func (r RichTextEquation) Color(color Color) RichTextEquation {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Color = color })
+
+	return r
+}
+
+// MentionUser returns a rich text mentioning the user with the given ID.
+func MentionUser(userID string) RichTextMention {
+	return mention(UserMention{
+		baseMention: baseMention{Type: MentionTypeUser},
+		User:        PartialUser{Object: ObjectTypeUser, ID: userID},
+	})
+}
+
+// MentionPage returns a rich text mentioning the page with the given ID.
+func MentionPage(pageID string) RichTextMention {
+	m := PageMention{baseMention: baseMention{Type: MentionTypePage}}
+	m.Page.ID = pageID
+
+	return mention(m)
+}
+
+// MentionDatabase returns a rich text mentioning the database with the given ID.
+func MentionDatabase(databaseID string) RichTextMention {
+	m := DatabaseMention{baseMention: baseMention{Type: MentionTypeDatabase}}
+	m.Database.ID = databaseID
+
+	return mention(m)
+}
+
+// MentionDate returns a rich text mentioning a date, or a date range if end is not nil.
+// Dates are ISO 8601 strings, with or without a time.
+func MentionDate(start string, end *string) RichTextMention {
+	return mention(DateMention{
+		baseMention: baseMention{Type: MentionTypeDate},
+		Date:        Date{Start: start, End: end},
+	})
+}
+
+func mention(m Mention) RichTextMention {
+	return RichTextMention{BaseRichText: BaseRichText{Type: RichTextTypeMention}, Mention: m}
+}
+
+// Bold returns a copy of the rich text in bold.
+func (r RichTextMention) Bold() RichTextMention {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Bold = true })
+
+	return r
+}
+
+// Italic returns a copy of the rich text in italic.
+func (r RichTextMention) Italic() RichTextMention {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Italic = true })
+
+	return r
+}
+
+// Strikethrough returns a copy of the rich text struck through.
+func (r RichTextMention) Strikethrough() RichTextMention {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Strikethrough = true })
+
+	return r
+}
+
+// Underline returns a copy of the rich text underlined.
+func (r RichTextMention) Underline() RichTextMention {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Underline = true })
+
+	return r
+}
+
+// Color returns a copy of the rich text with the given text or background color.
+func (r RichTextMention) Color(color Color) RichTextMention {
+	r.BaseRichText = r.annotate(func(a *Annotations) { a.Color = color })
+
+	return r
+}
+
+// annotate returns a copy of the base with updated annotations. The annotations are copied so that rich texts
+// built from the same value do not share them.
+func (r BaseRichText) annotate(update func(a *Annotations)) BaseRichText {
+	annotations := Annotations{Color: ColorDefault}
+	if r.Annotations != nil {
+		annotations = *r.Annotations
+	}
+
+	update(&annotations)
+	r.Annotations = &annotations
+
+	return r
+}
+
+// Paragraph returns a paragraph block.
+func Paragraph(text ...RichText) ParagraphBlock {
+	return ParagraphBlock{
+		BlockBase: newBlockBase(BlockTypeParagraph),
+		Paragraph: RichTextBlock{Text: richTexts(text)},
+	}
+}
+
+// WithChildren returns a copy of the block with the children appended to its children.
+func (b ParagraphBlock) WithChildren(children ...Block) ParagraphBlock {
+	b.Paragraph.Children = appendBlocks(b.Paragraph.Children, children)
+
+	return b
+}
+
+// Heading1 returns a heading block of the first level.
+func Heading1(text ...RichText) Heading1Block {
+	return Heading1Block{BlockBase: newBlockBase(BlockTypeHeading1), Heading1: HeadingBlock{Text: richTexts(text)}}
+}
+
+// Heading2 returns a heading block of the second level.
+func Heading2(text ...RichText) Heading2Block {
+	return Heading2Block{BlockBase: newBlockBase(BlockTypeHeading2), Heading2: HeadingBlock{Text: richTexts(text)}}
+}
+
+// Heading3 returns a heading block of the third level.
+func Heading3(text ...RichText) Heading3Block {
+	return Heading3Block{BlockBase: newBlockBase(BlockTypeHeading3), Heading3: HeadingBlock{Text: richTexts(text)}}
+}
+
+// BulletedListItem returns a bulleted list item block.
+func BulletedListItem(text ...RichText) BulletedListItemBlock {
+	return BulletedListItemBlock{
+		BlockBase:        newBlockBase(BlockTypeBulletedListItem),
+		BulletedListItem: RichTextBlock{Text: richTexts(text)},
+	}
+}
+
+// WithChildren returns a copy of the block with the children appended to its children.
+func (b BulletedListItemBlock) WithChildren(children ...Block) BulletedListItemBlock {
+	b.BulletedListItem.Children = appendBlocks(b.BulletedListItem.Children, children)
+
+	return b
+}
+
+// NumberedListItem returns a numbered list item block.
+func NumberedListItem(text ...RichText) NumberedListItemBlock {
+	return NumberedListItemBlock{
+		BlockBase:        newBlockBase(BlockTypeNumberedListItem),
+		NumberedListItem: RichTextBlock{Text: richTexts(text)},
+	}
+}
+
+// WithChildren returns a copy of the block with the children appended to its children.
+func (b NumberedListItemBlock) WithChildren(children ...Block) NumberedListItemBlock {
+	b.NumberedListItem.Children = appendBlocks(b.NumberedListItem.Children, children)
+
+	return b
+}
+
+// ToDo returns a to do block.
+func ToDo(checked bool, text ...RichText) ToDoBlock {
+	return ToDoBlock{
+		BlockBase: newBlockBase(BlockTypeToDo),
+		ToDo:      RichTextWithCheckBlock{Text: richTexts(text), Checked: checked},
+	}
+}
+
+// WithChildren returns a copy of the block with the children appended to its children.
+func (b ToDoBlock) WithChildren(children ...Block) ToDoBlock {
+	b.ToDo.Children = appendBlocks(b.ToDo.Children, children)
+
+	return b
+}
+
+// Toggle returns a toggle block, whose content is set with WithChildren.
+func Toggle(text ...RichText) ToggleBlock {
+	return ToggleBlock{BlockBase: newBlockBase(BlockTypeToggle), Toggle: RichTextBlock{Text: richTexts(text)}}
+}
+
+// WithChildren returns a copy of the block with the children appended to its children.
+func (b ToggleBlock) WithChildren(children ...Block) ToggleBlock {
+	b.Toggle.Children = appendBlocks(b.Toggle.Children, children)
+
+	return b
+}
+
+// ChildPage returns a child page block. Child pages are created with Pages().Create, not as blocks.
+func ChildPage(title string) ChildPageBlock {
+	return ChildPageBlock{BlockBase: newBlockBase(BlockTypeChildPage), ChildPage: TitleBlock{Title: title}}
+}
+
+// ChildDatabase returns a child database block. Child databases cannot be created as blocks.
+func ChildDatabase(title string) ChildDatabaseBlock {
+	return ChildDatabaseBlock{BlockBase: newBlockBase(BlockTypeChildDatabase), ChildDatabase: TitleBlock{Title: title}}
+}
+
+// Callout returns a callout block.
+func Callout(text ...RichText) CalloutBlock {
+	return CalloutBlock{BlockBase: newBlockBase(BlockTypeCallout), Callout: CalloutObject{Text: richTexts(text)}}
+}
+
+// WithIcon returns a copy of the block with the icon, e.g. EmojiIcon("💡").
+func (b CalloutBlock) WithIcon(icon Icon) CalloutBlock {
+	b.Callout.Icon = &icon
+
+	return b
+}
+
+// WithColor returns a copy of the block with the given text or background color.
+func (b CalloutBlock) WithColor(color Color) CalloutBlock {
+	b.Callout.Color = color
+
+	return b
+}
+
+// WithChildren returns a copy of the block with the children appended to its children.
+func (b CalloutBlock) WithChildren(children ...Block) CalloutBlock {
+	b.Callout.Children = appendBlocks(b.Callout.Children, children)
+
+	return b
+}
+
+// EmojiIcon returns an emoji icon.
+func EmojiIcon(emoji string) Icon {
+	return Icon{Type: IconTypeEmoji, Emoji: emoji}
+}
+
+// ExternalIcon returns an icon hosted at the URL.
+func ExternalIcon(url string) Icon {
+	return Icon{Type: IconTypeExternal, External: &ExternalFile{URL: url}}
+}
+
+// Quote returns a quote block.
+func Quote(text ...RichText) QuoteBlock {
+	return QuoteBlock{BlockBase: newBlockBase(BlockTypeQuote), Quote: RichTextBlock{Text: richTexts(text)}}
+}
+
+// WithChildren returns a copy of the block with the children appended to its children.
+func (b QuoteBlock) WithChildren(children ...Block) QuoteBlock {
+	b.Quote.Children = appendBlocks(b.Quote.Children, children)
+
+	return b
+}
+
+// Code returns a code block in the given language, e.g. "go" or "plain text".
+func Code(language string, text ...RichText) CodeBlock {
+	return CodeBlock{BlockBase: newBlockBase(BlockTypeCode), Code: CodeObject{Text: richTexts(text), Language: language}}
+}
+
+// WithCaption returns a copy of the block with the caption.
+func (b CodeBlock) WithCaption(caption ...RichText) CodeBlock {
+	b.Code.Caption = caption
+
+	return b
+}
+
+// Image returns an image block of an external image.
+func Image(url string) ImageBlock {
+	return ImageBlock{BlockBase: newBlockBase(BlockTypeImage), Image: externalFile(url)}
+}
+
+// WithCaption returns a copy of the block with the caption.
+func (b ImageBlock) WithCaption(caption ...RichText) ImageBlock {
+	b.Image.Caption = caption
+
+	return b
+}
+
+// Video returns a video block of an external video.
+func Video(url string) VideoBlock {
+	return VideoBlock{BlockBase: newBlockBase(BlockTypeVideo), Video: externalFile(url)}
+}
+
+// WithCaption returns a copy of the block with the caption.
+func (b VideoBlock) WithCaption(caption ...RichText) VideoBlock {
+	b.Video.Caption = caption
+
+	return b
+}
+
+// Attachment returns a file block of an external file.
+func Attachment(url string) FileBlock {
+	return FileBlock{BlockBase: newBlockBase(BlockTypeFile), File: externalFile(url)}
+}
+
+// WithCaption returns a copy of the block with the caption.
+func (b FileBlock) WithCaption(caption ...RichText) FileBlock {
+	b.File.Caption = caption
+
+	return b
+}
+
+// PDF returns a PDF block of an external PDF.
+func PDF(url string) PDFBlock {
+	return PDFBlock{BlockBase: newBlockBase(BlockTypePDF), PDF: externalFile(url)}
+}
+
+// WithCaption returns a copy of the block with the caption.
+func (b PDFBlock) WithCaption(caption ...RichText) PDFBlock {
+	b.PDF.Caption = caption
+
+	return b
+}
+
+// Bookmark returns a bookmark block.
+func Bookmark(url string) BookmarkBlock {
+	return BookmarkBlock{BlockBase: newBlockBase(BlockTypeBookmark), Bookmark: URLObject{URL: url}}
+}
+
+// WithCaption returns a copy of the block with the caption.
+func (b BookmarkBlock) WithCaption(caption ...RichText) BookmarkBlock {
+	b.Bookmark.Caption = caption
+
+	return b
+}
+
+// Embed returns an embed block.
+func Embed(url string) EmbedBlock {
+	return EmbedBlock{BlockBase: newBlockBase(BlockTypeEmbed), Embed: URLObject{URL: url}}
+}
+
+// WithCaption returns a copy of the block with the caption.
+func (b EmbedBlock) WithCaption(caption ...RichText) EmbedBlock {
+	b.Embed.Caption = caption
+
+	return b
+}
+
+// LinkPreview returns a link preview block. Link previews cannot be created as blocks.
+func LinkPreview(url string) LinkPreviewBlock {
+	return LinkPreviewBlock{BlockBase: newBlockBase(BlockTypeLinkPreview), LinkPreview: URLObject{URL: url}}
+}
+
+// Equation returns an equation block, the expression is a KaTeX compatible string.
+func Equation(expression string) EquationBlock {
+	return EquationBlock{BlockBase: newBlockBase(BlockTypeEquation), Equation: EquationObject{Expression: expression}}
+}
+
+// Divider returns a divider block.
+func Divider() DividerBlock {
+	return DividerBlock{BlockBase: newBlockBase(BlockTypeDivider)}
+}
+
+// TableOfContents returns a table of contents block.
+func TableOfContents() TableOfContentsBlock {
+	return TableOfContentsBlock{BlockBase: newBlockBase(BlockTypeTableOfContents)}
+}
+
+// Breadcrumb returns a breadcrumb block.
+func Breadcrumb() BreadcrumbBlock {
+	return BreadcrumbBlock{BlockBase: newBlockBase(BlockTypeBreadcrumb)}
+}
+
+// ColumnList returns a column list block with the columns.
+func ColumnList(columns ...ColumnBlock) ColumnListBlock {
+	children := make([]Block, 0, len(columns))
+	for _, column := range columns {
+		children = append(children, column)
+	}
+
+	return ColumnListBlock{BlockBase: newBlockBase(BlockTypeColumnList), ColumnList: ChildrenObject{Children: children}}
+}
+
+// Column returns a column block with the children as its content.
+func Column(children ...Block) ColumnBlock {
+	return ColumnBlock{BlockBase: newBlockBase(BlockTypeColumn), Column: ChildrenObject{Children: children}}
+}
+
+// Synced returns an original synced block with the children as its content.
+func Synced(children ...Block) SyncedBlock {
+	return SyncedBlock{BlockBase: newBlockBase(BlockTypeSyncedBlock), SyncedBlock: SyncedBlockObject{Children: children}}
+}
+
+// SyncedCopy returns a synced block duplicating the content of the original synced block with the given ID.
+func SyncedCopy(blockID string) SyncedBlock {
+	return SyncedBlock{
+		BlockBase:   newBlockBase(BlockTypeSyncedBlock),
+		SyncedBlock: SyncedBlockObject{SyncedFrom: &SyncedFrom{Type: "block_id", BlockID: blockID}},
+	}
+}
+
+// Template returns a template block, whose content is set with WithChildren.
+func Template(text ...RichText) TemplateBlock {
+	return TemplateBlock{BlockBase: newBlockBase(BlockTypeTemplate), Template: RichTextBlock{Text: richTexts(text)}}
+}
+
+// WithChildren returns a copy of the block with the children appended to its children.
+func (b TemplateBlock) WithChildren(children ...Block) TemplateBlock {
+	b.Template.Children = appendBlocks(b.Template.Children, children)
+
+	return b
+}
+
+// Table returns a table block with the rows, its width is the number of cells of the widest row.
+func Table(rows ...TableRowBlock) TableBlock {
+	width := 0
+	children := make([]Block, 0, len(rows))
+
+	for _, row := range rows {
+		if len(row.TableRow.Cells) > width {
+			width = len(row.TableRow.Cells)
+		}
+
+		children = append(children, row)
+	}
+
+	return TableBlock{
+		BlockBase: newBlockBase(BlockTypeTable),
+		Table:     TableObject{TableWidth: width, Children: children},
+	}
+}
+
+// WithColumnHeader returns a copy of the block using its first row as the header of the columns.
+func (b TableBlock) WithColumnHeader() TableBlock {
+	b.Table.HasColumnHeader = true
+
+	return b
+}
+
+// WithRowHeader returns a copy of the block using the first cell of every row as the header of the row.
+func (b TableBlock) WithRowHeader() TableBlock {
+	b.Table.HasRowHeader = true
+
+	return b
+}
+
+// TableRow returns a table row block, each cell is made of a single rich text. Use TableRowBlock directly
+// for cells made of several rich texts.
+func TableRow(cells ...RichText) TableRowBlock {
+	row := make([][]RichText, 0, len(cells))
+	for _, cell := range cells {
+		row = append(row, []RichText{cell})
+	}
+
+	return TableRowBlock{BlockBase: newBlockBase(BlockTypeTableRow), TableRow: TableRowObject{Cells: row}}
+}
+
+// LinkToPage returns a block linking to the page with the given ID.
+func LinkToPage(pageID string) LinkToPageBlock {
+	return LinkToPageBlock{
+		BlockBase:  newBlockBase(BlockTypeLinkToPage),
+		LinkToPage: LinkToPageObject{Type: LinkToPageTypePage, PageID: pageID},
+	}
+}
+
+// LinkToDatabase returns a block linking to the database with the given ID.
+func LinkToDatabase(databaseID string) LinkToPageBlock {
+	return LinkToPageBlock{
+		BlockBase:  newBlockBase(BlockTypeLinkToPage),
+		LinkToPage: LinkToPageObject{Type: LinkToPageTypeDatabase, DatabaseID: databaseID},
+	}
+}
+
+func newBlockBase(blockType BlockType) BlockBase {
+	return BlockBase{Object: ObjectTypeBlock, Type: blockType}
+}
+
+func externalFile(url string) FileObject {
+	return FileObject{Type: FileTypeExternal, External: &ExternalFile{URL: url}}
+}
+
+// richTexts returns the rich texts, or an empty slice so that the text of a block is never encoded as null.
+func richTexts(text []RichText) []RichText {
+	if text == nil {
+		return []RichText{}
+	}
+
+	return text
+}
+
+// appendBlocks appends the children to a copy of the blocks, so that blocks built from the same value
+// do not share their children.
+func appendBlocks(blocks []Block, children []Block) []Block {
+	if len(children) == 0 {
+		return blocks
+	}
+
+	return append(append(make([]Block, 0, len(blocks)+len(children)), blocks...), children...)
+}
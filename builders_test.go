@@ -0,0 +1,275 @@
+package notion
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRichTextBuilders(t *testing.T) {
+	type args struct {
+		richText RichText
+	}
+
+	type wants struct {
+		json string
+	}
+
+	type test struct {
+		name  string
+		args  args
+		wants wants
+	}
+
+	tests := []test{
+		{
+			name: "Text",
+			args: args{richText: Text("kale")},
+			wants: wants{
+				json: `{"type": "text", "text": {"content": "kale"}}`,
+			},
+		},
+		{
+			name: "Annotated text with a link",
+			args: args{richText: Text("kale").Bold().Italic().Strikethrough().Underline().Code().Color(ColorGreen).Link("https://example.com")},
+			wants: wants{
+				json: `{
+					"type": "text",
+					"text": {"content": "kale", "link": {"url": "https://example.com"}},
+					"annotations": {"bold": true, "italic": true, "strikethrough": true, "underline": true, "code": true, "color": "green"}
+				}`,
+			},
+		},
+		{
+			name: "Equation",
+			args: args{richText: InlineEquation("E = mc^2").Bold()},
+			wants: wants{
+				json: `{
+					"type": "equation",
+					"equation": {"expression": "E = mc^2"},
+					"annotations": {"bold": true, "italic": false, "strikethrough": false, "underline": false, "code": false, "color": "default"}
+				}`,
+			},
+		},
+		{
+			name: "User mention",
+			args: args{richText: MentionUser("6794760a-1f15-45cd-9c65-0dfe42f5135a")},
+			wants: wants{
+				json: `{"type": "mention", "mention": {"type": "user", "user": {"object": "user", "id": "6794760a-1f15-45cd-9c65-0dfe42f5135a"}}}`,
+			},
+		},
+		{
+			name: "Page mention",
+			args: args{richText: MentionPage("3c357473-a281-49a4-88c0-10d2b245a589").Italic()},
+			wants: wants{
+				json: `{
+					"type": "mention",
+					"mention": {"type": "page", "page": {"id": "3c357473-a281-49a4-88c0-10d2b245a589"}},
+					"annotations": {"bold": false, "italic": true, "strikethrough": false, "underline": false, "code": false, "color": "default"}
+				}`,
+			},
+		},
+		{
+			name: "Database mention",
+			args: args{richText: MentionDatabase("a1d8501e-1ac1-43e9-a6bd-ea9fe6c8822b")},
+			wants: wants{
+				json: `{"type": "mention", "mention": {"type": "database", "database": {"id": "a1d8501e-1ac1-43e9-a6bd-ea9fe6c8822b"}}}`,
+			},
+		},
+		{
+			name: "Date mention",
+			args: args{richText: MentionDate("2021-05-11", nil)},
+			wants: wants{
+				json: `{"type": "mention", "mention": {"type": "date", "date": {"start": "2021-05-11", "end": null}}}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.args.richText)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.wants.json, string(data))
+		})
+	}
+}
+
+func TestRichTextBuildersDoNotShareAnnotations(t *testing.T) {
+	bold := Text("kale").Bold()
+	italic := bold.Italic()
+
+	assert.Equal(t, &Annotations{Bold: true, Color: ColorDefault}, bold.Annotations)
+	assert.Equal(t, &Annotations{Bold: true, Italic: true, Color: ColorDefault}, italic.Annotations)
+}
+
+func TestBlockBuilders(t *testing.T) {
+	text := Text("kale")
+	child := Paragraph(Text("child"))
+
+	blocks := []Block{
+		Paragraph(text).WithChildren(child),
+		Heading1(text),
+		Heading2(text),
+		Heading3(text),
+		BulletedListItem(text).WithChildren(child),
+		NumberedListItem(text).WithChildren(child),
+		ToDo(true, text).WithChildren(child),
+		Toggle(text).WithChildren(child),
+		ChildPage("Kale"),
+		ChildDatabase("Kale"),
+		Callout(text).WithIcon(EmojiIcon("💡")).WithColor(BackgroundColorGreen).WithChildren(child),
+		Quote(text).WithChildren(child),
+		Code("go", Text("package main")).WithCaption(text),
+		Image("https://example.com/kale.png").WithCaption(text),
+		Video("https://example.com/kale.mp4").WithCaption(text),
+		Attachment("https://example.com/kale.zip").WithCaption(text),
+		PDF("https://example.com/kale.pdf").WithCaption(text),
+		Bookmark("https://example.com").WithCaption(text),
+		Embed("https://example.com").WithCaption(text),
+		LinkPreview("https://example.com"),
+		Equation("E = mc^2"),
+		Divider(),
+		TableOfContents(),
+		Breadcrumb(),
+		ColumnList(Column(child), Column(child)),
+		Synced(child),
+		SyncedCopy("3c357473-a281-49a4-88c0-10d2b245a589"),
+		Template(text).WithChildren(child),
+		Table(TableRow(text, text)).WithColumnHeader().WithRowHeader(),
+		TableRow(text),
+		LinkToPage("3c357473-a281-49a4-88c0-10d2b245a589"),
+		LinkToDatabase("a1d8501e-1ac1-43e9-a6bd-ea9fe6c8822b"),
+	}
+
+	for _, block := range blocks {
+		blockType := block.GetBase().Type
+
+		t.Run(string(blockType), func(t *testing.T) {
+			data, err := json.Marshal(block)
+			assert.NoError(t, err)
+
+			var fields map[string]json.RawMessage
+
+			assert.NoError(t, json.Unmarshal(data, &fields))
+			assert.JSONEq(t, `"block"`, string(fields["object"]))
+			assert.JSONEq(t, `"`+string(blockType)+`"`, string(fields["type"]))
+			assert.Contains(t, fields, string(blockType), "the content of the block must be keyed by its type")
+
+			// The block is decoded as the same type, with the same content.
+			var decoder blockDecoder
+
+			assert.NoError(t, json.Unmarshal(data, &decoder))
+			assert.Equal(t, blockType, decoder.Block.GetBase().Type)
+
+			redecoded, err := json.Marshal(decoder.Block)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(data), string(redecoded))
+		})
+	}
+}
+
+func TestBlockBuilders_JSON(t *testing.T) {
+	type args struct {
+		block Block
+	}
+
+	type wants struct {
+		json string
+	}
+
+	type test struct {
+		name  string
+		args  args
+		wants wants
+	}
+
+	tests := []test{
+		{
+			name: "Paragraph without text",
+			args: args{block: Paragraph()},
+			wants: wants{
+				json: `{"object": "block", "type": "paragraph", "paragraph": {"text": []}}`,
+			},
+		},
+		{
+			name: "To do with children",
+			args: args{block: ToDo(false, Text("Buy "), Text("kale").Bold()).WithChildren(Divider())},
+			wants: wants{
+				json: `{
+					"object": "block",
+					"type": "to_do",
+					"to_do": {
+						"text": [
+							{"type": "text", "text": {"content": "Buy "}},
+							{
+								"type": "text",
+								"text": {"content": "kale"},
+								"annotations": {
+									"bold": true, "italic": false, "strikethrough": false, "underline": false, "code": false,
+									"color": "default"
+								}
+							}
+						],
+						"checked": false,
+						"children": [{"object": "block", "type": "divider", "divider": {}}]
+					}
+				}`,
+			},
+		},
+		{
+			name: "Image",
+			args: args{block: Image("https://example.com/kale.png")},
+			wants: wants{
+				json: `{
+					"object": "block",
+					"type": "image",
+					"image": {"type": "external", "external": {"url": "https://example.com/kale.png"}}
+				}`,
+			},
+		},
+		{
+			name: "Table",
+			args: args{block: Table(TableRow(Text("Name")), TableRow(Text("Kale"), Text("2.5"))).WithColumnHeader()},
+			wants: wants{
+				json: `{
+					"object": "block",
+					"type": "table",
+					"table": {
+						"table_width": 2,
+						"has_column_header": true,
+						"has_row_header": false,
+						"children": [
+							{"object": "block", "type": "table_row", "table_row": {"cells": [[{"type": "text", "text": {"content": "Name"}}]]}},
+							{
+								"object": "block",
+								"type": "table_row",
+								"table_row": {
+									"cells": [[{"type": "text", "text": {"content": "Kale"}}], [{"type": "text", "text": {"content": "2.5"}}]]
+								}
+							}
+						]
+					}
+				}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.args.block)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.wants.json, string(data))
+		})
+	}
+}
+
+func TestBlockBuildersDoNotShareChildren(t *testing.T) {
+	item := BulletedListItem(Text("Kale"))
+	curly := item.WithChildren(Paragraph(Text("Curly")))
+	lacinato := item.WithChildren(Paragraph(Text("Lacinato")))
+
+	assert.Empty(t, item.BulletedListItem.Children)
+	assert.Equal(t, []Block{Paragraph(Text("Curly"))}, curly.BulletedListItem.Children)
+	assert.Equal(t, []Block{Paragraph(Text("Lacinato"))}, lacinato.BulletedListItem.Children)
+}
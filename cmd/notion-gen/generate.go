@@ -0,0 +1,405 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// options of the generated code.
+type options struct {
+	// Name of the package.
+	Package string
+	// Name of the struct, derived from the title of the database when empty.
+	Type string
+}
+
+// filterKind describes the filter condition of a type of property, e.g. notion.SingleTextFilter with its URL field
+// set to a notion.TextFilter for URL properties.
+type filterKind struct {
+	single    string
+	condition string
+	field     string
+	pointer   bool
+}
+
+var filterKinds = map[notion.PropertyType]filterKind{
+	notion.PropertyTypeTitle:          {"SingleTextFilter", "TextFilter", "Text", true},
+	notion.PropertyTypeRichText:       {"SingleTextFilter", "TextFilter", "RichText", true},
+	notion.PropertyTypeURL:            {"SingleTextFilter", "TextFilter", "URL", true},
+	notion.PropertyTypeEmail:          {"SingleTextFilter", "TextFilter", "Email", true},
+	notion.PropertyTypePhoneNumber:    {"SingleTextFilter", "TextFilter", "Phone", true},
+	notion.PropertyTypeNumber:         {"SingleNumberFilter", "NumberFilter", "Number", false},
+	notion.PropertyTypeCheckbox:       {"SingleCheckboxFilter", "CheckboxFilter", "Checkbox", false},
+	notion.PropertyTypeSelect:         {"SingleSelectFilter", "SelectFilter", "Select", false},
+	notion.PropertyTypeMultiSelect:    {"SingleMultiSelectFilter", "MultiSelectFilter", "MultiSelect", false},
+	notion.PropertyTypeDate:           {"SingleDateFilter", "DateFilter", "Date", true},
+	notion.PropertyTypeCreatedTime:    {"SingleDateFilter", "DateFilter", "CreatedTime", true},
+	notion.PropertyTypeLastEditedTime: {"SingleDateFilter", "DateFilter", "LastEditedTime", true},
+	notion.PropertyTypePeople:         {"SinglePeopleFilter", "PeopleFilter", "People", true},
+	notion.PropertyTypeCreatedBy:      {"SinglePeopleFilter", "PeopleFilter", "CreatedBy", true},
+	notion.PropertyTypeLastEditedBy:   {"SinglePeopleFilter", "PeopleFilter", "LastEditedBy", true},
+	notion.PropertyTypeFile:           {"SingleFilesFilter", "FilesFilter", "Files", false},
+	notion.PropertyTypeRelation:       {"SingleRelationFilter", "RelationFilter", "Relation", false},
+	notion.PropertyTypeFormula:        {"SingleFormulaFilter", "FormulaFilter", "Formula", false},
+}
+
+// commonInitialisms are written in upper case in identifiers, e.g. ID and URL.
+var commonInitialisms = map[string]bool{
+	"API": true, "CSS": true, "CSV": true, "DNS": true, "EOF": true, "HTML": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IP": true, "JSON": true, "PDF": true, "SQL": true, "SSH": true, "TCP": true, "TTL": true,
+	"UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// field is a property of the database mapped to a field of the struct.
+type field struct {
+	name      string
+	property  string
+	typ       notion.PropertyType
+	tagType   notion.PropertyValueType
+	goType    string
+	constName string
+	// Filter functions, when the property can be filtered.
+	filter   *filterKind
+	funcName string
+	// Type and constants of the options of select and multi select properties.
+	optionType string
+	options    []option
+}
+
+// tagName returns the name of the property as written in a tag, quoted when it contains commas, see
+// notion.UnmarshalProperties.
+func (f *field) tagName() string {
+	if strings.Contains(f.property, ",") || strings.HasPrefix(f.property, "'") || f.property == "-" {
+		return "'" + strings.ReplaceAll(f.property, "'", "''") + "'"
+	}
+
+	return f.property
+}
+
+type option struct {
+	constName string
+	name      string
+}
+
+// generator builds the identifiers of the generated code, which must be unique in the package.
+type generator struct {
+	options
+	database   *notion.Database
+	names      map[string]bool
+	databaseID string
+	fields     []*field
+}
+
+// generate returns the source of a Go file declaring a struct mapped to the pages of the database, see
+// notion.UnmarshalPage, with constants for the names of the properties and the options of select and multi
+// select properties, and functions returning filters on the properties.
+func generate(database *notion.Database, opts options) ([]byte, error) {
+	g := &generator{options: opts, database: database, names: make(map[string]bool)}
+
+	if g.Type == "" {
+		g.Type = exportedName(notion.PlainText(database.Title), "Page")
+	}
+
+	g.names[g.Type] = true
+	g.databaseID = g.unique(g.Type + "DatabaseID")
+	g.collectFields()
+
+	var b bytes.Buffer
+
+	g.render(&b)
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated code: %w", err)
+	}
+
+	return source, nil
+}
+
+// collectFields names the fields, then the other identifiers, so that the names of the properties are kept
+// as is in the struct and the option constants come last when the names collide.
+func (g *generator) collectFields() {
+	names := make([]string, 0, len(g.database.Properties))
+	for name := range g.database.Properties {
+		names = append(names, name)
+	}
+
+	// The title first, as in Notion.
+	sort.Slice(names, func(i, j int) bool {
+		ti, tj := isTitle(g.database.Properties[names[i]]), isTitle(g.database.Properties[names[j]])
+		if ti != tj {
+			return ti
+		}
+
+		return names[i] < names[j]
+	})
+
+	fieldNames := make(map[string]bool)
+
+	for _, name := range names {
+		property := g.database.Properties[name]
+		if property == nil {
+			continue
+		}
+
+		f := &field{property: name, typ: notion.PropertyTypeOf(property)}
+		f.name = unique(fieldNames, exportedName(name, "Property"))
+		f.tagType = tagType(f.typ)
+		f.constName = g.unique(g.Type + "Property" + f.name)
+
+		if kind, ok := filterKinds[f.typ]; ok {
+			f.filter = &kind
+			f.funcName = g.unique(g.Type + f.name + "Filter")
+		}
+
+		switch p := property.(type) {
+		case *notion.SelectProperty:
+			f.optionType = g.unique(g.Type + f.name)
+			f.options = make([]option, 0, len(p.Select.Options))
+
+			for _, o := range p.Select.Options {
+				f.options = append(f.options, option{name: o.Name})
+			}
+
+		case *notion.MultiSelectProperty:
+			f.optionType = g.unique(g.Type + f.name)
+			f.options = make([]option, 0, len(p.MultiSelect.Options))
+
+			for _, o := range p.MultiSelect.Options {
+				f.options = append(f.options, option{name: o.Name})
+			}
+		}
+
+		f.goType = goType(f)
+		g.fields = append(g.fields, f)
+	}
+
+	for _, f := range g.fields {
+		for i := range f.options {
+			f.options[i].constName = g.unique(f.optionType + exportedName(f.options[i].name, "Option"))
+		}
+	}
+}
+
+func (g *generator) unique(name string) string {
+	return unique(g.names, name)
+}
+
+func (g *generator) render(b *bytes.Buffer) {
+	title := notion.PlainText(g.database.Title)
+
+	fmt.Fprintf(b, "// Code generated by notion-gen from the database %q. DO NOT EDIT.\n\n", title)
+	fmt.Fprintf(b, "package %s\n\n", g.Package)
+
+	g.renderImports(b)
+
+	fmt.Fprintf(b, "// %s is the identifier of the database %q.\n", g.databaseID, title)
+	fmt.Fprintf(b, "const %s = %q\n\n", g.databaseID, g.database.ID)
+
+	fmt.Fprintf(b, "// Names of the properties of the database %q.\nconst (\n", title)
+
+	for _, f := range g.fields {
+		fmt.Fprintf(b, "%s = %q\n", f.constName, f.property)
+	}
+
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(b, "// %s is a page of the database %q, see notion.UnmarshalPage and notion.TypedDatabase.\n", g.Type, title)
+	fmt.Fprintf(b, "type %s struct {\n", g.Type)
+
+	for _, f := range g.fields {
+		tag := f.tagName()
+		if f.tagType != "" {
+			tag += "," + string(f.tagType)
+		}
+
+		tag = "notion:" + strconv.Quote(tag)
+		if strings.Contains(tag, "`") {
+			tag = strconv.Quote(tag)
+		} else {
+			tag = "`" + tag + "`"
+		}
+
+		fmt.Fprintf(b, "%s %s %s\n", f.name, f.goType, tag)
+	}
+
+	b.WriteString("}\n")
+
+	for _, f := range g.fields {
+		if f.optionType != "" {
+			g.renderOptions(b, f)
+		}
+	}
+
+	for _, f := range g.fields {
+		if f.filter != nil {
+			g.renderFilters(b, f)
+		}
+	}
+}
+
+// renderImports imports the packages used by the types of the fields and the filters.
+func (g *generator) renderImports(b *bytes.Buffer) {
+	var usesTime, usesNotion bool
+
+	for _, f := range g.fields {
+		usesTime = usesTime || strings.HasPrefix(f.goType, "time.")
+		usesNotion = usesNotion || strings.HasPrefix(f.goType, "notion.") || f.filter != nil
+	}
+
+	switch {
+	case usesTime && usesNotion:
+		b.WriteString("import (\n\"time\"\n\n\"github.com/mkfsn/notion-go\"\n)\n\n")
+	case usesTime:
+		b.WriteString("import \"time\"\n\n")
+	case usesNotion:
+		b.WriteString("import \"github.com/mkfsn/notion-go\"\n\n")
+	}
+}
+
+func (g *generator) renderOptions(b *bytes.Buffer, f *field) {
+	fmt.Fprintf(b, "\n// %s is an option of the %s property %q.\n", f.optionType, f.typ, f.property)
+	fmt.Fprintf(b, "type %s string\n", f.optionType)
+
+	if len(f.options) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "\n// Options of the %s property %q.\nconst (\n", f.typ, f.property)
+
+	for _, o := range f.options {
+		fmt.Fprintf(b, "%s %s = %q\n", o.constName, f.optionType, o.name)
+	}
+
+	b.WriteString(")\n")
+}
+
+func (g *generator) renderFilters(b *bytes.Buffer, f *field) {
+	condition := "condition"
+	if f.filter.pointer {
+		condition = "&condition"
+	}
+
+	fmt.Fprintf(b, "\n// %s returns a filter on the %s property %q.\n", f.funcName, f.typ, f.property)
+	fmt.Fprintf(b, "func %s(condition notion.%s) notion.%s {\n", f.funcName, f.filter.condition, f.filter.single)
+	fmt.Fprintf(b, "return notion.%s{\n", f.filter.single)
+	fmt.Fprintf(b, "SinglePropertyFilter: notion.SinglePropertyFilter{Property: %s},\n", f.constName)
+	fmt.Fprintf(b, "%s: %s,\n}\n}\n", f.filter.field, condition)
+
+	if f.optionType == "" {
+		return
+	}
+
+	operations := [][2]string{{"Equals", "is"}, {"DoesNotEqual", "is not"}}
+	if f.typ == notion.PropertyTypeMultiSelect {
+		operations = [][2]string{{"Contains", "contains"}, {"DoesNotContain", "does not contain"}}
+	}
+
+	for _, operation := range operations {
+		name := g.unique(g.Type + f.name + operation[0])
+
+		fmt.Fprintf(b, "\n// %s returns a filter matching the pages whose %s property %q %s the option.\n",
+			name, f.typ, f.property, operation[1])
+		fmt.Fprintf(b, "func %s(option %s) notion.%s {\n", name, f.optionType, f.filter.single)
+		fmt.Fprintf(b, "name := string(option)\n\n")
+		fmt.Fprintf(b, "return %s(notion.%s{%s: &name})\n}\n", f.funcName, f.filter.condition, operation[0])
+	}
+}
+
+func isTitle(property notion.Property) bool {
+	return notion.PropertyTypeOf(property) == notion.PropertyTypeTitle
+}
+
+// tagType returns the type of the property values of a property, set in the tag of its field.
+func tagType(propertyType notion.PropertyType) notion.PropertyValueType {
+	return notion.PropertyValueType(propertyType)
+}
+
+// goType returns the type of the field of a property, see notion.UnmarshalProperties.
+func goType(f *field) string {
+	switch f.typ {
+	case notion.PropertyTypeTitle, notion.PropertyTypeRichText, notion.PropertyTypeURL, notion.PropertyTypeEmail,
+		notion.PropertyTypePhoneNumber, notion.PropertyTypeCreatedBy, notion.PropertyTypeLastEditedBy:
+		return "string"
+
+	case notion.PropertyTypeNumber:
+		return "float64"
+
+	case notion.PropertyTypeSelect:
+		return f.optionType
+
+	case notion.PropertyTypeMultiSelect:
+		return "[]" + f.optionType
+
+	case notion.PropertyTypeDate:
+		return "notion.Date"
+
+	case notion.PropertyTypePeople, notion.PropertyTypeRelation, notion.PropertyTypeFile:
+		return "[]string"
+
+	case notion.PropertyTypeCheckbox:
+		return "bool"
+
+	case notion.PropertyTypeCreatedTime, notion.PropertyTypeLastEditedTime:
+		return "time.Time"
+
+	case notion.PropertyTypeFormula:
+		return "notion.FormulaPropertyValue"
+
+	case notion.PropertyTypeRollup:
+		return "notion.RollupPropertyValue"
+	}
+
+	return "notion.PropertyValue"
+}
+
+// exportedName returns an exported identifier made of the letters and digits of the name, e.g. "Due date" becomes
+// DueDate and "Website URL" becomes WebsiteURL. The fallback prefixes the names which do not start with an upper
+// case letter, and stands for the names without letters nor digits.
+func exportedName(name, fallback string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+
+	for _, word := range words {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			b.WriteString(upper)
+
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	identifier := b.String()
+
+	if first := []rune(identifier + " ")[0]; !unicode.IsUpper(first) {
+		identifier = fallback + identifier
+	}
+
+	return identifier
+}
+
+// unique returns the name, suffixed with a number when it is already taken, and takes it.
+func unique(names map[string]bool, name string) string {
+	identifier := name
+
+	for i := 2; names[identifier]; i++ {
+		identifier = name + strconv.Itoa(i)
+	}
+
+	names[identifier] = true
+
+	return identifier
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	data, err := os.ReadFile("testdata/tasks.json")
+	require.NoError(t, err)
+
+	var database notion.Database
+
+	require.NoError(t, json.Unmarshal(data, &database))
+
+	source, err := generate(&database, options{Package: "tasks", Type: "Task"})
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile("testdata/tasks.golden", source, 0o644))
+	}
+
+	golden, err := os.ReadFile("testdata/tasks.golden")
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(source))
+}
+
+func TestGenerate_Collisions(t *testing.T) {
+	var database notion.Database
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"object": "database",
+		"id": "668d797c-76fa-4934-9b05-ad288df2d136",
+		"title": [{"type": "text", "text": {"content": "Projects"}, "plain_text": "Projects"}],
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": {}},
+			"name": {"id": "a", "type": "rich_text", "rich_text": {}},
+			"Status": {"id": "b", "type": "select", "select": {"options": [{"id": "1", "name": "Filter", "color": "red"}]}}
+		}
+	}`), &database))
+
+	source, err := generate(&database, options{Package: "projects"})
+	require.NoError(t, err)
+	assert.Contains(t, string(source), "type Projects struct")
+	assert.Regexp(t, `\sName\s+string\s+`+"`"+`notion:"Name,title"`, string(source))
+	assert.Regexp(t, `\sName2\s+string\s+`+"`"+`notion:"name,rich_text"`, string(source))
+	assert.Contains(t, string(source), "ProjectsStatusFilter2 ProjectsStatus = \"Filter\"")
+}
+
+func TestExportedName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fallback string
+		want     string
+	}{
+		{name: "Name", want: "Name"},
+		{name: "Due date", want: "DueDate"},
+		{name: "Website URL", want: "WebsiteURL"},
+		{name: "project_id", want: "ProjectID"},
+		{name: "UI/UX", want: "UIUX"},
+		{name: "Estimate (h)", want: "EstimateH"},
+		{name: "3rd party", fallback: "Option", want: "Option3rdParty"},
+		{name: "🔥", fallback: "Option", want: "Option"},
+		{name: "été", want: "Été"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exportedName(tt.name, tt.fallback))
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	assert.Equal(t, "main", packageName(""))
+	assert.Equal(t, "tasks", packageName("internal/tasks/task.go"))
+	assert.Equal(t, "mytasks", packageName("internal/my-tasks/task.go"))
+}
+
+func TestField_TagName(t *testing.T) {
+	tests := []struct {
+		property string
+		want     string
+	}{
+		{property: "Due date", want: "Due date"},
+		{property: "Size, in points", want: "'Size, in points'"},
+		{property: "'Quoted'", want: "'''Quoted'''"},
+		{property: "-", want: "'-'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.property, func(t *testing.T) {
+			f := field{property: tt.property}
+			assert.Equal(t, tt.want, f.tagName())
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Command notion-gen generates a Go struct mapped to the pages of a Notion database, see notion.UnmarshalPage,
+// along with constants for the names of its properties and the options of its select and multi select
+// properties, and functions returning filters on its properties. Regenerating the code after the database
+// changes turns the uses of the removed properties and options into compile errors.
+//
+// The database is retrieved from the API with the token in NOTION_AUTH_TOKEN, or read from a JSON file as
+// returned by the API so that it works offline:
+//
+//	notion-gen -database def72422-ea36-4c8a-a6f1-a34e11a7fe54 -package tasks -type Task -o task.go
+//	notion-gen -input database.json -package tasks -o task.go
+//
+// It can be used with go generate:
+//
+//	//go:generate go run github.com/mkfsn/notion-go/cmd/notion-gen -input database.json -o task.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var errUsage = errors.New("either -database or -input must be set")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "notion-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("notion-gen", flag.ContinueOnError)
+
+	var (
+		databaseID = flags.String("database", "", "`ID` of the database to retrieve from the API, with the token in NOTION_AUTH_TOKEN")
+		input      = flags.String("input", "", "JSON `file` of the database, as returned by the API, or - for the standard input")
+		output     = flags.String("o", "", "output `file`, the standard output by default")
+		opts       options
+	)
+
+	flags.StringVar(&opts.Package, "package", "", "`name` of the package, the name of the directory of the output file by default")
+	flags.StringVar(&opts.Type, "type", "", "`name` of the struct, derived from the title of the database by default")
+
+	if err := flags.Parse(args); err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	if (*databaseID == "") == (*input == "") {
+		flags.Usage()
+
+		return errUsage
+	}
+
+	database, err := loadDatabase(*databaseID, *input)
+	if err != nil {
+		return err
+	}
+
+	if opts.Package == "" {
+		opts.Package = packageName(*output)
+	}
+
+	source, err := generate(database, opts)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(source)
+
+		return err // nolint:wrapcheck
+	}
+
+	return os.WriteFile(*output, source, 0o644) // nolint:gosec,wrapcheck
+}
+
+// loadDatabase retrieves the database from the API, or reads it from the input file.
+func loadDatabase(databaseID, input string) (*notion.Database, error) {
+	if databaseID != "" {
+		resp, err := notion.New(os.Getenv("NOTION_AUTH_TOKEN")).Databases().Retrieve(context.Background(),
+			notion.DatabasesRetrieveParameters{DatabaseID: databaseID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve database %s: %w", databaseID, err)
+		}
+
+		return &resp.Database, nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if input == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(input)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the database: %w", err)
+	}
+
+	var database notion.Database
+
+	if err := json.Unmarshal(data, &database); err != nil {
+		return nil, fmt.Errorf("failed to read the database from %s: %w", input, err)
+	}
+
+	return &database, nil
+}
+
+// packageName returns the name of the directory of the output file, made a valid package name, or main.
+func packageName(output string) string {
+	if output == "" {
+		return "main"
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(output))
+	if err != nil {
+		return "main"
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, filepath.Base(dir))
+
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		return "main"
+	}
+
+	return name
+}
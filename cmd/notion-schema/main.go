@@ -0,0 +1,75 @@
+// Command notion-schema applies a schema file to a Notion database, see the schema package. It prints the changes
+// turning the database into the schema, and applies them unless -dry-run is set:
+//
+//	notion-schema -database def72422-ea36-4c8a-a6f1-a34e11a7fe54 -schema tasks.yaml -dry-run
+//	notion-schema -database def72422-ea36-4c8a-a6f1-a34e11a7fe54 -schema tasks.yaml
+//
+// The token is read from NOTION_AUTH_TOKEN.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/schema"
+)
+
+var errUsage = errors.New("both -database and -schema must be set")
+
+func main() {
+	databases := notion.New(os.Getenv("NOTION_AUTH_TOKEN")).Databases()
+
+	if err := run(context.Background(), os.Args[1:], os.Stdout, databases); err != nil {
+		fmt.Fprintln(os.Stderr, "notion-schema:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, databases notion.DatabasesInterface) error {
+	flags := flag.NewFlagSet("notion-schema", flag.ContinueOnError)
+
+	var (
+		databaseID = flags.String("database", "", "`ID` of the database")
+		path       = flags.String("schema", "", "YAML or JSON `file` of the desired schema")
+		dryRun     = flags.Bool("dry-run", false, "print the changes without applying them")
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	if *databaseID == "" || *path == "" {
+		flags.Usage()
+
+		return errUsage
+	}
+
+	desired, err := schema.Load(*path)
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	plan, err := schema.Diff(ctx, databases, *databaseID, *desired)
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	fmt.Fprint(stdout, plan)
+
+	if *dryRun || len(plan.Changes) == 0 {
+		return nil
+	}
+
+	if err := plan.Apply(ctx, databases); err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	fmt.Fprintln(stdout, "Applied.")
+
+	return nil
+}
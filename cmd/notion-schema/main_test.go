@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	var patches int
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPatch {
+			patches++
+		}
+
+		_, err := writer.Write([]byte(`{
+			"object": "database",
+			"id": "def72422-ea36-4c8a-a6f1-a34e11a7fe54",
+			"properties": {
+				"Name": {"id": "title", "type": "title", "title": {}},
+				"Notes": {"id": "n;ot", "type": "rich_text", "rich_text": {}}
+			}
+		}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("properties:\n  Name:\n    type: title\n  Done:\n    type: checkbox\n"), 0o600))
+
+	databases := notion.New("token", notion.WithBaseURL(server.URL)).Databases()
+	args := []string{"-database", "def72422-ea36-4c8a-a6f1-a34e11a7fe54", "-schema", path}
+	plan := "Database def72422-ea36-4c8a-a6f1-a34e11a7fe54:\n  + Done (checkbox)\n  - Notes (rich_text)\n"
+
+	var stdout bytes.Buffer
+
+	require.NoError(t, run(context.Background(), append(args, "-dry-run"), &stdout, databases))
+	assert.Equal(t, plan, stdout.String())
+	assert.Equal(t, 0, patches)
+
+	stdout.Reset()
+
+	require.NoError(t, run(context.Background(), args, &stdout, databases))
+	assert.Equal(t, plan+"Applied.\n", stdout.String())
+	assert.Equal(t, 1, patches)
+
+	assert.ErrorIs(t, run(context.Background(), args[:2], &stdout, databases), errUsage)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/markdown"
+)
+
+func blocksList(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "blocks ls").paginated()
+
+	args, err := flags.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	params := notion.BlocksChildrenListParameters{PaginationParameters: flags.pagination(), BlockID: args[0]}
+
+	if flags.all {
+		blocks, err := e.api.Blocks().Children().ListAll(ctx, params).Collect(0)
+		if err != nil {
+			return err // nolint:wrapcheck
+		}
+
+		return e.print(flags.output, blocksOutput(blocks, blocks...))
+	}
+
+	resp, err := e.api.Blocks().Children().List(ctx, params)
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	e.printNextCursor(resp.PaginatedList)
+
+	return e.print(flags.output, blocksOutput(resp.Results, resp.Results...))
+}
+
+func blocksAppend(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "blocks append")
+	content := flags.String("markdown", "", "Markdown `file` of the blocks, or - for the standard input")
+
+	args, err := flags.parse(args, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	source := strings.Join(args[1:], " ")
+
+	if (*content == "") == (source == "") {
+		flags.Usage()
+
+		return fmt.Errorf("%w: either --markdown or a text must be given", errUsage)
+	}
+
+	if *content != "" {
+		if source, err = readFile(e.stdin, *content); err != nil {
+			return err
+		}
+	}
+
+	resp, err := e.api.Blocks().Children().Append(ctx, notion.BlocksChildrenAppendParameters{
+		BlockID:  args[0],
+		Children: markdown.Parse(source),
+	})
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	return e.print(flags.output, blocksOutput(resp.Block, resp.Block))
+}
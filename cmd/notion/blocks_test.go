@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Blocks(t *testing.T) {
+	e := newTestEnv(t)
+
+	stdout, _, err := e.run(t, "blocks", "append", e.pageIDs[0], "Buy", "**kale**")
+	require.NoError(t, err)
+
+	var block map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &block))
+	assert.Equal(t, e.pageIDs[0], block["id"])
+
+	_, _, err = e.run(t, "blocks", "append", e.pageIDs[0], "--", "- [x] Wash kale\n- [ ] Cook kale")
+	require.NoError(t, err)
+
+	stdout, stderr, err := e.run(t, "blocks", "ls", e.pageIDs[0], "--page-size", "2", "-o", "table")
+	require.NoError(t, err)
+	assert.Regexp(t, `^ID +TYPE +CHILDREN +CONTENT\n\S+ +paragraph +false +Buy \*\*kale\*\*\n\S+ +to_do +false +- \[x\] Wash kale\n$`, stdout)
+	assert.Contains(t, stderr, "More results with --cursor ")
+
+	stdout, _, err = e.run(t, "blocks", "ls", e.pageIDs[0], "--all", "--page-size", "2")
+	require.NoError(t, err)
+
+	var blocks []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &blocks))
+	assert.Len(t, blocks, 3)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/query"
+)
+
+func databasesList(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "db list").paginated()
+
+	if _, err := flags.parse(args, 0, 0); err != nil {
+		return err
+	}
+
+	params := notion.DatabasesListParameters{PaginationParameters: flags.pagination()}
+
+	if flags.all {
+		databases, err := e.api.Databases().ListAll(ctx, params).Collect(0)
+		if err != nil {
+			return err // nolint:wrapcheck
+		}
+
+		return e.print(flags.output, databasesOutput(databases))
+	}
+
+	resp, err := e.api.Databases().List(ctx, params)
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	e.printNextCursor(resp.PaginatedList)
+
+	return e.print(flags.output, databasesOutput(resp.Results))
+}
+
+func databasesGet(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "db get")
+
+	args, err := flags.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.api.Databases().Retrieve(ctx, notion.DatabasesRetrieveParameters{DatabaseID: args[0]})
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	return e.print(flags.output, databaseOutput(resp.Database))
+}
+
+func databasesQuery(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "db query").paginated()
+	text := flags.String("query", "", "filter and sorts in the language of the query package, e.g. `'Done = false order by Due'`")
+
+	args, err := flags.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	params, err := queryParameters(ctx, e.api, args[0], *text)
+	if err != nil {
+		return err
+	}
+
+	params.PaginationParameters = flags.pagination()
+
+	if flags.all {
+		pages, err := e.api.Databases().QueryAll(ctx, params).Collect(0)
+		if err != nil {
+			return err // nolint:wrapcheck
+		}
+
+		return e.print(flags.output, pagesOutput(pages))
+	}
+
+	resp, err := e.api.Databases().Query(ctx, params)
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	e.printNextCursor(resp.PaginatedList)
+
+	return e.print(flags.output, pagesOutput(resp.Results))
+}
+
+// queryParameters returns the parameters of a query of a database, parsed with the types of the properties of the
+// database, which is only retrieved when there is a query.
+func queryParameters(ctx context.Context, api *notion.API, databaseID, text string) (notion.DatabasesQueryParameters, error) {
+	if text == "" {
+		return notion.DatabasesQueryParameters{DatabaseID: databaseID}, nil
+	}
+
+	database, err := api.Databases().Retrieve(ctx, notion.DatabasesRetrieveParameters{DatabaseID: databaseID})
+	if err != nil {
+		return notion.DatabasesQueryParameters{}, err // nolint:wrapcheck
+	}
+
+	return parseQuery(&database.Database, databaseID, text)
+}
+
+// parseQuery returns the parameters of a query of a database, parsed with the types of the properties of the
+// database.
+func parseQuery(database *notion.Database, databaseID, text string) (notion.DatabasesQueryParameters, error) {
+	if text == "" {
+		return notion.DatabasesQueryParameters{DatabaseID: databaseID}, nil
+	}
+
+	q, err := query.Parse(text, database)
+	if err != nil {
+		return notion.DatabasesQueryParameters{}, err // nolint:wrapcheck
+	}
+
+	return q.Parameters(databaseID), nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Databases(t *testing.T) {
+	e := newTestEnv(t)
+
+	stdout, _, err := e.run(t, "db", "list", "-o", "table")
+	require.NoError(t, err)
+	assert.Regexp(t, `^ID +TITLE +LAST EDITED\n\S+ +Tasks +\S+\n$`, stdout)
+
+	stdout, _, err = e.run(t, "db", "get", e.databaseID, "--output", "table")
+	require.NoError(t, err)
+	assert.Regexp(t, `^PROPERTY +TYPE +ID\nName +title +title\nPrice +number +\S+\nStatus +select +\S+\nTags +multi_select +\S+\n$`, stdout)
+
+	stdout, _, err = e.run(t, "db", "query", e.databaseID, "--query", `Status = "Todo" order by Price desc`, "-o", "table")
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"ID                                    Name       Price  Status  Tags\n"+
+		e.pageIDs[2]+"  Cook kale  12     Todo    \n"+
+		e.pageIDs[3]+"  Eat kale   8      Todo    \n", stdout)
+
+	stdout, stderr, err := e.run(t, "db", "query", e.databaseID, "--page-size", "2")
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "More results with --cursor ")
+
+	var pages []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &pages))
+	assert.Len(t, pages, 2)
+
+	stdout, stderr, err = e.run(t, "db", "query", "--all", "--page-size", "2", e.databaseID)
+	require.NoError(t, err)
+	assert.Empty(t, stderr)
+	require.NoError(t, json.Unmarshal([]byte(stdout), &pages))
+	assert.Len(t, pages, 3)
+
+	_, _, err = e.run(t, "db", "query", e.databaseID, "--query", `Nmae = "Buy kale"`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mkfsn/notion-go"
+)
+
+const (
+	exportCSV   = "csv"
+	exportJSONL = "jsonl"
+	exportTSV   = "tsv"
+)
+
+func databasesExport(ctx context.Context, e *env, args []string) error {
+	flags := newBaseFlagSet(e, "db export")
+	format := flags.String("format", exportCSV, "export `format`: csv, jsonl or tsv")
+	text := flags.String("query", "", "filter and sorts in the language of the query package, e.g. `'Done = false order by Due'`")
+	pageSize := flags.Int("page-size", 100, "`number` of pages fetched per request, 100 at most") // nolint:gomnd
+
+	args, err := flags.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	var w exportWriter
+
+	switch *format {
+	case exportCSV:
+		w = &csvWriter{Writer: csv.NewWriter(e.stdout)}
+	case exportTSV:
+		w = &tsvWriter{w: e.stdout}
+	case exportJSONL:
+		w = &jsonlWriter{w: e.stdout}
+	default:
+		return fmt.Errorf("%w: unknown export format %q", errUsage, *format)
+	}
+
+	database, err := e.api.Databases().Retrieve(ctx, notion.DatabasesRetrieveParameters{DatabaseID: args[0]})
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	params, err := parseQuery(&database.Database, args[0], *text)
+	if err != nil {
+		return err
+	}
+
+	params.PageSize = int32(*pageSize)
+	columns := exportColumns(database.Database)
+
+	if err := w.writeHeader(append([]string{"ID"}, columns...)); err != nil {
+		return err
+	}
+
+	it := e.api.Databases().QueryAll(ctx, params)
+
+	for it.Next() {
+		page := it.Value()
+		values := make([]interface{}, 0, len(columns)+1)
+		values = append(values, page.ID)
+
+		for _, name := range columns {
+			values = append(values, flattenValue(page.Properties[name]))
+		}
+
+		if err := w.writeRow(values); err != nil {
+			return err
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	return w.flush()
+}
+
+// exportColumns returns the names of the properties of a database, the title first, as in Notion.
+func exportColumns(database notion.Database) []string {
+	columns := sortedKeys(database.Properties)
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		ti := notion.PropertyTypeOf(database.Properties[columns[i]]) == notion.PropertyTypeTitle
+		tj := notion.PropertyTypeOf(database.Properties[columns[j]]) == notion.PropertyTypeTitle
+
+		return ti && !tj
+	})
+
+	return columns
+}
+
+// exportWriter writes the pages of a database, one row per page.
+type exportWriter interface {
+	writeHeader(columns []string) error
+	writeRow(values []interface{}) error
+	flush() error
+}
+
+type csvWriter struct {
+	*csv.Writer
+}
+
+func (w *csvWriter) writeHeader(columns []string) error {
+	return w.Write(columns) // nolint:wrapcheck
+}
+
+func (w *csvWriter) writeRow(values []interface{}) error {
+	cells := make([]string, 0, len(values))
+	for _, value := range values {
+		cells = append(cells, formatCell(value))
+	}
+
+	return w.Write(cells) // nolint:wrapcheck
+}
+
+func (w *csvWriter) flush() error {
+	w.Flush()
+
+	return w.Error() // nolint:wrapcheck
+}
+
+// tsvWriter writes tab-separated values, replacing the tabs and newlines in the values by spaces.
+type tsvWriter struct {
+	w io.Writer
+}
+
+func (w *tsvWriter) writeHeader(columns []string) error {
+	return w.writeCells(columns)
+}
+
+func (w *tsvWriter) writeRow(values []interface{}) error {
+	cells := make([]string, 0, len(values))
+	for _, value := range values {
+		cells = append(cells, formatCell(value))
+	}
+
+	return w.writeCells(cells)
+}
+
+func (w *tsvWriter) writeCells(cells []string) error {
+	replacer := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+	for i, cell := range cells {
+		cells[i] = replacer.Replace(cell)
+	}
+
+	_, err := fmt.Fprintln(w.w, strings.Join(cells, "\t"))
+
+	return err // nolint:wrapcheck
+}
+
+func (w *tsvWriter) flush() error {
+	return nil
+}
+
+// jsonlWriter writes a JSON object per line, with the ID of the page and its properties in the order of the columns.
+// The properties are nested so that they cannot collide with the ID, e.g. a property named "id".
+type jsonlWriter struct {
+	w       io.Writer
+	columns []string
+}
+
+func (w *jsonlWriter) writeHeader(columns []string) error {
+	w.columns = columns
+
+	return nil
+}
+
+func (w *jsonlWriter) writeRow(values []interface{}) error {
+	var buf bytes.Buffer
+
+	id, err := json.Marshal(values[0])
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	buf.WriteString(`{"id":`)
+	buf.Write(id)
+	buf.WriteString(`,"properties":{`)
+
+	for i := 1; i < len(values); i++ {
+		if i > 1 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(w.columns[i])
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteString("}}\n")
+
+	_, err = w.w.Write(buf.Bytes())
+
+	return err // nolint:wrapcheck
+}
+
+func (w *jsonlWriter) flush() error {
+	return nil
+}
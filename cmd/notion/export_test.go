@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_DatabasesExport(t *testing.T) {
+	e := newTestEnv(t)
+
+	_, _, err := e.run(t, "page", "update", e.pageIDs[2], "--set", "Tags=green,leafy", "--set", "Name=Cook \"kale\", then eat")
+	require.NoError(t, err)
+
+	stdout, _, err := e.run(t, "db", "export", e.databaseID, "--query", `Status = "Todo" order by Price desc`, "--page-size", "1")
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"ID,Name,Price,Status,Tags\n"+
+		e.pageIDs[2]+`,"Cook ""kale"", then eat",12,Todo,"green, leafy"`+"\n"+
+		e.pageIDs[3]+",Eat kale,8,Todo,\n", stdout)
+
+	stdout, _, err = e.run(t, "db", "export", "--format", "tsv", e.databaseID, "--query", `Price < 5`)
+	require.NoError(t, err)
+	assert.Equal(t, "ID\tName\tPrice\tStatus\tTags\n"+e.pageIDs[1]+"\tBuy kale\t4\tDone\t\n", stdout)
+
+	stdout, _, err = e.run(t, "db", "export", e.databaseID, "--format", "jsonl", "--query", `order by Price desc`)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, `{"id":"`+e.pageIDs[2]+`","properties":{"Name":"Cook \"kale\", then eat","Price":12,"Status":"Todo","Tags":["green","leafy"]}}`, lines[0])
+	assert.Equal(t, `{"id":"`+e.pageIDs[1]+`","properties":{"Name":"Buy kale","Price":4,"Status":"Done","Tags":[]}}`, lines[2])
+
+	_, _, err = e.run(t, "db", "export", e.databaseID, "--format", "xlsx")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, _, err = e.run(t, "db", "export", e.databaseID, "-o", "table")
+	assert.ErrorIs(t, err, errUsage)
+}
+
+func TestRun_DatabasesExport_IDProperty(t *testing.T) {
+	e := newTestEnv(t)
+
+	databaseID := e.server.AddDatabase(notion.Database{
+		Title: []notion.RichText{notion.Text("Invoices")},
+		Properties: map[string]notion.Property{
+			"Name": notion.TitleProperty{},
+			"id":   notion.RichTextProperty{},
+		},
+	})
+
+	parent := notion.DatabaseParent{DatabaseID: databaseID}
+	parent.Type = notion.ParentTypeDatabase
+
+	pageID := e.server.AddPage(notion.Page{
+		Parent: parent,
+		Properties: map[string]notion.PropertyValue{
+			"Name": notion.TitlePropertyValue{Title: []notion.RichText{notion.Text("Kale")}},
+			"id":   notion.RichTextPropertyValue{RichText: []notion.RichText{notion.Text("INV-1")}},
+		},
+	})
+
+	stdout, _, err := e.run(t, "db", "export", databaseID, "--format", "jsonl")
+	require.NoError(t, err)
+
+	var row struct {
+		ID         string            `json:"id"`
+		Properties map[string]string `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &row))
+	assert.Equal(t, pageID, row.ID)
+	assert.Equal(t, map[string]string{"Name": "Kale", "id": "INV-1"}, row.Properties)
+}
+
+func TestExportColumns(t *testing.T) {
+	title := notion.TitleProperty{}
+	title.Type = notion.PropertyTypeTitle
+
+	database := notion.Database{Properties: map[string]notion.Property{
+		"Assignee": notion.PeopleProperty{},
+		"Task":     title,
+		"Due":      notion.DateProperty{},
+	}}
+
+	assert.Equal(t, []string{"Task", "Assignee", "Due"}, exportColumns(database))
+}
+
+func TestFlattenValue(t *testing.T) {
+	end, three, budget := "2021-05-21", 3.0, 24.0
+
+	ada := &notion.PersonUser{Person: notion.Person{Email: "ada@example.com"}}
+	ada.Name = "Ada"
+
+	bot := &notion.BotUser{}
+	bot.Name = "Importer"
+
+	tests := []struct {
+		name  string
+		value notion.PropertyValue
+		want  interface{}
+		cell  string
+	}{
+		{
+			name:  "title",
+			value: &notion.TitlePropertyValue{Title: []notion.RichText{notion.Text("Buy "), notion.Text("kale").Bold()}},
+			want:  "Buy kale",
+			cell:  "Buy kale",
+		},
+		{
+			name:  "number",
+			value: &notion.NumberPropertyValue{Number: 1234567.5},
+			want:  1234567.5,
+			cell:  "1234567.5",
+		},
+		{
+			name:  "date",
+			value: &notion.DatePropertyValue{Date: notion.Date{Start: "2021-05-20"}},
+			want:  "2021-05-20",
+			cell:  "2021-05-20",
+		},
+		{
+			name:  "date range",
+			value: &notion.DatePropertyValue{Date: notion.Date{Start: "2021-05-20", End: &end}},
+			want:  "2021-05-20/2021-05-21",
+			cell:  "2021-05-20/2021-05-21",
+		},
+		{
+			name:  "people",
+			value: &notion.PeoplePropertyValue{People: []notion.User{ada, bot, &notion.PartialUser{ID: "grace"}}},
+			want:  []string{"Ada <ada@example.com>", "Importer", "grace"},
+			cell:  "Ada <ada@example.com>, Importer, grace",
+		},
+		{
+			name:  "relation",
+			value: &notion.RelationPropertyValue{Relation: []notion.PageReference{{ID: "kale"}, {ID: "garlic"}}},
+			want:  []string{"kale", "garlic"},
+			cell:  "kale, garlic",
+		},
+		{
+			name:  "checkbox",
+			value: &notion.CheckboxPropertyValue{Checkbox: true},
+			want:  true,
+			cell:  "true",
+		},
+		{
+			name:  "date formula",
+			value: &notion.FormulaPropertyValue{Formula: &notion.DateFormulaValue{Date: notion.DatePropertyValue{Date: notion.Date{Start: "2021-05-20", End: &end}}}},
+			want:  "2021-05-20/2021-05-21",
+			cell:  "2021-05-20/2021-05-21",
+		},
+		{
+			name:  "number formula",
+			value: &notion.FormulaPropertyValue{Formula: &notion.NumberFormulaValue{Number: &three}},
+			want:  3.0,
+			cell:  "3",
+		},
+		{
+			name:  "number rollup",
+			value: &notion.RollupPropertyValue{Rollup: &notion.NumberRollupValue{Number: &budget}},
+			want:  24.0,
+			cell:  "24",
+		},
+		{
+			name: "array rollup",
+			value: &notion.RollupPropertyValue{Rollup: &notion.ArrayRollupValue{Array: []notion.PropertyValue{
+				&notion.DatePropertyValue{Date: notion.Date{Start: "2021-05-20", End: &end}},
+				&notion.PeoplePropertyValue{People: []notion.User{ada}},
+			}}},
+			want: []interface{}{"2021-05-20/2021-05-21", []string{"Ada <ada@example.com>"}},
+			cell: "2021-05-20/2021-05-21, Ada <ada@example.com>",
+		},
+		{
+			name:  "nil",
+			value: nil,
+			want:  nil,
+			cell:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenValue(tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.cell, formatCell(got))
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Command notion is a command-line client of the Notion API:
+//
+//	notion users list|get
+//	notion db list|get|query|export
+//	notion page get|create|update
+//	notion blocks ls|append
+//	notion search
+//
+// For example:
+//
+//	notion users list --all
+//	notion db query def72422-ea36-4c8a-a6f1-a34e11a7fe54 --query 'Status = "Done" order by Price desc' -o table
+//	notion db export def72422-ea36-4c8a-a6f1-a34e11a7fe54 --format csv --query 'Done = false' > tasks.csv
+//	notion page create --database def72422-ea36-4c8a-a6f1-a34e11a7fe54 --set Name="Buy kale" --set Price=4
+//	notion blocks append 98ad959b-2b6a-4774-80ee-00246fb0ea9b --markdown notes.md
+//
+// The token is read from NOTION_AUTH_TOKEN. The results are printed as JSON by default, or as a table or YAML with
+// -o. The commands listing results print the first page of results, or every result with --all, and the cursor of
+// the next page is printed on the standard error.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var (
+	errUsage = errors.New("invalid usage")
+	errToken = errors.New("NOTION_AUTH_TOKEN is not set")
+)
+
+type env struct {
+	api    *notion.API
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// usage is the usage of the command being run.
+	usage string
+}
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, e *env, args []string) error
+}
+
+// commands are the commands by name, e.g. "users list".
+var commands = map[string]command{ // nolint:gochecknoglobals
+	"users list":    {usage: "[--all] [--cursor cursor] [--page-size n]", run: usersList},
+	"users get":     {usage: "<user-id>", run: usersGet},
+	"db list":       {usage: "[--all] [--cursor cursor] [--page-size n]", run: databasesList},
+	"db get":        {usage: "<database-id>", run: databasesGet},
+	"db query":      {usage: "<database-id> [--query query] [--all] [--cursor cursor] [--page-size n]", run: databasesQuery},
+	"db export":     {usage: "<database-id> [--format csv|jsonl|tsv] [--query query] [--page-size n]", run: databasesExport},
+	"page get":      {usage: "<page-id>", run: pageGet},
+	"page create":   {usage: "--database <database-id> | --page <page-id> [--set name=value]... [--markdown file]", run: pageCreate},
+	"page update":   {usage: "<page-id> --set name=value...", run: pageUpdate},
+	"blocks ls":     {usage: "<block-id> [--all] [--cursor cursor] [--page-size n]", run: blocksList},
+	"blocks append": {usage: "<block-id> --markdown file | text...", run: blocksAppend},
+	"search":        {usage: "[query] [--object page|database] [--sort ascending|descending] [--all] [--cursor cursor] [--page-size n]", run: search},
+}
+
+func main() {
+	token := os.Getenv("NOTION_AUTH_TOKEN")
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "notion:", errToken)
+		os.Exit(1)
+	}
+
+	e := &env{api: notion.New(token), stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+
+	if err := run(context.Background(), e, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "notion:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, e *env, args []string) error {
+	for n := 1; n <= 2 && n <= len(args); n++ {
+		name := strings.Join(args[:n], " ")
+
+		if cmd, ok := commands[name]; ok {
+			e.usage = fmt.Sprintf("notion %s %s", name, cmd.usage)
+
+			return cmd.run(ctx, e, args[n:])
+		}
+	}
+
+	printUsage(e.stderr)
+
+	return errUsage
+}
+
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Usage:")
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  notion %s %s\n", name, commands[name].usage)
+	}
+
+	fmt.Fprintln(w, "\nEvery command but db export accepts -o json|table|yaml. The token is read from NOTION_AUTH_TOKEN.")
+}
+
+// flagSet is the flag set of a command, with the output format and the pagination flags.
+type flagSet struct {
+	*flag.FlagSet
+
+	output string
+
+	all      bool
+	cursor   string
+	pageSize int
+}
+
+// newFlagSet returns the flag set of a command printing its results in the format given by -o.
+func newFlagSet(e *env, name string) *flagSet {
+	f := newBaseFlagSet(e, name)
+
+	f.StringVar(&f.output, "o", formatJSON, "output `format`: json, table or yaml")
+	f.StringVar(&f.output, "output", formatJSON, "output `format`: json, table or yaml")
+
+	return f
+}
+
+// newBaseFlagSet returns the flag set of a command without the output format flags.
+func newBaseFlagSet(e *env, name string) *flagSet {
+	f := &flagSet{FlagSet: flag.NewFlagSet("notion "+name, flag.ContinueOnError)}
+	f.SetOutput(e.stderr)
+
+	f.Usage = func() {
+		fmt.Fprintln(e.stderr, "Usage:", e.usage)
+		f.PrintDefaults()
+	}
+
+	return f
+}
+
+// paginated adds the pagination flags.
+func (f *flagSet) paginated() *flagSet {
+	f.BoolVar(&f.all, "all", false, "fetch every page of results")
+	f.StringVar(&f.cursor, "cursor", "", "`cursor` of the page of results")
+	f.IntVar(&f.pageSize, "page-size", 0, "`number` of results per page, 100 at most")
+
+	return f
+}
+
+func (f *flagSet) pagination() notion.PaginationParameters {
+	return notion.PaginationParameters{StartCursor: f.cursor, PageSize: int32(f.pageSize)}
+}
+
+// parse parses the flags, which may be given before or after the arguments, and returns the arguments. The number
+// of arguments must be between min and max, max is not checked when it is negative.
+func (f *flagSet) parse(args []string, min, max int) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := f.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %s", errUsage, err)
+		}
+
+		rest := f.Args()
+
+		if parsed := len(args) - len(rest); parsed > 0 && args[parsed-1] == "--" {
+			positional = append(positional, rest...)
+
+			break
+		}
+
+		if args = rest; len(args) == 0 {
+			break
+		}
+
+		positional, args = append(positional, args[0]), args[1:]
+	}
+
+	if len(positional) < min || max >= 0 && len(positional) > max {
+		f.Usage()
+
+		return nil, errUsage
+	}
+
+	if _, ok := formats[f.output]; !ok && f.Lookup("output") != nil {
+		return nil, fmt.Errorf("%w: unknown output format %q", errUsage, f.output)
+	}
+
+	return positional, nil
+}
+
+// printNextCursor prints the cursor of the next page of results, when there is one.
+func (e *env) printNextCursor(list notion.PaginatedList) {
+	if list.HasMore && list.NextCursor != "" {
+		fmt.Fprintf(e.stderr, "More results with --cursor %s, or every result with --all.\n", list.NextCursor)
+	}
+}
+
+// stringsFlag is a flag which can be repeated.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/notiontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnv struct {
+	*env
+
+	server     *notiontest.Server
+	databaseID string
+	pageIDs    []string
+}
+
+// newTestEnv returns an environment using a fake of the API with two users, a database of three tasks and a page.
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	server := notiontest.NewServer()
+	t.Cleanup(server.Close)
+
+	for _, name := range []string{"Ada", "Grace"} {
+		user := notion.PersonUser{Person: notion.Person{Email: strings.ToLower(name) + "@example.com"}}
+		user.Name, user.Type = name, notion.UserTypePerson
+
+		server.AddUser(user)
+	}
+
+	workspace := notion.WorkspaceParent{}
+	workspace.Type = notion.ParentTypeWorkspace
+
+	rootID := server.AddPage(notion.Page{
+		Parent: workspace,
+		Properties: map[string]notion.PropertyValue{
+			"title": notion.TitlePropertyValue{Title: []notion.RichText{notion.Text("Home")}},
+		},
+	})
+
+	databaseID := server.AddDatabase(notion.Database{
+		Title: []notion.RichText{notion.Text("Tasks")},
+		Properties: map[string]notion.Property{
+			"Name":  notion.TitleProperty{},
+			"Price": notion.NumberProperty{},
+			"Tags":  notion.MultiSelectProperty{},
+			"Status": notion.SelectProperty{Select: notion.SelectPropertyOption{Options: []notion.SelectOption{
+				{Name: "Todo", Color: notion.ColorRed},
+				{Name: "Done", Color: notion.ColorGreen},
+			}}},
+		},
+	})
+
+	parent := notion.DatabaseParent{DatabaseID: databaseID}
+	parent.Type = notion.ParentTypeDatabase
+
+	pageIDs := []string{rootID}
+
+	for _, task := range []struct {
+		name   string
+		price  float64
+		status string
+	}{
+		{"Buy kale", 4, "Done"},
+		{"Cook kale", 12, "Todo"},
+		{"Eat kale", 8, "Todo"},
+	} {
+		pageIDs = append(pageIDs, server.AddPage(notion.Page{
+			Parent: parent,
+			Properties: map[string]notion.PropertyValue{
+				"Name":   notion.TitlePropertyValue{Title: []notion.RichText{notion.Text(task.name)}},
+				"Price":  notion.NumberPropertyValue{Number: task.price},
+				"Status": notion.SelectPropertyValue{Select: notion.SelectPropertyValueOption{Name: task.status}},
+			},
+		}))
+	}
+
+	return &testEnv{
+		env:        &env{api: server.Client(), stdin: strings.NewReader(""), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}},
+		server:     server,
+		databaseID: databaseID,
+		pageIDs:    pageIDs,
+	}
+}
+
+// run runs a command and returns its standard output and error.
+func (e *testEnv) run(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+
+	stdout, stderr := e.stdout.(*bytes.Buffer), e.stderr.(*bytes.Buffer)
+	stdout.Reset()
+	stderr.Reset()
+
+	err := run(context.Background(), e.env, args)
+
+	return stdout.String(), stderr.String(), err
+}
+
+func TestRun_Usage(t *testing.T) {
+	e := newTestEnv(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"users", "delete"}},
+		{name: "missing argument", args: []string{"users", "get"}},
+		{name: "extra argument", args: []string{"users", "list", "everyone"}},
+		{name: "unknown flag", args: []string{"users", "list", "--everyone"}},
+		{name: "unknown format", args: []string{"users", "list", "-o", "xml"}},
+		{name: "unknown object", args: []string{"search", "--object", "block"}},
+		{name: "unknown direction", args: []string{"search", "--sort", "up"}},
+		{name: "no parent", args: []string{"page", "create", "--set", "title=Notes"}},
+		{name: "no value", args: []string{"page", "update", e.pageIDs[1]}},
+		{name: "no content", args: []string{"blocks", "append", e.pageIDs[0]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := e.run(t, tt.args...)
+			assert.ErrorIs(t, err, errUsage)
+		})
+	}
+}
+
+func TestRun_Users(t *testing.T) {
+	e := newTestEnv(t)
+
+	stdout, stderr, err := e.run(t, "users", "list", "--page-size", "1", "-o", "table")
+	require.NoError(t, err)
+	assert.Regexp(t, `^ID +TYPE +NAME +EMAIL\n\S+ +person +Ada +ada@example.com\n$`, stdout)
+	assert.Contains(t, stderr, "More results with --cursor ")
+
+	stdout, stderr, err = e.run(t, "users", "list", "--all", "--page-size", "1")
+	require.NoError(t, err)
+	assert.Empty(t, stderr)
+
+	var users []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &users))
+	require.Len(t, users, 2)
+	assert.Equal(t, "Grace", users[1]["name"])
+
+	stdout, _, err = e.run(t, "users", "get", users[1]["id"].(string), "-o", "yaml")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "name: Grace\n")
+	assert.Contains(t, stdout, "person:\n  email: grace@example.com\n")
+
+	_, _, err = e.run(t, "users", "get", "unknown")
+	assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+}
@@ -0,0 +1,399 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatJSON  = "json"
+	formatTable = "table"
+	formatYAML  = "yaml"
+)
+
+var formats = map[string]bool{formatJSON: true, formatTable: true, formatYAML: true} // nolint:gochecknoglobals
+
+// output is the result of a command, printed as JSON or YAML, or as a table of rows.
+type output struct {
+	value  interface{}
+	header []string
+	rows   [][]string
+}
+
+func (e *env) print(format string, out output) error {
+	switch format {
+	case formatTable:
+		return writeTable(e.stdout, out.header, out.rows)
+	case formatYAML:
+		return writeYAML(e.stdout, out.value)
+	}
+
+	return writeJSON(e.stdout, out.value)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return nil
+}
+
+// writeYAML writes a value as it is encoded in JSON, keeping the order of the fields of the objects.
+func writeYAML(w io.Writer, v interface{}) error {
+	var buf bytes.Buffer
+
+	if err := writeJSON(&buf, v); err != nil {
+		return err
+	}
+
+	// JSON is a subset of YAML, so the JSON document is decoded as YAML and encoded again in the block style.
+	var node yaml.Node
+
+	if err := yaml.Unmarshal(buf.Bytes(), &node); err != nil {
+		return fmt.Errorf("failed to convert JSON to YAML: %w", err)
+	}
+
+	resetStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2) // nolint:gomnd
+
+	if err := encoder.Encode(&node); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	return encoder.Close() // nolint:wrapcheck
+}
+
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0) // nolint:gomnd
+
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, row := range rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, strings.NewReplacer("\t", " ", "\n", " ").Replace(cell))
+		}
+
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush() // nolint:wrapcheck
+}
+
+func usersOutput(value interface{}, users ...notion.User) output {
+	out := output{value: value, header: []string{"ID", "TYPE", "NAME", "EMAIL"}}
+
+	for _, user := range users {
+		switch u := user.(type) {
+		case *notion.PersonUser:
+			out.rows = append(out.rows, []string{u.ID, string(u.Type), u.Name, u.Person.Email})
+		case *notion.BotUser:
+			out.rows = append(out.rows, []string{u.ID, string(u.Type), u.Name, ""})
+		case *notion.PartialUser:
+			out.rows = append(out.rows, []string{u.ID, "", "", ""})
+		}
+	}
+
+	return out
+}
+
+func databasesOutput(databases []notion.Database) output {
+	out := output{value: databases, header: []string{"ID", "TITLE", "LAST EDITED"}}
+
+	for _, database := range databases {
+		out.rows = append(out.rows, []string{database.ID, notion.PlainText(database.Title), formatTime(database.LastEditedTime)})
+	}
+
+	return out
+}
+
+// databaseOutput prints the properties of a database in a table.
+func databaseOutput(database notion.Database) output {
+	out := output{value: database, header: []string{"PROPERTY", "TYPE", "ID"}}
+
+	for _, name := range sortedKeys(database.Properties) {
+		property := database.Properties[name]
+
+		out.rows = append(out.rows, []string{name, string(notion.PropertyTypeOf(property)), notion.PropertyIDOf(property)})
+	}
+
+	return out
+}
+
+// pagesOutput prints the pages in a table with a column for each property, the title first.
+func pagesOutput(pages []notion.Page) output {
+	var titles, others []string
+
+	seen := make(map[string]bool)
+
+	for _, page := range pages {
+		for name, value := range page.Properties {
+			if seen[name] {
+				continue
+			}
+
+			seen[name] = true
+
+			if _, ok := value.(*notion.TitlePropertyValue); ok {
+				titles = append(titles, name)
+			} else {
+				others = append(others, name)
+			}
+		}
+	}
+
+	sort.Strings(titles)
+	sort.Strings(others)
+
+	columns := append(titles, others...)
+	out := output{value: pages, header: append([]string{"ID"}, columns...)}
+
+	for _, page := range pages {
+		row := []string{page.ID}
+		for _, name := range columns {
+			row = append(row, formatValue(page.Properties[name]))
+		}
+
+		out.rows = append(out.rows, row)
+	}
+
+	return out
+}
+
+// pageOutput prints the properties of a page in a table.
+func pageOutput(page notion.Page) output {
+	out := output{value: page, header: []string{"PROPERTY", "VALUE"}}
+
+	for _, name := range sortedKeys(page.Properties) {
+		out.rows = append(out.rows, []string{name, formatValue(page.Properties[name])})
+	}
+
+	return out
+}
+
+func blocksOutput(value interface{}, blocks ...notion.Block) output {
+	out := output{value: value, header: []string{"ID", "TYPE", "CHILDREN", "CONTENT"}}
+
+	for _, block := range blocks {
+		base := block.GetBase()
+		content := strings.TrimSpace(markdown.Blocks([]notion.Block{block}))
+
+		if i := strings.IndexByte(content, '\n'); i >= 0 {
+			content = content[:i] + " ..."
+		}
+
+		out.rows = append(out.rows, []string{base.ID, string(base.Type), fmt.Sprint(base.HasChildren), content})
+	}
+
+	return out
+}
+
+func searchOutput(results []notion.SearchableObject) output {
+	out := output{value: results, header: []string{"OBJECT", "ID", "TITLE", "LAST EDITED"}}
+
+	for _, result := range results {
+		switch r := result.(type) {
+		case *notion.Page:
+			out.rows = append(out.rows, []string{string(r.Object), r.ID, pageTitle(*r), formatTime(r.LastEditedTime)})
+		case *notion.Database:
+			out.rows = append(out.rows, []string{string(r.Object), r.ID, notion.PlainText(r.Title), formatTime(r.LastEditedTime)})
+		}
+	}
+
+	return out
+}
+
+func pageTitle(page notion.Page) string {
+	for _, value := range page.Properties {
+		if title, ok := value.(*notion.TitlePropertyValue); ok {
+			return notion.PlainText(title.Title)
+		}
+	}
+
+	return ""
+}
+
+// formatValue formats a property value as flattened by flattenValue, lists being separated by commas.
+func formatValue(value notion.PropertyValue) string {
+	return formatCell(flattenValue(value))
+}
+
+// flattenValue simplifies a property value to a scalar or a list of scalars as markdown.Value does, except that
+// dates are ISO 8601 ranges, e.g. 2021-05-20/2021-05-21, and people are named with their email.
+// nolint:cyclop
+func flattenValue(value notion.PropertyValue) interface{} {
+	switch v := deref(value).(type) {
+	case notion.DatePropertyValue:
+		return isoDate(v.Date)
+
+	case notion.PeoplePropertyValue:
+		names := make([]string, 0, len(v.People))
+		for _, user := range v.People {
+			names = append(names, personName(user))
+		}
+
+		return names
+
+	case notion.CreatedByPropertyValue:
+		return personName(v.CreatedBy)
+
+	case notion.LastEditedByPropertyValue:
+		return personName(v.LastEditedBy)
+
+	case notion.FormulaPropertyValue:
+		switch formula := deref(v.Formula).(type) {
+		case notion.StringFormulaValue:
+			if formula.String != nil {
+				return *formula.String
+			}
+
+			return nil
+
+		case notion.NumberFormulaValue:
+			if formula.Number != nil {
+				return *formula.Number
+			}
+
+			return nil
+
+		case notion.DateFormulaValue:
+			return isoDate(formula.Date.Date)
+		}
+
+	case notion.RollupPropertyValue:
+		switch rollup := deref(v.Rollup).(type) {
+		case notion.NumberRollupValue:
+			if rollup.Number != nil {
+				return *rollup.Number
+			}
+
+			return nil
+
+		case notion.DateRollupValue:
+			return isoDate(rollup.Date)
+
+		case notion.ArrayRollupValue:
+			values := make([]interface{}, 0, len(rollup.Array))
+			for _, value := range rollup.Array {
+				values = append(values, flattenValue(value))
+			}
+
+			return values
+		}
+	}
+
+	return markdown.Value(value)
+}
+
+func isoDate(date notion.Date) string {
+	if date.End == nil {
+		return date.Start
+	}
+
+	return date.Start + "/" + *date.End
+}
+
+// personName returns the name of a user followed by their email, e.g. Ada <ada@example.com>, or the ID of a user
+// of which only the ID is known.
+func personName(user notion.User) string {
+	switch u := deref(user).(type) {
+	case notion.PersonUser:
+		if u.Person.Email == "" {
+			return u.Name
+		}
+
+		if u.Name == "" {
+			return u.Person.Email
+		}
+
+		return fmt.Sprintf("%s <%s>", u.Name, u.Person.Email)
+
+	case notion.BotUser:
+		return u.Name
+
+	case notion.PartialUser:
+		return u.ID
+	}
+
+	return ""
+}
+
+// formatCell formats a value flattened by flattenValue, lists being separated by commas.
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		cells := make([]string, 0, len(v))
+		for _, item := range v {
+			cells = append(cells, formatCell(item))
+		}
+
+		return strings.Join(cells, ", ")
+	}
+
+	return fmt.Sprint(value)
+}
+
+func deref(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		return rv.Elem().Interface()
+	}
+
+	return v
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/markdown"
+)
+
+func pageGet(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "page get")
+
+	args, err := flags.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.api.Pages().Retrieve(ctx, notion.PagesRetrieveParameters{PageID: args[0]})
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	return e.print(flags.output, pageOutput(resp.Page))
+}
+
+func pageCreate(ctx context.Context, e *env, args []string) error {
+	var values stringsFlag
+
+	flags := newFlagSet(e, "page create")
+	databaseID := flags.String("database", "", "`ID` of the database of the page")
+	pageID := flags.String("page", "", "`ID` of the parent page of the page")
+	content := flags.String("markdown", "", "Markdown `file` of the content of the page, or - for the standard input")
+	flags.Var(&values, "set", "set the property `name=value`, lists are separated by commas and date ranges by a slash")
+
+	if _, err := flags.parse(args, 0, 0); err != nil {
+		return err
+	}
+
+	if (*databaseID == "") == (*pageID == "") {
+		flags.Usage()
+
+		return fmt.Errorf("%w: either --database or --page must be set", errUsage)
+	}
+
+	params := notion.PagesCreateParameters{Parent: notion.PageParentInput{PageID: *pageID}}
+	schema := map[string]notion.PropertyType{"title": notion.PropertyTypeTitle}
+
+	if *databaseID != "" {
+		database, err := e.api.Databases().Retrieve(ctx, notion.DatabasesRetrieveParameters{DatabaseID: *databaseID})
+		if err != nil {
+			return err // nolint:wrapcheck
+		}
+
+		params.Parent = notion.DatabaseParentInput{DatabaseID: *databaseID}
+		schema = databaseSchema(database.Database)
+	}
+
+	properties, err := parseValues(schema, values)
+	if err != nil {
+		return err
+	}
+
+	params.Properties = properties
+
+	if *content != "" {
+		source, err := readFile(e.stdin, *content)
+		if err != nil {
+			return err
+		}
+
+		params.Children = markdown.Parse(source)
+	}
+
+	resp, err := e.api.Pages().Create(ctx, params)
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	return e.print(flags.output, pageOutput(resp.Page))
+}
+
+func pageUpdate(ctx context.Context, e *env, args []string) error {
+	var values stringsFlag
+
+	flags := newFlagSet(e, "page update")
+	flags.Var(&values, "set", "set the property `name=value`, lists are separated by commas and date ranges by a slash")
+
+	args, err := flags.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		flags.Usage()
+
+		return fmt.Errorf("%w: --set must be set", errUsage)
+	}
+
+	page, err := e.api.Pages().Retrieve(ctx, notion.PagesRetrieveParameters{PageID: args[0]})
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	schema := map[string]notion.PropertyType{"title": notion.PropertyTypeTitle}
+
+	if parent, ok := page.Parent.(*notion.DatabaseParent); ok {
+		database, err := e.api.Databases().Retrieve(ctx, notion.DatabasesRetrieveParameters{DatabaseID: parent.DatabaseID})
+		if err != nil {
+			return err // nolint:wrapcheck
+		}
+
+		schema = databaseSchema(database.Database)
+	}
+
+	properties, err := parseValues(schema, values)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.api.Pages().Update(ctx, notion.PagesUpdateParameters{PageID: args[0], Properties: properties})
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	return e.print(flags.output, pageOutput(resp.Page))
+}
+
+// databaseSchema returns the types of the properties of a database by name.
+func databaseSchema(database notion.Database) map[string]notion.PropertyType {
+	schema := make(map[string]notion.PropertyType, len(database.Properties))
+
+	for name, property := range database.Properties {
+		schema[name] = notion.PropertyTypeOf(property)
+	}
+
+	return schema
+}
+
+// parseValues parses the values of properties given as name=value.
+func parseValues(schema map[string]notion.PropertyType, values []string) (map[string]notion.PropertyValue, error) {
+	properties := make(map[string]notion.PropertyValue, len(values))
+
+	for _, nameValue := range values {
+		i := strings.IndexByte(nameValue, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %q is not a name=value pair", errUsage, nameValue)
+		}
+
+		name, text := nameValue[:i], nameValue[i+1:]
+
+		propertyType, ok := schema[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown property %q", errUsage, name)
+		}
+
+		value, err := parseValue(propertyType, text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: property %q: %s", errUsage, name, err)
+		}
+
+		properties[name] = value
+	}
+
+	return properties, nil
+}
+
+// parseValue parses the value of a property of the given type.
+// nolint:cyclop
+func parseValue(propertyType notion.PropertyType, text string) (notion.PropertyValue, error) {
+	switch propertyType { // nolint:exhaustive
+	case notion.PropertyTypeTitle:
+		return notion.TitlePropertyValue{Title: []notion.RichText{notion.Text(text)}}, nil
+
+	case notion.PropertyTypeRichText:
+		return notion.RichTextPropertyValue{RichText: []notion.RichText{notion.Text(text)}}, nil
+
+	case notion.PropertyTypeNumber:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", text) // nolint:goerr113
+		}
+
+		return notion.NumberPropertyValue{Number: number}, nil
+
+	case notion.PropertyTypeSelect:
+		return notion.SelectPropertyValue{Select: notion.SelectPropertyValueOption{Name: text}}, nil
+
+	case notion.PropertyTypeMultiSelect:
+		options := make([]notion.MultiSelectPropertyValueOption, 0)
+		for _, name := range splitList(text) {
+			options = append(options, notion.MultiSelectPropertyValueOption{Name: name})
+		}
+
+		return notion.MultiSelectPropertyValue{MultiSelect: options}, nil
+
+	case notion.PropertyTypeDate:
+		date := notion.Date{Start: text}
+		if i := strings.IndexByte(text, '/'); i >= 0 {
+			end := text[i+1:]
+			date = notion.Date{Start: text[:i], End: &end}
+		}
+
+		return notion.DatePropertyValue{Date: date}, nil
+
+	case notion.PropertyTypeCheckbox:
+		checked, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", text) // nolint:goerr113
+		}
+
+		return notion.CheckboxPropertyValue{Checkbox: checked}, nil
+
+	case notion.PropertyTypeURL:
+		return notion.URLPropertyValue{URL: text}, nil
+
+	case notion.PropertyTypeEmail:
+		return notion.EmailPropertyValue{Email: text}, nil
+
+	case notion.PropertyTypePhoneNumber:
+		return notion.PhoneNumberPropertyValue{PhoneNumber: text}, nil
+
+	case notion.PropertyTypePeople:
+		people := make([]notion.User, 0)
+		for _, id := range splitList(text) {
+			people = append(people, notion.PartialUser{Object: notion.ObjectTypeUser, ID: id})
+		}
+
+		return notion.PeoplePropertyValue{People: people}, nil
+
+	case notion.PropertyTypeRelation:
+		references := make([]notion.PageReference, 0)
+		for _, id := range splitList(text) {
+			references = append(references, notion.PageReference{ID: id})
+		}
+
+		return notion.RelationPropertyValue{Relation: references}, nil
+	}
+
+	return nil, fmt.Errorf("%s properties cannot be set", propertyType) // nolint:goerr113
+}
+
+// splitList splits a list separated by commas, without empty items.
+func splitList(text string) []string {
+	var items []string
+
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// readFile reads a file, or the standard input when the path is -.
+func readFile(stdin io.Reader, path string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if path == "-" {
+		data, err = ioutil.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return string(data), nil
+}
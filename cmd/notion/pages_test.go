@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Pages(t *testing.T) {
+	e := newTestEnv(t)
+
+	stdout, _, err := e.run(t, "page", "get", e.pageIDs[1], "-o", "table")
+	require.NoError(t, err)
+	assert.Equal(t, "PROPERTY  VALUE\nName      Buy kale\nPrice     4\nStatus    Done\nTags      \n", stdout)
+
+	stdout, _, err = e.run(t, "page", "create", "--database", e.databaseID,
+		"--set", "Name=Peel kale", "--set", "Price=2.5", "--set", "Tags=green, leafy", "-o", "table")
+	require.NoError(t, err)
+	assert.Equal(t, "PROPERTY  VALUE\nName      Peel kale\nPrice     2.5\nStatus    \nTags      green, leafy\n", stdout)
+
+	e.stdin = strings.NewReader("# Kale\n\n- [ ] Buy kale\n")
+
+	stdout, _, err = e.run(t, "page", "create", "--page", e.pageIDs[0], "--set", "title=Notes", "--markdown", "-")
+	require.NoError(t, err)
+
+	var page struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &page))
+
+	children, err := e.api.Blocks().Children().List(context.Background(), notion.BlocksChildrenListParameters{BlockID: page.ID})
+	require.NoError(t, err)
+	require.Len(t, children.Results, 2)
+	assert.Equal(t, notion.BlockTypeHeading1, children.Results[0].GetBase().Type)
+	assert.Equal(t, notion.BlockTypeToDo, children.Results[1].GetBase().Type)
+
+	stdout, _, err = e.run(t, "page", "update", e.pageIDs[2], "--set", "Status=Done", "--set", "Price=10", "-o", "yaml")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "number: 10\n")
+	assert.Contains(t, stdout, "name: Done\n")
+
+	_, _, err = e.run(t, "page", "update", e.pageIDs[2], "--set", "Price=ten")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, _, err = e.run(t, "page", "update", e.pageIDs[2], "--set", "Nmae=Cook kale")
+	assert.ErrorIs(t, err, errUsage)
+}
+
+func TestParseValue(t *testing.T) {
+	end := "2021-05-21"
+
+	tests := []struct {
+		name         string
+		propertyType notion.PropertyType
+		text         string
+		want         notion.PropertyValue
+		wantErr      bool
+	}{
+		{
+			name:         "title",
+			propertyType: notion.PropertyTypeTitle,
+			text:         "Buy kale",
+			want:         notion.TitlePropertyValue{Title: []notion.RichText{notion.Text("Buy kale")}},
+		},
+		{
+			name:         "number",
+			propertyType: notion.PropertyTypeNumber,
+			text:         "-1.5",
+			want:         notion.NumberPropertyValue{Number: -1.5},
+		},
+		{
+			name:         "invalid number",
+			propertyType: notion.PropertyTypeNumber,
+			text:         "ten",
+			wantErr:      true,
+		},
+		{
+			name:         "multi select",
+			propertyType: notion.PropertyTypeMultiSelect,
+			text:         "green, leafy,",
+			want: notion.MultiSelectPropertyValue{MultiSelect: []notion.MultiSelectPropertyValueOption{
+				{Name: "green"}, {Name: "leafy"},
+			}},
+		},
+		{
+			name:         "date",
+			propertyType: notion.PropertyTypeDate,
+			text:         "2021-05-20",
+			want:         notion.DatePropertyValue{Date: notion.Date{Start: "2021-05-20"}},
+		},
+		{
+			name:         "date range",
+			propertyType: notion.PropertyTypeDate,
+			text:         "2021-05-20/2021-05-21",
+			want:         notion.DatePropertyValue{Date: notion.Date{Start: "2021-05-20", End: &end}},
+		},
+		{
+			name:         "checkbox",
+			propertyType: notion.PropertyTypeCheckbox,
+			text:         "true",
+			want:         notion.CheckboxPropertyValue{Checkbox: true},
+		},
+		{
+			name:         "people",
+			propertyType: notion.PropertyTypePeople,
+			text:         "ada,grace",
+			want: notion.PeoplePropertyValue{People: []notion.User{
+				notion.PartialUser{Object: notion.ObjectTypeUser, ID: "ada"},
+				notion.PartialUser{Object: notion.ObjectTypeUser, ID: "grace"},
+			}},
+		},
+		{
+			name:         "relation",
+			propertyType: notion.PropertyTypeRelation,
+			text:         "kale",
+			want:         notion.RelationPropertyValue{Relation: []notion.PageReference{{ID: "kale"}}},
+		},
+		{
+			name:         "formula",
+			propertyType: notion.PropertyTypeFormula,
+			text:         "1",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseValue(tt.propertyType, tt.text)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mkfsn/notion-go"
+)
+
+func search(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "search").paginated()
+	object := flags.String("object", "", "only search the objects of the `type` page or database")
+	direction := flags.String("sort", "", "sort the results by last edited time in the `direction` ascending or descending")
+
+	args, err := flags.parse(args, 0, -1)
+	if err != nil {
+		return err
+	}
+
+	params := notion.SearchParameters{PaginationParameters: flags.pagination(), Query: strings.Join(args, " ")}
+
+	switch *object {
+	case "":
+	case string(notion.SearchFilterValuePage), string(notion.SearchFilterValueDatabase):
+		params.Filter = notion.SearchFilter{Property: notion.SearchFilterPropertyObject, Value: notion.SearchFilterValue(*object)}
+	default:
+		return fmt.Errorf("%w: unknown object type %q", errUsage, *object)
+	}
+
+	switch *direction {
+	case "":
+	case string(notion.SearchSortDirectionAscending), string(notion.SearchSortDirectionDescending):
+		params.Sort = notion.SearchSort{Timestamp: notion.SearchSortTimestampLastEditedTime, Direction: notion.SearchSortDirection(*direction)}
+	default:
+		return fmt.Errorf("%w: unknown sort direction %q", errUsage, *direction)
+	}
+
+	if flags.all {
+		results, err := e.api.SearchAll(ctx, params).Collect(0)
+		if err != nil {
+			return err // nolint:wrapcheck
+		}
+
+		return e.print(flags.output, searchOutput(results))
+	}
+
+	resp, err := e.api.Search(ctx, params)
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	e.printNextCursor(resp.PaginatedList)
+
+	return e.print(flags.output, searchOutput(resp.Results))
+}
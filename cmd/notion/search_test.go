@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Search(t *testing.T) {
+	e := newTestEnv(t)
+
+	stdout, _, err := e.run(t, "search", "kale", "--object", "page", "-o", "table")
+	require.NoError(t, err)
+	assert.Regexp(t, `^OBJECT +ID +TITLE +LAST EDITED\n(page +\S+ +\w+ kale +\S+\n){3}$`, stdout)
+
+	stdout, _, err = e.run(t, "search", "--object", "database", "-o", "table")
+	require.NoError(t, err)
+	assert.Regexp(t, `^OBJECT +ID +TITLE +LAST EDITED\ndatabase +\S+ +Tasks +\S+\n$`, stdout)
+
+	stdout, stderr, err := e.run(t, "search", "--all", "--page-size", "2", "--sort", "descending", "-o", "table")
+	require.NoError(t, err)
+	assert.Empty(t, stderr)
+	assert.Equal(t, 6, strings.Count(stdout, "\n"), "a header, four pages and a database")
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+
+	"github.com/mkfsn/notion-go"
+)
+
+func usersList(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "users list").paginated()
+
+	if _, err := flags.parse(args, 0, 0); err != nil {
+		return err
+	}
+
+	params := notion.UsersListParameters{PaginationParameters: flags.pagination()}
+
+	if flags.all {
+		users, err := e.api.Users().ListAll(ctx, params).Collect(0)
+		if err != nil {
+			return err // nolint:wrapcheck
+		}
+
+		return e.print(flags.output, usersOutput(users, users...))
+	}
+
+	resp, err := e.api.Users().List(ctx, params)
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	e.printNextCursor(resp.PaginatedList)
+
+	return e.print(flags.output, usersOutput(resp.Results, resp.Results...))
+}
+
+func usersGet(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "users get")
+
+	args, err := flags.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.api.Users().Retrieve(ctx, notion.UsersRetrieveParameters{UserID: args[0]})
+	if err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	return e.print(flags.output, usersOutput(resp.User, resp.User))
+}
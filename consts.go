@@ -12,9 +12,53 @@ const (
 	BlockTypeToDo             BlockType = "to_do"
 	BlockTypeToggle           BlockType = "toggle"
 	BlockTypeChildPage        BlockType = "child_page"
+	BlockTypeChildDatabase    BlockType = "child_database"
+	BlockTypeCallout          BlockType = "callout"
+	BlockTypeQuote            BlockType = "quote"
+	BlockTypeCode             BlockType = "code"
+	BlockTypeImage            BlockType = "image"
+	BlockTypeVideo            BlockType = "video"
+	BlockTypeFile             BlockType = "file"
+	BlockTypePDF              BlockType = "pdf"
+	BlockTypeBookmark         BlockType = "bookmark"
+	BlockTypeEmbed            BlockType = "embed"
+	BlockTypeEquation         BlockType = "equation"
+	BlockTypeDivider          BlockType = "divider"
+	BlockTypeTableOfContents  BlockType = "table_of_contents"
+	BlockTypeBreadcrumb       BlockType = "breadcrumb"
+	BlockTypeColumnList       BlockType = "column_list"
+	BlockTypeColumn           BlockType = "column"
+	BlockTypeLinkPreview      BlockType = "link_preview"
+	BlockTypeSyncedBlock      BlockType = "synced_block"
+	BlockTypeTemplate         BlockType = "template"
+	BlockTypeTable            BlockType = "table"
+	BlockTypeTableRow         BlockType = "table_row"
+	BlockTypeLinkToPage       BlockType = "link_to_page"
 	BlockTypeUnsupported      BlockType = "unsupported"
 )
 
+type FileType string
+
+const (
+	FileTypeExternal FileType = "external"
+	FileTypeFile     FileType = "file"
+)
+
+type IconType string
+
+const (
+	IconTypeEmoji    IconType = "emoji"
+	IconTypeExternal IconType = "external"
+	IconTypeFile     IconType = "file"
+)
+
+type LinkToPageType string
+
+const (
+	LinkToPageTypePage     LinkToPageType = "page_id"
+	LinkToPageTypeDatabase LinkToPageType = "database_id"
+)
+
 type Color string
 
 const (
@@ -64,6 +108,16 @@ const (
 	FormulaValueTypeDate    FormulaValueType = "date"
 )
 
+type RollupValueKind string
+
+const (
+	RollupValueKindNumber      RollupValueKind = "number"
+	RollupValueKindDate        RollupValueKind = "date"
+	RollupValueKindArray       RollupValueKind = "array"
+	RollupValueKindUnsupported RollupValueKind = "unsupported"
+	RollupValueKindIncomplete  RollupValueKind = "incomplete"
+)
+
 type RollupFunction string
 
 const (
@@ -80,6 +134,10 @@ const (
 	RollupFunctionMin               RollupFunction = "min"
 	RollupFunctionMax               RollupFunction = "max"
 	RollupFunctionRange             RollupFunction = "range"
+	RollupFunctionEarliestDate      RollupFunction = "earliest_date"
+	RollupFunctionLatestDate        RollupFunction = "latest_date"
+	RollupFunctionDateRange         RollupFunction = "date_range"
+	RollupFunctionShowOriginal      RollupFunction = "show_original"
 )
 
 type ObjectType string
@@ -96,7 +154,7 @@ type ParentType string
 
 const (
 	ParentTypeDatabase  ParentType = "database_id"
-	ParentTypePage      ParentType = "page"
+	ParentTypePage      ParentType = "page_id"
 	ParentTypeWorkspace ParentType = "workspace"
 )
 
@@ -110,7 +168,7 @@ const (
 	PropertyTypeMultiSelect    PropertyType = "multi_select"
 	PropertyTypeDate           PropertyType = "date"
 	PropertyTypePeople         PropertyType = "people"
-	PropertyTypeFile           PropertyType = "file"
+	PropertyTypeFile           PropertyType = "files"
 	PropertyTypeCheckbox       PropertyType = "checkbox"
 	PropertyTypeURL            PropertyType = "url"
 	PropertyTypeEmail          PropertyType = "email"
@@ -156,6 +214,24 @@ const (
 	RichTextTypeEquation RichTextType = "equation"
 )
 
+type MentionType string
+
+const (
+	MentionTypeUser        MentionType = "user"
+	MentionTypePage        MentionType = "page"
+	MentionTypeDatabase    MentionType = "database"
+	MentionTypeDate        MentionType = "date"
+	MentionTypeLinkPreview MentionType = "link_preview"
+	MentionTypeTemplate    MentionType = "template_mention"
+)
+
+type TemplateMentionType string
+
+const (
+	TemplateMentionTypeDate TemplateMentionType = "template_mention_date"
+	TemplateMentionTypeUser TemplateMentionType = "template_mention_user"
+)
+
 type SearchFilterValue string
 
 const (
@@ -173,7 +249,7 @@ type SearchSortDirection string
 
 const (
 	SearchSortDirectionAscending  SearchSortDirection = "ascending"
-	SearchSortDirectionDescending SearchSortDirection = " descending"
+	SearchSortDirectionDescending SearchSortDirection = "descending"
 )
 
 type SearchSortTimestamp string
@@ -3,7 +3,9 @@ package notion
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -106,7 +108,7 @@ type Mention interface {
 }
 
 type baseMention struct {
-	Type string `json:"type"`
+	Type MentionType `json:"type"`
 }
 
 func (b baseMention) isMention() {}
@@ -116,6 +118,25 @@ type UserMention struct {
 	User User `json:"user"`
 }
 
+func (u *UserMention) UnmarshalJSON(data []byte) error {
+	type Alias UserMention
+
+	alias := struct {
+		*Alias
+		User userDecoder `json:"user"`
+	}{
+		Alias: (*Alias)(u),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal UserMention: %w", err)
+	}
+
+	u.User = alias.User.User
+
+	return nil
+}
+
 type PageMention struct {
 	baseMention
 	Page struct {
@@ -132,7 +153,28 @@ type DatabaseMention struct {
 
 type DateMention struct {
 	baseMention
-	Date DatePropertyValue `json:"date"`
+	Date Date `json:"date"`
+}
+
+type LinkPreviewMention struct {
+	baseMention
+	LinkPreview struct {
+		URL string `json:"url"`
+	} `json:"link_preview"`
+}
+
+type TemplateMentionValue struct {
+	Type TemplateMentionType `json:"type"`
+	// "today" or "now", for TemplateMentionTypeDate.
+	TemplateMentionDate string `json:"template_mention_date,omitempty"`
+	// "me", for TemplateMentionTypeUser.
+	TemplateMentionUser string `json:"template_mention_user,omitempty"`
+}
+
+// TemplateMention is a mention in a template, replaced by the date or the user when a page is created from it.
+type TemplateMention struct {
+	baseMention
+	TemplateMention TemplateMentionValue `json:"template_mention"`
 }
 
 type RichTextMention struct {
@@ -140,6 +182,25 @@ type RichTextMention struct {
 	Mention Mention `json:"mention"`
 }
 
+func (r *RichTextMention) UnmarshalJSON(data []byte) error {
+	type Alias RichTextMention
+
+	alias := struct {
+		*Alias
+		Mention mentionDecoder `json:"mention"`
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal RichTextMention: %w", err)
+	}
+
+	r.Mention = alias.Mention.Mention
+
+	return nil
+}
+
 type EquationObject struct {
 	Expression string `json:"expression"`
 }
@@ -149,8 +210,54 @@ type RichTextEquation struct {
 	Equation EquationObject `json:"equation"`
 }
 
+// PlainText concatenates the plain text of rich texts. The content of a rich text is used when its plain text
+// is not set, e.g. for rich texts built locally.
+func PlainText(richTexts []RichText) string {
+	var b strings.Builder
+
+	for _, richText := range richTexts {
+		b.WriteString(plainText(richText))
+	}
+
+	return b.String()
+}
+
+func plainText(richText RichText) string {
+	switch r := richText.(type) {
+	case *RichTextText:
+		return plainText(*r)
+
+	case *RichTextEquation:
+		return plainText(*r)
+
+	case *RichTextMention:
+		return r.PlainText
+
+	case RichTextText:
+		if r.PlainText != "" {
+			return r.PlainText
+		}
+
+		return r.Text.Content
+
+	case RichTextEquation:
+		if r.PlainText != "" {
+			return r.PlainText
+		}
+
+		return r.Equation.Expression
+
+	case RichTextMention:
+		return r.PlainText
+	}
+
+	return ""
+}
+
 type Property interface {
 	isProperty()
+	propertyID() string
+	propertyType() PropertyType
 }
 
 type baseProperty struct {
@@ -160,10 +267,38 @@ type baseProperty struct {
 	ID string `json:"id"`
 	// Type that controls the behavior of the property
 	Type PropertyType `json:"type"`
+	// (Optional) New name of the property, to rename it with Databases().Update.
+	Name string `json:"name,omitempty"`
 }
 
 func (p baseProperty) isProperty() {}
 
+// PropertyTypeOf returns the type of a property, or an empty type for a nil property.
+func PropertyTypeOf(property Property) PropertyType {
+	if property == nil {
+		return ""
+	}
+
+	return property.propertyType()
+}
+
+// PropertyIDOf returns the ID of a property, or an empty ID for a nil property.
+func PropertyIDOf(property Property) string {
+	if property == nil {
+		return ""
+	}
+
+	return property.propertyID()
+}
+
+func (p baseProperty) propertyID() string {
+	return p.ID
+}
+
+func (p baseProperty) propertyType() PropertyType {
+	return p.Type
+}
+
 type TitleProperty struct {
 	baseProperty
 	Title interface{} `json:"title"`
@@ -175,7 +310,7 @@ type RichTextProperty struct {
 }
 
 type NumberPropertyOption struct {
-	Format NumberFormat `json:"format"`
+	Format NumberFormat `json:"format,omitempty"`
 }
 
 type NumberProperty struct {
@@ -184,19 +319,19 @@ type NumberProperty struct {
 }
 
 type SelectOption struct {
-	ID    string `json:"id"`
+	ID    string `json:"id,omitempty"`
 	Name  string `json:"name"`
-	Color Color  `json:"color"`
+	Color Color  `json:"color,omitempty"`
 }
 
 type MultiSelectOption struct {
-	ID    string `json:"id"`
+	ID    string `json:"id,omitempty"`
 	Name  string `json:"name"`
-	Color Color  `json:"color"`
+	Color Color  `json:"color,omitempty"`
 }
 
 type SelectPropertyOption struct {
-	Options []SelectOption `json:"options"`
+	Options []SelectOption `json:"options,omitempty"`
 }
 
 type SelectProperty struct {
@@ -205,7 +340,7 @@ type SelectProperty struct {
 }
 
 type MultiSelectPropertyOption struct {
-	Options []MultiSelectOption `json:"options"`
+	Options []MultiSelectOption `json:"options,omitempty"`
 }
 
 type MultiSelectProperty struct {
@@ -225,7 +360,7 @@ type PeopleProperty struct {
 
 type FileProperty struct {
 	baseProperty
-	File interface{} `json:"file"`
+	File interface{} `json:"files"`
 }
 
 type CheckboxProperty struct {
@@ -259,8 +394,8 @@ type FormulaProperty struct {
 
 type Relation struct {
 	DatabaseID         string  `json:"database_id"`
-	SyncedPropertyName *string `json:"synced_property_name"`
-	SyncedPropertyID   *string `json:"synced_property_id"`
+	SyncedPropertyName *string `json:"synced_property_name,omitempty"`
+	SyncedPropertyID   *string `json:"synced_property_id,omitempty"`
 }
 
 type RelationProperty struct {
@@ -269,10 +404,10 @@ type RelationProperty struct {
 }
 
 type RollupPropertyOption struct {
-	RelationPropertyName string         `json:"relation_property_name"`
-	RelationPropertyID   string         `json:"relation_property_id"`
-	RollupPropertyName   string         `json:"rollup_property_name"`
-	RollupPropertyID     string         `json:"rollup_property_id"`
+	RelationPropertyName string         `json:"relation_property_name,omitempty"`
+	RelationPropertyID   string         `json:"relation_property_id,omitempty"`
+	RollupPropertyName   string         `json:"rollup_property_name,omitempty"`
+	RollupPropertyID     string         `json:"rollup_property_id,omitempty"`
 	Function             RollupFunction `json:"function"`
 }
 
@@ -301,6 +436,131 @@ type LastEditedByProperty struct {
 	LastEditedBy interface{} `json:"last_edited_by"`
 }
 
+// RenamedProperty renames a property with Databases().Update, keeping its type and configuration.
+type RenamedProperty struct {
+	baseProperty
+}
+
+// RenameProperty returns a property renaming a property of a database to the given name, e.g.
+// DatabasesUpdateParameters{Properties: map[string]Property{"Status": RenameProperty("State")}}.
+func RenameProperty(name string) RenamedProperty {
+	return RenamedProperty{baseProperty: baseProperty{Name: name}}
+}
+
+func (p RenamedProperty) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct { // nolint: wrapcheck
+		Name string `json:"name"`
+	}{Name: p.Name})
+}
+
+// The properties are encoded with their type and configuration as Databases().Create and Databases().Update
+// expect them, see marshalProperty.
+
+func (p TitleProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeTitle, p.Title)
+}
+
+func (p RichTextProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeRichText, p.RichText)
+}
+
+func (p NumberProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeNumber, p.Number)
+}
+
+func (p SelectProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeSelect, p.Select)
+}
+
+func (p MultiSelectProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeMultiSelect, p.MultiSelect)
+}
+
+func (p DateProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeDate, p.Date)
+}
+
+func (p PeopleProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypePeople, p.People)
+}
+
+func (p FileProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeFile, p.File)
+}
+
+func (p CheckboxProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeCheckbox, p.Checkbox)
+}
+
+func (p URLProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeURL, p.URL)
+}
+
+func (p EmailProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeEmail, p.Email)
+}
+
+func (p PhoneNumberProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypePhoneNumber, p.PhoneNumber)
+}
+
+func (p FormulaProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeFormula, p.Formula)
+}
+
+func (p RelationProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeRelation, p.Relation)
+}
+
+func (p RollupProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeRollup, p.Rollup)
+}
+
+func (p CreatedTimeProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeCreatedTime, p.CreatedTime)
+}
+
+func (p CreatedByProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeCreatedBy, p.CreatedBy)
+}
+
+func (p LastEditedTimeProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeLastEditedTime, p.LastEditedTime)
+}
+
+func (p LastEditedByProperty) MarshalJSON() ([]byte, error) {
+	return marshalProperty(p.baseProperty, PropertyTypeLastEditedBy, p.LastEditedBy)
+}
+
+// marshalProperty encodes a property with its type, set from its Go type as it is not set on properties built
+// locally, its ID and new name when they are set, and its configuration, an empty object when it is nil.
+func marshalProperty(base baseProperty, propertyType PropertyType, configuration interface{}) ([]byte, error) {
+	if rv := reflect.ValueOf(configuration); !rv.IsValid() ||
+		(rv.Kind() == reflect.Map || rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		configuration = struct{}{}
+	}
+
+	property := map[string]interface{}{
+		"type":               propertyType,
+		string(propertyType): configuration,
+	}
+
+	if base.ID != "" {
+		property["id"] = base.ID
+	}
+
+	if base.Name != "" {
+		property["name"] = base.Name
+	}
+
+	data, err := json.Marshal(property)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s property: %w", propertyType, err)
+	}
+
+	return data, nil
+}
+
 type DatabasesRetrieveParameters struct {
 	DatabaseID string `json:"-" url:"-"`
 }
@@ -309,6 +569,34 @@ type DatabasesRetrieveResponse struct {
 	Database
 }
 
+type DatabasesCreateParameters struct {
+	// The page in which the database is created.
+	Parent PageParentInput `json:"parent" url:"-"`
+	// Title of the database as it appears in Notion.
+	Title []RichText `json:"title" url:"-"`
+	// Property schema of the database. The keys are the names of the properties, and the values are properties
+	// such as TitleProperty, a database must have exactly one, or SelectProperty with its options.
+	Properties map[string]Property `json:"properties" url:"-"`
+}
+
+type DatabasesCreateResponse struct {
+	Database
+}
+
+type DatabasesUpdateParameters struct {
+	DatabaseID string `json:"-" url:"-"`
+	// (Optional) New title of the database.
+	Title []RichText `json:"title,omitempty" url:"-"`
+	// (Optional) Properties to change. The keys are the names or IDs of the properties, and the values are
+	// the new properties, e.g. a SelectProperty with new options, a new property, RenameProperty to rename
+	// a property, or nil to remove a property.
+	Properties map[string]Property `json:"properties,omitempty" url:"-"`
+}
+
+type DatabasesUpdateResponse struct {
+	Database
+}
+
 type DatabasesListParameters struct {
 	PaginationParameters
 }
@@ -425,6 +713,43 @@ type DateFilter struct {
 	NextYear   map[string]interface{} `json:"next_year,omitempty"`
 }
 
+// MarshalJSON encodes the relative dates which are set to an empty map, e.g. PastWeek, as empty objects
+// rather than leaving them out.
+func (d DateFilter) MarshalJSON() ([]byte, error) {
+	type Alias DateFilter
+
+	relative := func(m map[string]interface{}) interface{} {
+		if m == nil {
+			return nil
+		}
+
+		return m
+	}
+
+	data, err := json.Marshal(struct {
+		Alias
+		PastWeek  interface{} `json:"past_week,omitempty"`
+		PastMonth interface{} `json:"past_month,omitempty"`
+		PastYear  interface{} `json:"past_year,omitempty"`
+		NextWeek  interface{} `json:"next_week,omitempty"`
+		NextMonth interface{} `json:"next_month,omitempty"`
+		NextYear  interface{} `json:"next_year,omitempty"`
+	}{
+		Alias:     Alias(d),
+		PastWeek:  relative(d.PastWeek),
+		PastMonth: relative(d.PastMonth),
+		PastYear:  relative(d.PastYear),
+		NextWeek:  relative(d.NextWeek),
+		NextMonth: relative(d.NextMonth),
+		NextYear:  relative(d.NextYear),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal DateFilter: %w", err)
+	}
+
+	return data, nil
+}
+
 // SingleDateFilter is a date filter condition applies to database properties of types "date", "created_time", and "last_edited_time".
 type SingleDateFilter struct {
 	SinglePropertyFilter
@@ -509,14 +834,62 @@ type DatabasesQueryResponse struct {
 	Results []Page `json:"results"`
 }
 
+// DatabasesIterator iterates over every database, starting at the cursor of the parameters.
+type DatabasesIterator struct {
+	*pager
+}
+
+// Value returns the current database.
+func (d *DatabasesIterator) Value() Database {
+	return d.value.(Database)
+}
+
+// Collect returns all the remaining databases, or at most maxItems databases if maxItems is positive.
+func (d *DatabasesIterator) Collect(maxItems int) ([]Database, error) {
+	var databases []Database
+
+	for (maxItems <= 0 || len(databases) < maxItems) && d.Next() {
+		databases = append(databases, d.Value())
+	}
+
+	return databases, d.Err()
+}
+
+// PagesIterator iterates over every page of a database query, starting at the cursor of the parameters.
+type PagesIterator struct {
+	*pager
+}
+
+// Value returns the current page.
+func (p *PagesIterator) Value() Page {
+	return p.value.(Page)
+}
+
+// Collect returns all the remaining pages, or at most maxItems pages if maxItems is positive.
+func (p *PagesIterator) Collect(maxItems int) ([]Page, error) {
+	var pages []Page
+
+	for (maxItems <= 0 || len(pages) < maxItems) && p.Next() {
+		pages = append(pages, p.Value())
+	}
+
+	return pages, p.Err()
+}
+
 type DatabasesInterface interface {
+	Create(ctx context.Context, params DatabasesCreateParameters) (*DatabasesCreateResponse, error)
 	Retrieve(ctx context.Context, params DatabasesRetrieveParameters) (*DatabasesRetrieveResponse, error)
+	Update(ctx context.Context, params DatabasesUpdateParameters) (*DatabasesUpdateResponse, error)
 	List(ctx context.Context, params DatabasesListParameters) (*DatabasesListResponse, error)
 	Query(ctx context.Context, params DatabasesQueryParameters) (*DatabasesQueryResponse, error)
+	ListAll(ctx context.Context, params DatabasesListParameters) *DatabasesIterator
+	QueryAll(ctx context.Context, params DatabasesQueryParameters) *PagesIterator
 }
 
 type databasesClient struct {
 	restClient rest.Interface
+	// Databases retrieved to validate the queries, when enabled by WithQueryValidation.
+	schemas *schemaCache
 }
 
 func newDatabasesClient(restClient rest.Interface) *databasesClient {
@@ -537,6 +910,40 @@ func (d *databasesClient) Retrieve(ctx context.Context, params DatabasesRetrieve
 	return &result, err // nolint:wrapcheck
 }
 
+func (d *databasesClient) Create(ctx context.Context, params DatabasesCreateParameters) (*DatabasesCreateResponse, error) {
+	var result DatabasesCreateResponse
+
+	var failure HTTPError
+
+	err := d.restClient.New().Post().
+		Endpoint(APIDatabasesCreateEndpoint).
+		QueryStruct(params).
+		BodyJSON(params).
+		Receive(ctx, &result, &failure)
+
+	return &result, err // nolint:wrapcheck
+}
+
+func (d *databasesClient) Update(ctx context.Context, params DatabasesUpdateParameters) (*DatabasesUpdateResponse, error) {
+	var result DatabasesUpdateResponse
+
+	var failure HTTPError
+
+	err := d.restClient.New().Patch().
+		Idempotent().
+		Endpoint(strings.Replace(APIDatabasesUpdateEndpoint, "{database_id}", params.DatabaseID, 1)).
+		QueryStruct(params).
+		BodyJSON(params).
+		Receive(ctx, &result, &failure)
+
+	// The properties of the database validating the queries have changed.
+	if d.schemas != nil {
+		d.schemas.forget(params.DatabaseID)
+	}
+
+	return &result, err // nolint:wrapcheck
+}
+
 func (d *databasesClient) List(ctx context.Context, params DatabasesListParameters) (*DatabasesListResponse, error) {
 	var result DatabasesListResponse
 
@@ -551,19 +958,81 @@ func (d *databasesClient) List(ctx context.Context, params DatabasesListParamete
 }
 
 func (d *databasesClient) Query(ctx context.Context, params DatabasesQueryParameters) (*DatabasesQueryResponse, error) {
+	if d.schemas != nil {
+		if err := d.schemas.validate(ctx, params, d.Retrieve); err != nil {
+			return nil, err
+		}
+	}
+
 	var result DatabasesQueryResponse
 
 	var failure HTTPError
 
 	err := d.restClient.New().Post().
+		Idempotent().
 		Endpoint(strings.Replace(APIDatabasesQueryEndpoint, "{database_id}", params.DatabaseID, 1)).
 		QueryStruct(params).
 		BodyJSON(params).
 		Receive(ctx, &result, &failure)
 
+	// The database may have changed since it was retrieved.
+	if d.schemas != nil && errors.Is(err, ErrValidationError) {
+		d.schemas.forget(params.DatabaseID)
+	}
+
 	return &result, err // nolint:wrapcheck
 }
 
+func (d *databasesClient) ListAll(ctx context.Context, params DatabasesListParameters) *DatabasesIterator {
+	return NewDatabasesIterator(ctx, d, params)
+}
+
+// NewDatabasesIterator returns an iterator over every database listed by databases.
+func NewDatabasesIterator(ctx context.Context, databases DatabasesInterface, params DatabasesListParameters) *DatabasesIterator {
+	return &DatabasesIterator{
+		pager: newPager(ctx, params.StartCursor, func(ctx context.Context, startCursor string) ([]interface{}, PaginatedList, error) {
+			params.StartCursor = startCursor
+
+			resp, err := databases.List(ctx, params)
+			if err != nil {
+				return nil, PaginatedList{}, err
+			}
+
+			results := make([]interface{}, 0, len(resp.Results))
+			for _, database := range resp.Results {
+				results = append(results, database)
+			}
+
+			return results, resp.PaginatedList, nil
+		}),
+	}
+}
+
+func (d *databasesClient) QueryAll(ctx context.Context, params DatabasesQueryParameters) *PagesIterator {
+	return NewPagesIterator(ctx, d, params)
+}
+
+// NewPagesIterator returns an iterator over every page of a database queried with databases.
+func NewPagesIterator(ctx context.Context, databases DatabasesInterface, params DatabasesQueryParameters) *PagesIterator {
+	return &PagesIterator{
+		pager: newPager(ctx, params.StartCursor, func(ctx context.Context, startCursor string) ([]interface{}, PaginatedList, error) {
+			params.StartCursor = startCursor
+
+			resp, err := databases.Query(ctx, params)
+			if err != nil {
+				return nil, PaginatedList{}, err
+			}
+
+			results := make([]interface{}, 0, len(resp.Results))
+			for _, page := range resp.Results {
+				results = append(results, page)
+			}
+
+			return results, resp.PaginatedList, nil
+		}),
+	}
+}
+
 type richTextDecoder struct {
 	RichText
 }
@@ -591,6 +1060,52 @@ func (r *richTextDecoder) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, &r.RichText)
 }
 
+type mentionDecoder struct {
+	Mention
+}
+
+func (m *mentionDecoder) UnmarshalJSON(data []byte) error {
+	var decoder struct {
+		Type MentionType `json:"type"`
+	}
+
+	if err := json.Unmarshal(data, &decoder); err != nil {
+		return fmt.Errorf("failed to unmarshal Mention: %w", err)
+	}
+
+	switch decoder.Type {
+	case MentionTypeUser:
+		m.Mention = &UserMention{}
+
+	case MentionTypePage:
+		m.Mention = &PageMention{}
+
+	case MentionTypeDatabase:
+		m.Mention = &DatabaseMention{}
+
+	case MentionTypeDate:
+		m.Mention = &DateMention{}
+
+	case MentionTypeLinkPreview:
+		m.Mention = &LinkPreviewMention{}
+
+	case MentionTypeTemplate:
+		m.Mention = &TemplateMention{}
+	}
+
+	return json.Unmarshal(data, &m.Mention)
+}
+
+func decodeRichTexts(decoders []richTextDecoder) []RichText {
+	richTexts := make([]RichText, 0, len(decoders))
+
+	for _, decoder := range decoders {
+		richTexts = append(richTexts, decoder.RichText)
+	}
+
+	return richTexts
+}
+
 type propertyDecoder struct {
 	Property
 }
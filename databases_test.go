@@ -2,6 +2,7 @@ package notion
 
 import (
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -605,8 +606,8 @@ func Test_databasesClient_Retrieve(t *testing.T) {
 							},
 							"Photo": {
 								"id": "aTIT",
-								"type": "file",
-								"file": {}
+								"type": "files",
+								"files": {}
 							}
 						}
 					}`))
@@ -614,10 +615,6 @@ func Test_databasesClient_Retrieve(t *testing.T) {
 					// the options in the multi_select property is an array of array which does not make sense
 					// to me, thus changing it to an array but need to verify this.
 
-					// FIXME: In the API reference https://developers.notion.com/reference/get-database
-					// the Photo Property has `files` type but in the documentation,
-					// https://developers.notion.com/reference/database, the type should be `file`,
-					// Following the documentation, the JSON string above has been changed to type `file`.
 					assert.NoError(t, err)
 				}),
 			},
@@ -836,6 +833,449 @@ func Test_databasesClient_Retrieve(t *testing.T) {
 	}
 }
 
+func Test_databasesClient_Create(t *testing.T) {
+	type fields struct {
+		restClient      rest.Interface
+		mockHTTPHandler http.Handler
+		authToken       string
+	}
+
+	type args struct {
+		ctx    context.Context
+		params DatabasesCreateParameters
+	}
+
+	type wants struct {
+		response *DatabasesCreateResponse
+		err      error
+	}
+
+	type test struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}
+
+	synced := "Tasks"
+
+	tests := []test{
+		{
+			name: "Create a database with a property schema",
+			fields: fields{
+				restClient: rest.New(),
+				authToken:  "22e5435c-01f7-4d68-ad8c-203948e96b0b",
+				mockHTTPHandler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					assert.Equal(t, http.MethodPost, request.Method)
+					assert.Equal(t, "/v1/databases", request.RequestURI)
+
+					b, err := ioutil.ReadAll(request.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{
+						"parent": {"page_id": "98ad959b-2b6a-4774-80ee-00246fb0ea9b"},
+						"title": [{"type": "text", "text": {"content": "Grocery List"}}],
+						"properties": {
+							"Name": {"type": "title", "title": {}},
+							"Description": {"type": "rich_text", "rich_text": {}},
+							"In stock": {"type": "checkbox", "checkbox": {}},
+							"Price": {"type": "number", "number": {"format": "dollar"}},
+							"Food group": {"type": "select", "select": {"options": [
+								{"name": "🥦Vegetable", "color": "green"},
+								{"name": "🍎Fruit", "color": "red"}
+							]}},
+							"Stores": {"type": "multi_select", "multi_select": {"options": [{"name": "Duc Loi Market"}]}},
+							"Last ordered": {"type": "date", "date": {}},
+							"Photo": {"type": "files", "files": {}},
+							"Cost of next trip": {"type": "formula", "formula": {"expression": "prop(\"Price\") * 2"}},
+							"Projects": {"type": "relation", "relation": {
+								"database_id": "668d797c-76fa-4934-9b05-ad288df2d136",
+								"synced_property_name": "Tasks"
+							}},
+							"Budget": {"type": "rollup", "rollup": {
+								"relation_property_name": "Projects",
+								"rollup_property_name": "Budget",
+								"function": "sum"
+							}}
+						}
+					}`, string(b))
+
+					writer.WriteHeader(http.StatusOK)
+
+					_, err = writer.Write([]byte(`{
+						"object": "database",
+						"id": "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+						"created_time": "2021-07-08T23:50:00.000Z",
+						"last_edited_time": "2021-07-08T23:50:00.000Z",
+						"title": [{"type": "text", "text": {"content": "Grocery List", "link": null}, "plain_text": "Grocery List", "href": null}],
+						"properties": {
+							"Name": {"id": "title", "type": "title", "title": {}},
+							"Photo": {"id": "yfiK", "type": "files", "files": {}}
+						}
+					}`))
+					assert.NoError(t, err)
+				}),
+			},
+			args: args{
+				ctx: context.Background(),
+				params: DatabasesCreateParameters{
+					Parent: PageParentInput{PageID: "98ad959b-2b6a-4774-80ee-00246fb0ea9b"},
+					Title:  []RichText{Text("Grocery List")},
+					Properties: map[string]Property{
+						"Name":        TitleProperty{},
+						"Description": &RichTextProperty{},
+						"In stock":    CheckboxProperty{Checkbox: map[string]interface{}(nil)},
+						"Price":       NumberProperty{Number: NumberPropertyOption{Format: NumberFormatDollar}},
+						"Food group": SelectProperty{Select: SelectPropertyOption{Options: []SelectOption{
+							{Name: "🥦Vegetable", Color: ColorGreen},
+							{Name: "🍎Fruit", Color: ColorRed},
+						}}},
+						"Stores": MultiSelectProperty{MultiSelect: MultiSelectPropertyOption{Options: []MultiSelectOption{
+							{Name: "Duc Loi Market"},
+						}}},
+						"Last ordered":      DateProperty{},
+						"Photo":             FileProperty{},
+						"Cost of next trip": FormulaProperty{Formula: Formula{Expression: `prop("Price") * 2`}},
+						"Projects": RelationProperty{Relation: Relation{
+							DatabaseID:         "668d797c-76fa-4934-9b05-ad288df2d136",
+							SyncedPropertyName: &synced,
+						}},
+						"Budget": RollupProperty{Rollup: RollupPropertyOption{
+							RelationPropertyName: "Projects",
+							RollupPropertyName:   "Budget",
+							Function:             RollupFunctionSum,
+						}},
+					},
+				},
+			},
+			wants: wants{
+				response: &DatabasesCreateResponse{
+					Database: Database{
+						Object:         ObjectTypeDatabase,
+						ID:             "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+						CreatedTime:    time.Date(2021, 7, 8, 23, 50, 0, 0, time.UTC),
+						LastEditedTime: time.Date(2021, 7, 8, 23, 50, 0, 0, time.UTC),
+						Title: []RichText{
+							&RichTextText{
+								BaseRichText: BaseRichText{PlainText: "Grocery List", Type: RichTextTypeText},
+								Text:         TextObject{Content: "Grocery List"},
+							},
+						},
+						Properties: map[string]Property{
+							"Name": &TitleProperty{
+								baseProperty: baseProperty{ID: "title", Type: PropertyTypeTitle},
+								Title:        map[string]interface{}{},
+							},
+							"Photo": &FileProperty{
+								baseProperty: baseProperty{ID: "yfiK", Type: PropertyTypeFile},
+								File:         map[string]interface{}{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPServer := httptest.NewServer(tt.fields.mockHTTPHandler)
+
+			sut := New(
+				tt.fields.authToken,
+				WithBaseURL(mockHTTPServer.URL),
+			)
+
+			got, err := sut.Databases().Create(tt.args.ctx, tt.args.params)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants.response, got)
+		})
+	}
+}
+
+func Test_databasesClient_Update(t *testing.T) {
+	type fields struct {
+		restClient      rest.Interface
+		mockHTTPHandler http.Handler
+		authToken       string
+	}
+
+	type args struct {
+		ctx    context.Context
+		params DatabasesUpdateParameters
+	}
+
+	type wants struct {
+		response *DatabasesUpdateResponse
+		err      error
+	}
+
+	type test struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}
+
+	status := SelectProperty{Select: SelectPropertyOption{Options: []SelectOption{
+		{ID: "3a1c9e2f-5b7d-4e8a-9c0b-1d2e3f4a5b6c", Name: "Done", Color: ColorGreen},
+		{Name: "Blocked", Color: ColorRed},
+	}}}
+	status.Name = "State"
+
+	tests := []test{
+		{
+			name: "Rename the database, add, rename and remove properties and add an option",
+			fields: fields{
+				restClient: rest.New(),
+				authToken:  "22e5435c-01f7-4d68-ad8c-203948e96b0b",
+				mockHTTPHandler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					assert.Equal(t, http.MethodPatch, request.Method)
+					assert.Equal(t, "/v1/databases/668d797c-76fa-4934-9b05-ad288df2d136", request.RequestURI)
+
+					b, err := ioutil.ReadAll(request.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{
+						"title": [{"type": "text", "text": {"content": "Tasks"}}],
+						"properties": {
+							"Status": {"type": "select", "name": "State", "select": {"options": [
+								{"id": "3a1c9e2f-5b7d-4e8a-9c0b-1d2e3f4a5b6c", "name": "Done", "color": "green"},
+								{"name": "Blocked", "color": "red"}
+							]}},
+							"Notes": {"name": "Description"},
+							"Website": {"type": "url", "url": {}},
+							"Photo": null
+						}
+					}`, string(b))
+
+					writer.WriteHeader(http.StatusOK)
+
+					_, err = writer.Write([]byte(`{
+						"object": "database",
+						"id": "668d797c-76fa-4934-9b05-ad288df2d136",
+						"created_time": "2021-07-08T23:50:00.000Z",
+						"last_edited_time": "2021-07-09T10:00:00.000Z",
+						"title": [],
+						"properties": {
+							"Website": {"id": "bJ?h", "type": "url", "url": {}}
+						}
+					}`))
+					assert.NoError(t, err)
+				}),
+			},
+			args: args{
+				ctx: context.Background(),
+				params: DatabasesUpdateParameters{
+					DatabaseID: "668d797c-76fa-4934-9b05-ad288df2d136",
+					Title:      []RichText{Text("Tasks")},
+					Properties: map[string]Property{
+						"Status":  status,
+						"Notes":   RenameProperty("Description"),
+						"Website": URLProperty{},
+						"Photo":   nil,
+					},
+				},
+			},
+			wants: wants{
+				response: &DatabasesUpdateResponse{
+					Database: Database{
+						Object:         ObjectTypeDatabase,
+						ID:             "668d797c-76fa-4934-9b05-ad288df2d136",
+						CreatedTime:    time.Date(2021, 7, 8, 23, 50, 0, 0, time.UTC),
+						LastEditedTime: time.Date(2021, 7, 9, 10, 0, 0, 0, time.UTC),
+						Title:          []RichText{},
+						Properties: map[string]Property{
+							"Website": &URLProperty{
+								baseProperty: baseProperty{ID: "bJ?h", Type: PropertyTypeURL},
+								URL:          map[string]interface{}{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid schema",
+			fields: fields{
+				restClient: rest.New(),
+				authToken:  "22e5435c-01f7-4d68-ad8c-203948e96b0b",
+				mockHTTPHandler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					writer.WriteHeader(http.StatusBadRequest)
+
+					_, err := writer.Write([]byte(`{
+						"object": "error",
+						"status": 400,
+						"code": "validation_error",
+						"message": "Cannot update title property type."
+					}`))
+					assert.NoError(t, err)
+				}),
+			},
+			args: args{
+				ctx: context.Background(),
+				params: DatabasesUpdateParameters{
+					DatabaseID: "668d797c-76fa-4934-9b05-ad288df2d136",
+					Properties: map[string]Property{"Name": NumberProperty{}},
+				},
+			},
+			wants: wants{
+				err: ErrValidationError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPServer := httptest.NewServer(tt.fields.mockHTTPHandler)
+
+			sut := New(
+				tt.fields.authToken,
+				WithBaseURL(mockHTTPServer.URL),
+			)
+
+			got, err := sut.Databases().Update(tt.args.ctx, tt.args.params)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants.response, got)
+		})
+	}
+}
+
 func newFloat64(f float64) *float64 {
 	return &f
 }
+
+func TestPlainText(t *testing.T) {
+	richTexts := []RichText{
+		&RichTextText{
+			BaseRichText: BaseRichText{PlainText: "Lacinato ", Type: RichTextTypeText},
+			Text:         TextObject{Content: "Lacinato "},
+		},
+		RichTextText{Text: TextObject{Content: "kale "}},
+		&RichTextEquation{Equation: EquationObject{Expression: "e=mc^2"}},
+		&RichTextMention{BaseRichText: BaseRichText{PlainText: " @Avocado"}},
+	}
+
+	assert.Equal(t, "Lacinato kale e=mc^2 @Avocado", PlainText(richTexts))
+}
+
+func TestDateFilter_MarshalJSON(t *testing.T) {
+	after := "2021-05-10"
+
+	data, err := json.Marshal(SingleDateFilter{
+		SinglePropertyFilter: SinglePropertyFilter{Property: "Due"},
+		Date:                 &DateFilter{After: &after, PastWeek: map[string]interface{}{}},
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"property": "Due", "date": {"after": "2021-05-10", "past_week": {}}}`, string(data))
+}
+
+func TestPropertyTypeOf(t *testing.T) {
+	assert.Equal(t, PropertyTypeSelect, PropertyTypeOf(&SelectProperty{baseProperty: baseProperty{Type: PropertyTypeSelect}}))
+	assert.Equal(t, PropertyType(""), PropertyTypeOf(nil))
+}
+
+func TestPropertyIDOf(t *testing.T) {
+	assert.Equal(t, "a%3Ab", PropertyIDOf(&SelectProperty{baseProperty: baseProperty{ID: "a%3Ab", Type: PropertyTypeSelect}}))
+	assert.Equal(t, "", PropertyIDOf(nil))
+}
+
+func TestRichTextMention_UnmarshalJSON(t *testing.T) {
+	title, ok := readFixturePage(t).Properties["Name"].(*TitlePropertyValue)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	pageMention := &PageMention{baseMention: baseMention{Type: MentionTypePage}}
+	pageMention.Page.ID = "3c612f56-fdd0-4a30-a4d6-bda7d7426309"
+
+	databaseMention := &DatabaseMention{baseMention: baseMention{Type: MentionTypeDatabase}}
+	databaseMention.Database.ID = "a1d8501e-1ac1-43e9-a6bd-ea9fe6c8822b"
+
+	linkPreviewMention := &LinkPreviewMention{baseMention: baseMention{Type: MentionTypeLinkPreview}}
+	linkPreviewMention.LinkPreview.URL = "https://github.com/mkfsn/notion-go/issues/1"
+
+	end := "2021-05-24T15:00:00.000+02:00"
+
+	want := []Mention{
+		&UserMention{
+			baseMention: baseMention{Type: MentionTypeUser},
+			User: &PersonUser{
+				baseUser: baseUser{
+					Object: ObjectTypeUser,
+					ID:     "6794760a-1f15-45cd-9c65-0dfe42f5135a",
+					Type:   UserTypePerson,
+					Name:   "Aman Gupta",
+				},
+				Person: Person{Email: "aman@example.com"},
+			},
+		},
+		pageMention,
+		databaseMention,
+		&DateMention{
+			baseMention: baseMention{Type: MentionTypeDate},
+			Date:        Date{Start: "2021-05-24T14:00:00.000+02:00", End: &end},
+		},
+		linkPreviewMention,
+		&TemplateMention{
+			baseMention: baseMention{Type: MentionTypeTemplate},
+			TemplateMention: TemplateMentionValue{
+				Type:                TemplateMentionTypeDate,
+				TemplateMentionDate: "today",
+			},
+		},
+		&TemplateMention{
+			baseMention: baseMention{Type: MentionTypeTemplate},
+			TemplateMention: TemplateMentionValue{
+				Type:                TemplateMentionTypeUser,
+				TemplateMentionUser: "me",
+			},
+		},
+	}
+
+	if !assert.Len(t, title.Title, len(want)) {
+		return
+	}
+
+	for i, richText := range title.Title {
+		mention, ok := richText.(*RichTextMention)
+		if assert.True(t, ok, "rich text %d is a %T", i, richText) {
+			assert.Equal(t, RichTextTypeMention, mention.Type)
+			assert.Equal(t, want[i], mention.Mention, "mention %d", i)
+		}
+	}
+
+	assert.Equal(t, "https://www.notion.so/3c612f56fdd04a30a4d6bda7d7426309", title.Title[1].(*RichTextMention).Href)
+	assert.True(t, title.Title[1].(*RichTextMention).Annotations.Bold)
+	assert.Equal(t, "@Aman Gupta", PlainText(title.Title[:1]))
+}
+
+func TestRichTextMention_RoundTrip(t *testing.T) {
+	end := "2021-05-12"
+
+	for _, richText := range []RichTextMention{
+		MentionUser("6794760a-1f15-45cd-9c65-0dfe42f5135a"),
+		MentionPage("3c612f56-fdd0-4a30-a4d6-bda7d7426309"),
+		MentionDatabase("a1d8501e-1ac1-43e9-a6bd-ea9fe6c8822b"),
+		MentionDate("2021-05-11", &end),
+	} {
+		data, err := json.Marshal(richText)
+		assert.NoError(t, err)
+
+		var decoded RichTextMention
+
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+
+		encoded, err := json.Marshal(decoded)
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(data), string(encoded))
+	}
+}
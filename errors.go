@@ -3,12 +3,31 @@ package notion
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mkfsn/notion-go/rest"
 )
 
 var (
 	ErrUnknown = errors.New("unknown")
 )
 
+// Sentinel errors matching an HTTPError with the corresponding ErrorCode, e.g. errors.Is(err, ErrObjectNotFound).
+var (
+	ErrInvalidJSON         = errors.New("invalid json")
+	ErrInvalidRequestURI   = errors.New("invalid request url")
+	ErrInvalidRequest      = errors.New("invalid request")
+	ErrValidationError     = errors.New("validation error")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrRestrictedResource  = errors.New("restricted resource")
+	ErrObjectNotFound      = errors.New("object not found")
+	ErrConflictError       = errors.New("conflict error")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrInternalServerError = errors.New("internal server error")
+	ErrServiceUnavailable  = errors.New("service unavailable")
+)
+
 // ErrorCode https://developers.notion.com/reference/errors
 type ErrorCode string
 
@@ -26,11 +45,105 @@ const (
 	ErrorCodeServiceUnavailable  ErrorCode = "service_unavailable"
 )
 
+var errorCodeSentinels = map[ErrorCode]error{
+	ErrorCodeInvalidJSON:         ErrInvalidJSON,
+	ErrorCodeInvalidRequestURI:   ErrInvalidRequestURI,
+	ErrorCodeInvalidRequest:      ErrInvalidRequest,
+	ErrorCodeValidationError:     ErrValidationError,
+	ErrorCodeUnauthorized:        ErrUnauthorized,
+	ErrorCodeRestrictedResource:  ErrRestrictedResource,
+	ErrorCodeObjectNotFound:      ErrObjectNotFound,
+	ErrorCodeConflictError:       ErrConflictError,
+	ErrorCodeRateLimited:         ErrRateLimited,
+	ErrorCodeInternalServerError: ErrInternalServerError,
+	ErrorCodeServiceUnavailable:  ErrServiceUnavailable,
+}
+
+// statusCodeErrorCodes is used to guess the ErrorCode of a response without a Notion error body,
+// e.g. an error page returned by a proxy.
+var statusCodeErrorCodes = map[int]ErrorCode{
+	http.StatusUnauthorized:        ErrorCodeUnauthorized,
+	http.StatusForbidden:           ErrorCodeRestrictedResource,
+	http.StatusNotFound:            ErrorCodeObjectNotFound,
+	http.StatusConflict:            ErrorCodeConflictError,
+	http.StatusTooManyRequests:     ErrorCodeRateLimited,
+	http.StatusInternalServerError: ErrorCodeInternalServerError,
+	http.StatusBadGateway:          ErrorCodeServiceUnavailable,
+	http.StatusServiceUnavailable:  ErrorCodeServiceUnavailable,
+	http.StatusGatewayTimeout:      ErrorCodeServiceUnavailable,
+}
+
+const (
+	maxErrorBodyLength = 256
+)
+
 type HTTPError struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
+	// The identifier Notion assigned to the request, useful when contacting their support.
+	RequestID string `json:"request_id"`
+	// HTTP status code of the response.
+	StatusCode int `json:"-"`
+	// The delay requested by the Retry-After header, zero if absent.
+	RetryAfter time.Duration `json:"-"`
+	// Raw body of the response, which may not be JSON.
+	Body []byte `json:"-"`
 }
 
 func (e HTTPError) Error() string {
+	if e.Code == "" && e.StatusCode != 0 {
+		body := string(e.Body)
+		if len(body) > maxErrorBodyLength {
+			body = body[:maxErrorBodyLength] + "..."
+		}
+
+		return fmt.Sprintf("Status: %d, Body: %s", e.StatusCode, body)
+	}
+
 	return fmt.Sprintf("Code: %s, Message: %s", e.Code, e.Message)
 }
+
+// Is reports whether the target is the sentinel error of the error code.
+// The error code is guessed from the HTTP status code when the response had no Notion error body.
+func (e HTTPError) Is(target error) bool {
+	code := e.Code
+	if code == "" {
+		code = statusCodeErrorCodes[e.StatusCode]
+	}
+
+	sentinel, ok := errorCodeSentinels[code]
+
+	return ok && sentinel == target
+}
+
+// RecordResponse implements rest.ResponseRecorder
+func (e *HTTPError) RecordResponse(resp *http.Response, body []byte) {
+	e.StatusCode = resp.StatusCode
+	e.Body = body
+
+	if e.RequestID == "" {
+		e.RequestID = resp.Header.Get("X-Notion-Request-Id")
+	}
+
+	if d, ok := rest.RetryAfter(resp.Header); ok {
+		e.RetryAfter = d
+	}
+}
+
+// IsRetryable reports whether the request which failed with the error may succeed if sent again later.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) ||
+		errors.Is(err, ErrInternalServerError) ||
+		errors.Is(err, ErrServiceUnavailable) ||
+		errors.Is(err, rest.ErrRateLimitExceeded)
+}
+
+// IsRateLimited reports whether the request was rejected by Notion or by the client side rate limiter.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, rest.ErrRateLimitExceeded)
+}
+
+// IsNotFound reports whether the requested object does not exist or is not shared with the integration.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrObjectNotFound)
+}
@@ -1,8 +1,15 @@
 package notion
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/mkfsn/notion-go/rest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,3 +41,133 @@ func TestHTTPError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "Match the sentinel error of the code",
+			err:    &HTTPError{Code: ErrorCodeObjectNotFound, StatusCode: http.StatusNotFound},
+			target: ErrObjectNotFound,
+			want:   true,
+		},
+		{
+			name:   "Match a wrapped error",
+			err:    fmt.Errorf("failed to retrieve page: %w", &HTTPError{Code: ErrorCodeConflictError}),
+			target: ErrConflictError,
+			want:   true,
+		},
+		{
+			name:   "Do not match the sentinel error of another code",
+			err:    &HTTPError{Code: ErrorCodeObjectNotFound},
+			target: ErrUnauthorized,
+			want:   false,
+		},
+		{
+			name:   "Guess the code from the status code",
+			err:    &HTTPError{StatusCode: http.StatusBadGateway},
+			target: ErrServiceUnavailable,
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "rate_limited", err: &HTTPError{Code: ErrorCodeRateLimited}, want: true},
+		{name: "internal_server_error", err: &HTTPError{Code: ErrorCodeInternalServerError}, want: true},
+		{name: "service_unavailable", err: &HTTPError{Code: ErrorCodeServiceUnavailable}, want: true},
+		{name: "gateway timeout", err: &HTTPError{StatusCode: http.StatusGatewayTimeout}, want: true},
+		{name: "client side rate limit", err: fmt.Errorf("wait: %w", rest.ErrRateLimitExceeded), want: true},
+		{name: "validation_error", err: &HTTPError{Code: ErrorCodeValidationError}, want: false},
+		{name: "unknown", err: ErrUnknown, want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestHTTPError_RecordResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      http.Header
+		statusCode  int
+		body        string
+		wantError   *HTTPError
+		wantMessage string
+	}{
+		{
+			name: "Notion error with a request id and a Retry-After header",
+			header: http.Header{
+				"X-Notion-Request-Id": []string{"e2ee1d4a-6e4b-4b89-8f1b-cf4c22d9e4c7"},
+				"Retry-After":         []string{"7"},
+			},
+			statusCode: http.StatusTooManyRequests,
+			body:       `{"object": "error", "status": 429, "code": "rate_limited", "message": "You have been rate limited."}`,
+			wantError: &HTTPError{
+				Code:       ErrorCodeRateLimited,
+				Message:    "You have been rate limited.",
+				RequestID:  "e2ee1d4a-6e4b-4b89-8f1b-cf4c22d9e4c7",
+				StatusCode: http.StatusTooManyRequests,
+				RetryAfter: 7 * time.Second,
+				Body:       []byte(`{"object": "error", "status": 429, "code": "rate_limited", "message": "You have been rate limited."}`),
+			},
+			wantMessage: "Code: rate_limited, Message: You have been rate limited.",
+		},
+		{
+			name:       "HTML error page of a proxy",
+			header:     http.Header{},
+			statusCode: http.StatusBadGateway,
+			body:       `<html><body>502 Bad Gateway</body></html>`,
+			wantError: &HTTPError{
+				StatusCode: http.StatusBadGateway,
+				Body:       []byte(`<html><body>502 Bad Gateway</body></html>`),
+			},
+			wantMessage: "Status: 502, Body: <html><body>502 Bad Gateway</body></html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				for key, values := range tt.header {
+					writer.Header()[key] = values
+				}
+
+				writer.WriteHeader(tt.statusCode)
+
+				_, err := writer.Write([]byte(tt.body))
+				assert.NoError(t, err)
+			}))
+			defer mockHTTPServer.Close()
+
+			sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+			_, err := sut.Pages().Retrieve(context.Background(), PagesRetrieveParameters{PageID: "page"})
+
+			var httpError *HTTPError
+
+			assert.True(t, errors.As(err, &httpError))
+			assert.Equal(t, tt.wantError, httpError)
+			assert.EqualError(t, err, tt.wantMessage)
+		})
+	}
+}
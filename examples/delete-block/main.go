@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/mkfsn/notion-go"
+)
+
+func main() {
+	c := notion.New(os.Getenv("NOTION_AUTH_TOKEN"))
+
+	resp, err := c.Blocks().Delete(context.Background(), notion.BlocksDeleteParameters{
+		BlockID: "0c940186ab704351bb342d16f0635d49"},
+	)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("block: %#v\n", resp.Block)
+}
@@ -6,27 +6,17 @@ import (
 	"os"
 
 	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/filter"
 )
 
 func main() {
 	c := notion.New(os.Getenv("NOTION_AUTH_TOKEN"))
 
-	keyword := "medium.com"
-
 	resp, err := c.Databases().Query(context.Background(), notion.DatabasesQueryParameters{
 		DatabaseID: "def72422-ea36-4c8a-a6f1-a34e11a7fe54",
-		Filter: notion.CompoundFilter{
-			Or: []notion.Filter{
-				notion.SingleTextFilter{
-					SinglePropertyFilter: notion.SinglePropertyFilter{
-						Property: "URL",
-					},
-					URL: &notion.TextFilter{
-						Contains: &keyword,
-					},
-				},
-			},
-		},
+		Filter: filter.Prop("URL").URL().Contains("medium.com").
+			Or(filter.Prop("Done").Checkbox().Equals(true)).
+			Build(),
 		Sorts: []notion.Sort{
 			{
 				Property:  "Created",
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/mkfsn/notion-go"
+)
+
+func main() {
+	c := notion.New(os.Getenv("NOTION_AUTH_TOKEN"))
+
+	resp, err := c.Blocks().Update(context.Background(), notion.BlocksUpdateParameters{
+		BlockID: "0c940186ab704351bb342d16f0635d49",
+		Block: notion.ToDoBlock{
+			BlockBase: notion.BlockBase{
+				Object: notion.ObjectTypeBlock,
+				Type:   notion.BlockTypeToDo,
+			},
+			ToDo: notion.RichTextWithCheckBlock{
+				Text: []notion.RichText{
+					notion.RichTextText{
+						BaseRichText: notion.BaseRichText{
+							Type: notion.RichTextTypeText,
+						},
+						Text: notion.TextObject{
+							Content: "Buy Lacinato kale",
+						},
+					},
+				},
+				Checked: true,
+			},
+		},
+	})
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("block: %#v\n", resp.Block)
+}
@@ -0,0 +1,329 @@
+package filter
+
+import (
+	"time"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// Text holds the conditions on title, rich text, URL, email and phone number properties, and on formulas
+// returning text.
+type Text struct {
+	build func(notion.TextFilter) notion.Filter
+}
+
+// Equals matches the texts equal to the value.
+func (t Text) Equals(value string) Condition {
+	return Condition{filter: t.build(notion.TextFilter{Equals: &value})}
+}
+
+// DoesNotEqual matches the texts which differ from the value.
+func (t Text) DoesNotEqual(value string) Condition {
+	return Condition{filter: t.build(notion.TextFilter{DoesNotEqual: &value})}
+}
+
+// Contains matches the texts containing the value.
+func (t Text) Contains(value string) Condition {
+	return Condition{filter: t.build(notion.TextFilter{Contains: &value})}
+}
+
+// DoesNotContain matches the texts which do not contain the value.
+func (t Text) DoesNotContain(value string) Condition {
+	return Condition{filter: t.build(notion.TextFilter{DoesNotContain: &value})}
+}
+
+// StartsWith matches the texts starting with the value.
+func (t Text) StartsWith(value string) Condition {
+	return Condition{filter: t.build(notion.TextFilter{StartsWith: &value})}
+}
+
+// EndsWith matches the texts ending with the value.
+func (t Text) EndsWith(value string) Condition {
+	return Condition{filter: t.build(notion.TextFilter{EndsWith: &value})}
+}
+
+// IsEmpty matches the empty texts.
+func (t Text) IsEmpty() Condition {
+	return Condition{filter: t.build(notion.TextFilter{IsEmpty: newTrue()})}
+}
+
+// IsNotEmpty matches the texts which are not empty.
+func (t Text) IsNotEmpty() Condition {
+	return Condition{filter: t.build(notion.TextFilter{IsNotEmpty: newTrue()})}
+}
+
+// Number holds the conditions on number properties and on formulas returning numbers.
+type Number struct {
+	build func(notion.NumberFilter) notion.Filter
+}
+
+// Equals matches the numbers equal to the value.
+func (n Number) Equals(value float64) Condition {
+	return Condition{filter: n.build(notion.NumberFilter{Equals: &value})}
+}
+
+// DoesNotEqual matches the numbers which differ from the value.
+func (n Number) DoesNotEqual(value float64) Condition {
+	return Condition{filter: n.build(notion.NumberFilter{DoesNotEqual: &value})}
+}
+
+// GreaterThan matches the numbers greater than the value.
+func (n Number) GreaterThan(value float64) Condition {
+	return Condition{filter: n.build(notion.NumberFilter{GreaterThan: &value})}
+}
+
+// LessThan matches the numbers less than the value.
+func (n Number) LessThan(value float64) Condition {
+	return Condition{filter: n.build(notion.NumberFilter{LessThan: &value})}
+}
+
+// GreaterThanOrEqualTo matches the numbers greater than or equal to the value.
+func (n Number) GreaterThanOrEqualTo(value float64) Condition {
+	return Condition{filter: n.build(notion.NumberFilter{GreaterThanOrEqualTo: &value})}
+}
+
+// LessThanOrEqualTo matches the numbers less than or equal to the value.
+func (n Number) LessThanOrEqualTo(value float64) Condition {
+	return Condition{filter: n.build(notion.NumberFilter{LessThanOrEqualTo: &value})}
+}
+
+// IsEmpty matches the pages without number.
+func (n Number) IsEmpty() Condition {
+	return Condition{filter: n.build(notion.NumberFilter{IsEmpty: true})}
+}
+
+// IsNotEmpty matches the pages with a number.
+func (n Number) IsNotEmpty() Condition {
+	return Condition{filter: n.build(notion.NumberFilter{IsNotEmpty: true})}
+}
+
+// Checkbox holds the conditions on checkbox properties and on formulas returning booleans.
+type Checkbox struct {
+	build func(notion.CheckboxFilter) notion.Filter
+}
+
+// Equals matches the checkboxes in the given state. As false values are left out of notion.CheckboxFilter,
+// Equals(false) is built as DoesNotEqual(true).
+func (c Checkbox) Equals(value bool) Condition {
+	if !value {
+		return Condition{filter: c.build(notion.CheckboxFilter{DoesNotEqual: true})}
+	}
+
+	return Condition{filter: c.build(notion.CheckboxFilter{Equals: true})}
+}
+
+// DoesNotEqual matches the checkboxes which are not in the given state, see Equals.
+func (c Checkbox) DoesNotEqual(value bool) Condition {
+	return c.Equals(!value)
+}
+
+// Select holds the conditions on select properties.
+type Select struct {
+	build func(notion.SelectFilter) notion.Filter
+}
+
+// Equals matches the pages whose option has the given name.
+func (s Select) Equals(option string) Condition {
+	return Condition{filter: s.build(notion.SelectFilter{Equals: &option})}
+}
+
+// DoesNotEqual matches the pages whose option does not have the given name.
+func (s Select) DoesNotEqual(option string) Condition {
+	return Condition{filter: s.build(notion.SelectFilter{DoesNotEqual: &option})}
+}
+
+// IsEmpty matches the pages without option.
+func (s Select) IsEmpty() Condition {
+	return Condition{filter: s.build(notion.SelectFilter{IsEmpty: true})}
+}
+
+// IsNotEmpty matches the pages with an option.
+func (s Select) IsNotEmpty() Condition {
+	return Condition{filter: s.build(notion.SelectFilter{IsNotEmpty: true})}
+}
+
+// MultiSelect holds the conditions on multi select properties.
+type MultiSelect struct {
+	build func(notion.MultiSelectFilter) notion.Filter
+}
+
+// Contains matches the pages having the option with the given name.
+func (m MultiSelect) Contains(option string) Condition {
+	return Condition{filter: m.build(notion.MultiSelectFilter{Contains: &option})}
+}
+
+// DoesNotContain matches the pages which do not have the option with the given name.
+func (m MultiSelect) DoesNotContain(option string) Condition {
+	return Condition{filter: m.build(notion.MultiSelectFilter{DoesNotContain: &option})}
+}
+
+// IsEmpty matches the pages without options.
+func (m MultiSelect) IsEmpty() Condition {
+	return Condition{filter: m.build(notion.MultiSelectFilter{IsEmpty: true})}
+}
+
+// IsNotEmpty matches the pages with options.
+func (m MultiSelect) IsNotEmpty() Condition {
+	return Condition{filter: m.build(notion.MultiSelectFilter{IsNotEmpty: true})}
+}
+
+// Date holds the conditions on date, created time and last edited time properties, and on formulas returning
+// dates. Times at midnight UTC are compared as dates without time.
+type Date struct {
+	build func(notion.DateFilter) notion.Filter
+}
+
+// Equals matches the dates equal to the value.
+func (d Date) Equals(value time.Time) Condition {
+	date := formatDate(value)
+
+	return Condition{filter: d.build(notion.DateFilter{Equals: &date})}
+}
+
+// Before matches the dates before the value.
+func (d Date) Before(value time.Time) Condition {
+	date := formatDate(value)
+
+	return Condition{filter: d.build(notion.DateFilter{Before: &date})}
+}
+
+// After matches the dates after the value.
+func (d Date) After(value time.Time) Condition {
+	date := formatDate(value)
+
+	return Condition{filter: d.build(notion.DateFilter{After: &date})}
+}
+
+// OnOrBefore matches the dates on or before the value.
+func (d Date) OnOrBefore(value time.Time) Condition {
+	date := formatDate(value)
+
+	return Condition{filter: d.build(notion.DateFilter{OnOrBefore: &date})}
+}
+
+// OnOrAfter matches the dates on or after the value.
+func (d Date) OnOrAfter(value time.Time) Condition {
+	date := formatDate(value)
+
+	return Condition{filter: d.build(notion.DateFilter{OnOrAfter: &date})}
+}
+
+// IsEmpty matches the pages without date.
+func (d Date) IsEmpty() Condition {
+	return Condition{filter: d.build(notion.DateFilter{IsEmpty: true})}
+}
+
+// IsNotEmpty matches the pages with a date.
+func (d Date) IsNotEmpty() Condition {
+	return Condition{filter: d.build(notion.DateFilter{IsNotEmpty: true})}
+}
+
+// PastWeek matches the dates within the past week.
+func (d Date) PastWeek() Condition {
+	return Condition{filter: d.build(notion.DateFilter{PastWeek: map[string]interface{}{}})}
+}
+
+// PastMonth matches the dates within the past month.
+func (d Date) PastMonth() Condition {
+	return Condition{filter: d.build(notion.DateFilter{PastMonth: map[string]interface{}{}})}
+}
+
+// PastYear matches the dates within the past year.
+func (d Date) PastYear() Condition {
+	return Condition{filter: d.build(notion.DateFilter{PastYear: map[string]interface{}{}})}
+}
+
+// NextWeek matches the dates within the next week.
+func (d Date) NextWeek() Condition {
+	return Condition{filter: d.build(notion.DateFilter{NextWeek: map[string]interface{}{}})}
+}
+
+// NextMonth matches the dates within the next month.
+func (d Date) NextMonth() Condition {
+	return Condition{filter: d.build(notion.DateFilter{NextMonth: map[string]interface{}{}})}
+}
+
+// NextYear matches the dates within the next year.
+func (d Date) NextYear() Condition {
+	return Condition{filter: d.build(notion.DateFilter{NextYear: map[string]interface{}{}})}
+}
+
+// People holds the conditions on people, created by and last edited by properties.
+type People struct {
+	build func(notion.PeopleFilter) notion.Filter
+}
+
+// Contains matches the pages having the user with the given ID.
+func (p People) Contains(userID string) Condition {
+	return Condition{filter: p.build(notion.PeopleFilter{Contains: &userID})}
+}
+
+// DoesNotContain matches the pages which do not have the user with the given ID.
+func (p People) DoesNotContain(userID string) Condition {
+	return Condition{filter: p.build(notion.PeopleFilter{DoesNotContain: &userID})}
+}
+
+// IsEmpty matches the pages without users.
+func (p People) IsEmpty() Condition {
+	return Condition{filter: p.build(notion.PeopleFilter{IsEmpty: true})}
+}
+
+// IsNotEmpty matches the pages with users.
+func (p People) IsNotEmpty() Condition {
+	return Condition{filter: p.build(notion.PeopleFilter{IsNotEmpty: true})}
+}
+
+// Files holds the conditions on files properties.
+type Files struct {
+	build func(notion.FilesFilter) notion.Filter
+}
+
+// IsEmpty matches the pages without files.
+func (f Files) IsEmpty() Condition {
+	return Condition{filter: f.build(notion.FilesFilter{IsEmpty: true})}
+}
+
+// IsNotEmpty matches the pages with files.
+func (f Files) IsNotEmpty() Condition {
+	return Condition{filter: f.build(notion.FilesFilter{IsNotEmpty: true})}
+}
+
+// Relation holds the conditions on relation properties.
+type Relation struct {
+	build func(notion.RelationFilter) notion.Filter
+}
+
+// Contains matches the pages related to the page with the given ID.
+func (r Relation) Contains(pageID string) Condition {
+	return Condition{filter: r.build(notion.RelationFilter{Contains: &pageID})}
+}
+
+// DoesNotContain matches the pages which are not related to the page with the given ID.
+func (r Relation) DoesNotContain(pageID string) Condition {
+	return Condition{filter: r.build(notion.RelationFilter{DoesNotContain: &pageID})}
+}
+
+// IsEmpty matches the pages without related pages.
+func (r Relation) IsEmpty() Condition {
+	return Condition{filter: r.build(notion.RelationFilter{IsEmpty: true})}
+}
+
+// IsNotEmpty matches the pages with related pages.
+func (r Relation) IsNotEmpty() Condition {
+	return Condition{filter: r.build(notion.RelationFilter{IsNotEmpty: true})}
+}
+
+func newTrue() *bool {
+	b := true
+
+	return &b
+}
+
+// formatDate formats a time as a date without time when it is midnight UTC, as notion.MarshalProperties does.
+func formatDate(t time.Time) string {
+	if t.Location() == time.UTC && t.Equal(t.Truncate(24*time.Hour)) {
+		return t.Format("2006-01-02")
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
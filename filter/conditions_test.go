@@ -0,0 +1,88 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditions(t *testing.T) {
+	day := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+	instant := time.Date(2021, 5, 10, 14, 30, 0, 0, time.FixedZone("", 2*60*60))
+
+	tests := []struct {
+		name      string
+		condition Condition
+		want      string
+	}{
+		{"title equals", Prop("Name").Title().Equals("Kale"), `{"property": "Name", "text": {"equals": "Kale"}}`},
+		{"rich text does not equal", Prop("Notes").RichText().DoesNotEqual("x"), `{"property": "Notes", "rich_text": {"does_not_equal": "x"}}`},
+		{"url contains", Prop("URL").URL().Contains("medium.com"), `{"property": "URL", "url": {"contains": "medium.com"}}`},
+		{"email does not contain", Prop("Email").Email().DoesNotContain("@"), `{"property": "Email", "email": {"does_not_contain": "@"}}`},
+		{"phone starts with", Prop("Phone").PhoneNumber().StartsWith("+33"), `{"property": "Phone", "phone": {"starts_with": "+33"}}`},
+		{"text ends with", Prop("Name").Title().EndsWith("s"), `{"property": "Name", "text": {"ends_with": "s"}}`},
+		{"text is empty", Prop("Name").Title().IsEmpty(), `{"property": "Name", "text": {"is_empty": true}}`},
+		{"text is not empty", Prop("Name").Title().IsNotEmpty(), `{"property": "Name", "text": {"is_not_empty": true}}`},
+		{"number equals", Prop("Price").Number().Equals(0), `{"property": "Price", "number": {"equals": 0}}`},
+		{"number does not equal", Prop("Price").Number().DoesNotEqual(1), `{"property": "Price", "number": {"does_not_equal": 1}}`},
+		{"number greater than", Prop("Price").Number().GreaterThan(1), `{"property": "Price", "number": {"greater_than": 1}}`},
+		{"number less than", Prop("Price").Number().LessThan(1), `{"property": "Price", "number": {"less_than": 1}}`},
+		{"number greater than or equal to", Prop("Price").Number().GreaterThanOrEqualTo(1), `{"property": "Price", "number": {"greater_than_or_equal_to": 1}}`},
+		{"number less than or equal to", Prop("Price").Number().LessThanOrEqualTo(1), `{"property": "Price", "number": {"less_than_or_equal_to": 1}}`},
+		{"number is empty", Prop("Price").Number().IsEmpty(), `{"property": "Price", "number": {"is_empty": true}}`},
+		{"number is not empty", Prop("Price").Number().IsNotEmpty(), `{"property": "Price", "number": {"is_not_empty": true}}`},
+		{"checkbox equals true", Prop("Done").Checkbox().Equals(true), `{"property": "Done", "checkbox": {"equals": true}}`},
+		{"checkbox equals false", Prop("Done").Checkbox().Equals(false), `{"property": "Done", "checkbox": {"does_not_equal": true}}`},
+		{"checkbox does not equal false", Prop("Done").Checkbox().DoesNotEqual(false), `{"property": "Done", "checkbox": {"equals": true}}`},
+		{"select equals", Prop("Status").Select().Equals("Done"), `{"property": "Status", "select": {"equals": "Done"}}`},
+		{"select does not equal", Prop("Status").Select().DoesNotEqual("Done"), `{"property": "Status", "select": {"does_not_equal": "Done"}}`},
+		{"select is empty", Prop("Status").Select().IsEmpty(), `{"property": "Status", "select": {"is_empty": true}}`},
+		{"select is not empty", Prop("Status").Select().IsNotEmpty(), `{"property": "Status", "select": {"is_not_empty": true}}`},
+		{"multi select contains", Prop("Tags").MultiSelect().Contains("urgent"), `{"property": "Tags", "multi_select": {"contains": "urgent"}}`},
+		{"multi select does not contain", Prop("Tags").MultiSelect().DoesNotContain("urgent"), `{"property": "Tags", "multi_select": {"does_not_contain": "urgent"}}`},
+		{"multi select is empty", Prop("Tags").MultiSelect().IsEmpty(), `{"property": "Tags", "multi_select": {"is_empty": true}}`},
+		{"multi select is not empty", Prop("Tags").MultiSelect().IsNotEmpty(), `{"property": "Tags", "multi_select": {"is_not_empty": true}}`},
+		{"date equals", Prop("Due").Date().Equals(day), `{"property": "Due", "date": {"equals": "2021-05-10"}}`},
+		{"date before", Prop("Due").Date().Before(instant), `{"property": "Due", "date": {"before": "2021-05-10T14:30:00+02:00"}}`},
+		{"date after", Prop("Due").Date().After(day), `{"property": "Due", "date": {"after": "2021-05-10"}}`},
+		{"date on or before", Prop("Due").Date().OnOrBefore(day), `{"property": "Due", "date": {"on_or_before": "2021-05-10"}}`},
+		{"date on or after", Prop("Due").Date().OnOrAfter(day), `{"property": "Due", "date": {"on_or_after": "2021-05-10"}}`},
+		{"date is empty", Prop("Due").Date().IsEmpty(), `{"property": "Due", "date": {"is_empty": true}}`},
+		{"date is not empty", Prop("Due").Date().IsNotEmpty(), `{"property": "Due", "date": {"is_not_empty": true}}`},
+		{"date past week", Prop("Due").Date().PastWeek(), `{"property": "Due", "date": {"past_week": {}}}`},
+		{"date past month", Prop("Due").Date().PastMonth(), `{"property": "Due", "date": {"past_month": {}}}`},
+		{"date past year", Prop("Due").Date().PastYear(), `{"property": "Due", "date": {"past_year": {}}}`},
+		{"date next week", Prop("Due").Date().NextWeek(), `{"property": "Due", "date": {"next_week": {}}}`},
+		{"date next month", Prop("Due").Date().NextMonth(), `{"property": "Due", "date": {"next_month": {}}}`},
+		{"date next year", Prop("Due").Date().NextYear(), `{"property": "Due", "date": {"next_year": {}}}`},
+		{"created time", Prop("Created").CreatedTime().After(day), `{"property": "Created", "created_time": {"after": "2021-05-10"}}`},
+		{"last edited time", Prop("Edited").LastEditedTime().PastWeek(), `{"property": "Edited", "last_edited_time": {"past_week": {}}}`},
+		{"people contains", Prop("Assignees").People().Contains("u1"), `{"property": "Assignees", "people": {"contains": "u1"}}`},
+		{"people does not contain", Prop("Assignees").People().DoesNotContain("u1"), `{"property": "Assignees", "people": {"does_not_contain": "u1"}}`},
+		{"people is empty", Prop("Assignees").People().IsEmpty(), `{"property": "Assignees", "people": {"is_empty": true}}`},
+		{"people is not empty", Prop("Assignees").People().IsNotEmpty(), `{"property": "Assignees", "people": {"is_not_empty": true}}`},
+		{"created by", Prop("Author").CreatedBy().Contains("u1"), `{"property": "Author", "created_by": {"contains": "u1"}}`},
+		{"last edited by", Prop("Editor").LastEditedBy().Contains("u1"), `{"property": "Editor", "last_edited_by": {"contains": "u1"}}`},
+		{"files is empty", Prop("Files").Files().IsEmpty(), `{"property": "Files", "files": {"is_empty": true}}`},
+		{"files is not empty", Prop("Files").Files().IsNotEmpty(), `{"property": "Files", "files": {"is_not_empty": true}}`},
+		{"relation contains", Prop("Project").Relation().Contains("p1"), `{"property": "Project", "relation": {"contains": "p1"}}`},
+		{"relation does not contain", Prop("Project").Relation().DoesNotContain("p1"), `{"property": "Project", "relation": {"does_not_contain": "p1"}}`},
+		{"relation is empty", Prop("Project").Relation().IsEmpty(), `{"property": "Project", "relation": {"is_empty": true}}`},
+		{"relation is not empty", Prop("Project").Relation().IsNotEmpty(), `{"property": "Project", "relation": {"is_not_empty": true}}`},
+		{"formula text", Prop("Total").Formula().Text().Contains("a"), `{"property": "Total", "formula": {"text": {"contains": "a"}}}`},
+		{"formula checkbox", Prop("Total").Formula().Checkbox().Equals(true), `{"property": "Total", "formula": {"checkbox": {"equals": true}}}`},
+		{"formula number", Prop("Total").Formula().Number().GreaterThan(2), `{"property": "Total", "formula": {"number": {"greater_than": 2}}}`},
+		{"formula date", Prop("Total").Formula().Date().NextMonth(), `{"property": "Total", "formula": {"date": {"next_month": {}}}}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.condition.Build())
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Package filter builds the filters of database queries, e.g.
+//
+//	filter.Prop("URL").URL().Contains("medium.com").Or(filter.Prop("Done").Checkbox().Equals(true))
+//
+// Each type of property only has the conditions which apply to it, so that impossible filters, such as a
+// number condition on a checkbox property, do not compile. Conditions are combined with And and Or, and
+// Build returns the filter as the types of the notion package, e.g. for DatabasesQueryParameters.Filter.
+package filter
+
+import (
+	"github.com/mkfsn/notion-go"
+)
+
+// Condition is a filter condition, or a combination of conditions. The zero Condition matches every page:
+// it is left out when combined with other conditions, and is built as a nil filter.
+type Condition struct {
+	filter notion.Filter
+}
+
+// Of returns the condition of a filter built otherwise, e.g. by the code generated by notion-gen, so that it can
+// be combined with other conditions.
+func Of(filter notion.Filter) Condition {
+	return Condition{filter: filter}
+}
+
+// Build returns the filter, or nil for the zero Condition.
+func (c Condition) Build() notion.Filter {
+	return c.filter
+}
+
+// IsZero reports whether the condition is the zero Condition.
+func (c Condition) IsZero() bool {
+	return c.filter == nil
+}
+
+// And returns a condition matching the pages matched by the condition and by all the others.
+func (c Condition) And(others ...Condition) Condition {
+	return And(append([]Condition{c}, others...)...)
+}
+
+// Or returns a condition matching the pages matched by the condition or by any of the others.
+func (c Condition) Or(others ...Condition) Condition {
+	return Or(append([]Condition{c}, others...)...)
+}
+
+// And returns a condition matching the pages matched by all the conditions. Conditions which are
+// themselves built by And are flattened, and a single condition is returned as is.
+func And(conditions ...Condition) Condition {
+	filters := compound(conditions, func(f notion.CompoundFilter) []notion.Filter {
+		return f.And
+	})
+
+	if len(filters) <= 1 {
+		return single(filters)
+	}
+
+	return Condition{filter: notion.CompoundFilter{And: filters}}
+}
+
+// Or returns a condition matching the pages matched by any of the conditions. Conditions which are
+// themselves built by Or are flattened, and a single condition is returned as is.
+func Or(conditions ...Condition) Condition {
+	filters := compound(conditions, func(f notion.CompoundFilter) []notion.Filter {
+		return f.Or
+	})
+
+	if len(filters) <= 1 {
+		return single(filters)
+	}
+
+	return Condition{filter: notion.CompoundFilter{Or: filters}}
+}
+
+// compound returns the filters of the conditions, leaving out the zero conditions and flattening the
+// compound filters with the same operator, as returned by operands.
+func compound(conditions []Condition, operands func(notion.CompoundFilter) []notion.Filter) []notion.Filter {
+	filters := make([]notion.Filter, 0, len(conditions))
+
+	for _, condition := range conditions {
+		if condition.IsZero() {
+			continue
+		}
+
+		if f, ok := condition.filter.(notion.CompoundFilter); ok {
+			if nested := operands(f); len(nested) > 0 && len(nested) == len(f.And)+len(f.Or) {
+				filters = append(filters, nested...)
+
+				continue
+			}
+		}
+
+		filters = append(filters, condition.filter)
+	}
+
+	return filters
+}
+
+func single(filters []notion.Filter) Condition {
+	if len(filters) == 0 {
+		return Condition{}
+	}
+
+	return Condition{filter: filters[0]}
+}
+
+// Property is a property of the database to filter on.
+type Property struct {
+	name string
+}
+
+// Prop returns the property with the given name, or identifier.
+func Prop(name string) Property {
+	return Property{name: name}
+}
+
+func (p Property) property() notion.SinglePropertyFilter {
+	return notion.SinglePropertyFilter{Property: p.name}
+}
+
+// Title returns the conditions on a title property.
+func (p Property) Title() Text {
+	return Text{build: func(f notion.TextFilter) notion.Filter {
+		return notion.SingleTextFilter{SinglePropertyFilter: p.property(), Text: &f}
+	}}
+}
+
+// RichText returns the conditions on a rich text property.
+func (p Property) RichText() Text {
+	return Text{build: func(f notion.TextFilter) notion.Filter {
+		return notion.SingleTextFilter{SinglePropertyFilter: p.property(), RichText: &f}
+	}}
+}
+
+// URL returns the conditions on a URL property.
+func (p Property) URL() Text {
+	return Text{build: func(f notion.TextFilter) notion.Filter {
+		return notion.SingleTextFilter{SinglePropertyFilter: p.property(), URL: &f}
+	}}
+}
+
+// Email returns the conditions on an email property.
+func (p Property) Email() Text {
+	return Text{build: func(f notion.TextFilter) notion.Filter {
+		return notion.SingleTextFilter{SinglePropertyFilter: p.property(), Email: &f}
+	}}
+}
+
+// PhoneNumber returns the conditions on a phone number property.
+func (p Property) PhoneNumber() Text {
+	return Text{build: func(f notion.TextFilter) notion.Filter {
+		return notion.SingleTextFilter{SinglePropertyFilter: p.property(), Phone: &f}
+	}}
+}
+
+// Number returns the conditions on a number property.
+func (p Property) Number() Number {
+	return Number{build: func(f notion.NumberFilter) notion.Filter {
+		return notion.SingleNumberFilter{SinglePropertyFilter: p.property(), Number: f}
+	}}
+}
+
+// Checkbox returns the conditions on a checkbox property.
+func (p Property) Checkbox() Checkbox {
+	return Checkbox{build: func(f notion.CheckboxFilter) notion.Filter {
+		return notion.SingleCheckboxFilter{SinglePropertyFilter: p.property(), Checkbox: f}
+	}}
+}
+
+// Select returns the conditions on a select property.
+func (p Property) Select() Select {
+	return Select{build: func(f notion.SelectFilter) notion.Filter {
+		return notion.SingleSelectFilter{SinglePropertyFilter: p.property(), Select: f}
+	}}
+}
+
+// MultiSelect returns the conditions on a multi select property.
+func (p Property) MultiSelect() MultiSelect {
+	return MultiSelect{build: func(f notion.MultiSelectFilter) notion.Filter {
+		return notion.SingleMultiSelectFilter{SinglePropertyFilter: p.property(), MultiSelect: f}
+	}}
+}
+
+// Date returns the conditions on a date property.
+func (p Property) Date() Date {
+	return Date{build: func(f notion.DateFilter) notion.Filter {
+		return notion.SingleDateFilter{SinglePropertyFilter: p.property(), Date: &f}
+	}}
+}
+
+// CreatedTime returns the conditions on a created time property.
+func (p Property) CreatedTime() Date {
+	return Date{build: func(f notion.DateFilter) notion.Filter {
+		return notion.SingleDateFilter{SinglePropertyFilter: p.property(), CreatedTime: &f}
+	}}
+}
+
+// LastEditedTime returns the conditions on a last edited time property.
+func (p Property) LastEditedTime() Date {
+	return Date{build: func(f notion.DateFilter) notion.Filter {
+		return notion.SingleDateFilter{SinglePropertyFilter: p.property(), LastEditedTime: &f}
+	}}
+}
+
+// People returns the conditions on a people property.
+func (p Property) People() People {
+	return People{build: func(f notion.PeopleFilter) notion.Filter {
+		return notion.SinglePeopleFilter{SinglePropertyFilter: p.property(), People: &f}
+	}}
+}
+
+// CreatedBy returns the conditions on a created by property.
+func (p Property) CreatedBy() People {
+	return People{build: func(f notion.PeopleFilter) notion.Filter {
+		return notion.SinglePeopleFilter{SinglePropertyFilter: p.property(), CreatedBy: &f}
+	}}
+}
+
+// LastEditedBy returns the conditions on a last edited by property.
+func (p Property) LastEditedBy() People {
+	return People{build: func(f notion.PeopleFilter) notion.Filter {
+		return notion.SinglePeopleFilter{SinglePropertyFilter: p.property(), LastEditedBy: &f}
+	}}
+}
+
+// Files returns the conditions on a files property.
+func (p Property) Files() Files {
+	return Files{build: func(f notion.FilesFilter) notion.Filter {
+		return notion.SingleFilesFilter{SinglePropertyFilter: p.property(), Files: f}
+	}}
+}
+
+// Relation returns the conditions on a relation property.
+func (p Property) Relation() Relation {
+	return Relation{build: func(f notion.RelationFilter) notion.Filter {
+		return notion.SingleRelationFilter{SinglePropertyFilter: p.property(), Relation: f}
+	}}
+}
+
+// Formula returns the conditions on a formula property, depending on the type of its result.
+func (p Property) Formula() Formula {
+	return Formula{property: p}
+}
+
+// Formula holds the conditions on a formula property.
+type Formula struct {
+	property Property
+}
+
+// Text returns the conditions on a formula returning text.
+func (f Formula) Text() Text {
+	return Text{build: func(c notion.TextFilter) notion.Filter {
+		return notion.SingleFormulaFilter{SinglePropertyFilter: f.property.property(), Formula: notion.FormulaFilter{Text: &c}}
+	}}
+}
+
+// Checkbox returns the conditions on a formula returning a boolean.
+func (f Formula) Checkbox() Checkbox {
+	return Checkbox{build: func(c notion.CheckboxFilter) notion.Filter {
+		return notion.SingleFormulaFilter{SinglePropertyFilter: f.property.property(), Formula: notion.FormulaFilter{Checkbox: &c}}
+	}}
+}
+
+// Number returns the conditions on a formula returning a number.
+func (f Formula) Number() Number {
+	return Number{build: func(c notion.NumberFilter) notion.Filter {
+		return notion.SingleFormulaFilter{SinglePropertyFilter: f.property.property(), Formula: notion.FormulaFilter{Number: &c}}
+	}}
+}
+
+// Date returns the conditions on a formula returning a date.
+func (f Formula) Date() Date {
+	return Date{build: func(c notion.DateFilter) notion.Filter {
+		return notion.SingleFormulaFilter{SinglePropertyFilter: f.property.property(), Formula: notion.FormulaFilter{Date: &c}}
+	}}
+}
@@ -0,0 +1,107 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCondition(t *testing.T) {
+	url := Prop("URL").URL().Contains("medium.com")
+	done := Prop("Done").Checkbox().Equals(true)
+	price := Prop("Price").Number().LessThan(5)
+
+	tests := []struct {
+		name      string
+		condition Condition
+		want      string
+	}{
+		{
+			name:      "or",
+			condition: url.Or(done),
+			want: `{"or": [
+				{"property": "URL", "url": {"contains": "medium.com"}},
+				{"property": "Done", "checkbox": {"equals": true}}
+			]}`,
+		},
+		{
+			name:      "flattened",
+			condition: url.Or(done).Or(price),
+			want: `{"or": [
+				{"property": "URL", "url": {"contains": "medium.com"}},
+				{"property": "Done", "checkbox": {"equals": true}},
+				{"property": "Price", "number": {"less_than": 5}}
+			]}`,
+		},
+		{
+			name:      "nested",
+			condition: And(url.Or(done), price),
+			want: `{"and": [
+				{"or": [
+					{"property": "URL", "url": {"contains": "medium.com"}},
+					{"property": "Done", "checkbox": {"equals": true}}
+				]},
+				{"property": "Price", "number": {"less_than": 5}}
+			]}`,
+		},
+		{
+			name:      "zero conditions left out",
+			condition: Condition{}.And(price, Condition{}),
+			want:      `{"property": "Price", "number": {"less_than": 5}}`,
+		},
+		{
+			name:      "of",
+			condition: Of(notion.SingleCheckboxFilter{SinglePropertyFilter: notion.SinglePropertyFilter{Property: "Done"}}).And(price),
+			want: `{"and": [
+				{"property": "Done", "checkbox": {}},
+				{"property": "Price", "number": {"less_than": 5}}
+			]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.condition.Build())
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestCondition_Zero(t *testing.T) {
+	var condition Condition
+
+	assert.True(t, condition.IsZero())
+	assert.Nil(t, condition.Build())
+	assert.True(t, And().IsZero())
+	assert.True(t, Or(Condition{}, Condition{}).IsZero())
+
+	params := notion.DatabasesQueryParameters{Filter: condition.Build()}
+	data, err := json.Marshal(params)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "filter")
+}
+
+func TestCondition_Build(t *testing.T) {
+	want := notion.CompoundFilter{
+		Or: []notion.Filter{
+			notion.SingleTextFilter{
+				SinglePropertyFilter: notion.SinglePropertyFilter{Property: "URL"},
+				URL:                  &notion.TextFilter{Contains: newString("medium.com")},
+			},
+			notion.SingleSelectFilter{
+				SinglePropertyFilter: notion.SinglePropertyFilter{Property: "Status"},
+				Select:               notion.SelectFilter{Equals: newString("Done")},
+			},
+		},
+	}
+
+	assert.Equal(t, want, Prop("URL").URL().Contains("medium.com").Or(Prop("Status").Select().Equals("Done")).Build())
+}
+
+func newString(s string) *string {
+	return &s
+}
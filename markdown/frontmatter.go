@@ -0,0 +1,237 @@
+package markdown
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var plainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_ -]*[A-Za-z0-9_]$|^[A-Za-z_]$`)
+
+// Page renders a page as Markdown, with a YAML front matter generated from its properties followed by its content.
+func Page(page notion.Page, content []*notion.BlockNode) (string, error) {
+	frontMatter, err := FrontMatter(page.Properties)
+	if err != nil {
+		return "", err
+	}
+
+	rendered := Tree(content)
+	if rendered == "" {
+		return frontMatter, nil
+	}
+
+	return frontMatter + "\n" + rendered + "\n", nil
+}
+
+// FrontMatter renders the property values of a page as a YAML front matter, sorted by property name.
+// Every value is simplified to a scalar or a list, e.g. a select is rendered as the name of its option.
+func FrontMatter(properties map[string]notion.PropertyValue) (string, error) {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var b strings.Builder
+
+	b.WriteString("---\n")
+
+	for _, name := range names {
+		// JSON is a subset of YAML, so JSON encoded values are valid YAML flow values.
+		value, err := encodeJSON(Value(properties[name]))
+		if err != nil {
+			return "", fmt.Errorf("failed to render property %q: %w", name, err)
+		}
+
+		key := name
+		if !plainKey.MatchString(name) {
+			if key, err = encodeJSON(name); err != nil {
+				return "", fmt.Errorf("failed to render property %q: %w", name, err)
+			}
+		}
+
+		b.WriteString(key + ": " + value + "\n")
+	}
+
+	b.WriteString("---\n")
+
+	return b.String(), nil
+}
+
+func encodeJSON(v interface{}) (string, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(v); err != nil {
+		return "", fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// Value simplifies a property value to a scalar or a list, as it is rendered in front matters, e.g. the name of the
+// option of a select, the plain text of a title or the names of people. Dates with an end are rendered as an object
+// with a start and an end, and values which cannot be simplified as nil.
+// nolint: cyclop
+func Value(value notion.PropertyValue) interface{} {
+	switch v := deref(value).(type) {
+	case notion.TitlePropertyValue:
+		return notion.PlainText(v.Title)
+
+	case notion.RichTextPropertyValue:
+		return notion.PlainText(v.RichText)
+
+	case notion.NumberPropertyValue:
+		return v.Number
+
+	case notion.SelectPropertyValue:
+		if v.Select.Name == "" {
+			return nil
+		}
+
+		return v.Select.Name
+
+	case notion.MultiSelectPropertyValue:
+		names := make([]string, 0, len(v.MultiSelect))
+		for _, option := range v.MultiSelect {
+			names = append(names, option.Name)
+		}
+
+		return names
+
+	case notion.DatePropertyValue:
+		return simplifyDate(v.Date)
+
+	case notion.FormulaPropertyValue:
+		return simplifyFormula(v.Formula)
+
+	case notion.RelationPropertyValue:
+		ids := make([]string, 0, len(v.Relation))
+		for _, reference := range v.Relation {
+			ids = append(ids, reference.ID)
+		}
+
+		return ids
+
+	case notion.RollupPropertyValue:
+		return simplifyRollup(v.Rollup)
+
+	case notion.PeoplePropertyValue:
+		names := make([]string, 0, len(v.People))
+		for _, user := range v.People {
+			names = append(names, userName(user))
+		}
+
+		return names
+
+	case notion.FilesPropertyValue:
+		names := make([]string, 0, len(v.Files))
+		for _, file := range v.Files {
+			names = append(names, file.Name)
+		}
+
+		return names
+
+	case notion.CheckboxPropertyValue:
+		return v.Checkbox
+
+	case notion.URLPropertyValue:
+		return v.URL
+
+	case notion.EmailPropertyValue:
+		return v.Email
+
+	case notion.PhoneNumberPropertyValue:
+		return v.PhoneNumber
+
+	case notion.CreatedTimePropertyValue:
+		return v.CreatedTime.Format(time.RFC3339)
+
+	case notion.CreatedByPropertyValue:
+		return userName(v.CreatedBy)
+
+	case notion.LastEditedTimePropertyValue:
+		return v.LastEditedTime.Format(time.RFC3339)
+
+	case notion.LastEditedByPropertyValue:
+		return userName(v.LastEditedBy)
+	}
+
+	return nil
+}
+
+func simplifyDate(date notion.Date) interface{} {
+	if date.End == nil {
+		return date.Start
+	}
+
+	return struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}{
+		Start: date.Start,
+		End:   *date.End,
+	}
+}
+
+func simplifyFormula(formula notion.FormulaValue) interface{} {
+	switch v := deref(formula).(type) {
+	case notion.StringFormulaValue:
+		return v.String
+
+	case notion.NumberFormulaValue:
+		return v.Number
+
+	case notion.BooleanFormulaValue:
+		return v.Boolean
+
+	case notion.DateFormulaValue:
+		return simplifyDate(v.Date.Date)
+	}
+
+	return nil
+}
+
+func simplifyRollup(rollup notion.RollupValueType) interface{} {
+	switch v := deref(rollup).(type) {
+	case notion.NumberRollupValue:
+		return v.Number
+
+	case notion.DateRollupValue:
+		return simplifyDate(v.Date)
+
+	case notion.ArrayRollupValue:
+		values := make([]interface{}, 0, len(v.Array))
+		for _, value := range v.Array {
+			values = append(values, Value(value))
+		}
+
+		return values
+	}
+
+	return nil
+}
+
+func userName(user notion.User) string {
+	switch u := deref(user).(type) {
+	case notion.PersonUser:
+		return u.Name
+
+	case notion.BotUser:
+		return u.Name
+
+	case notion.PartialUser:
+		return u.ID
+	}
+
+	return ""
+}
@@ -0,0 +1,413 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// maxRichTextLength is the maximum length of the content of a rich text accepted by the Notion API.
+const maxRichTextLength = 2000
+
+// segment is a run of text sharing the same annotations and link.
+type segment struct {
+	content     string
+	annotations notion.Annotations
+	link        string
+	equation    bool
+}
+
+// ParseInline parses inline Markdown into rich texts: emphasis, code spans, strikethrough and underline (<u>)
+// are mapped to annotations, links and autolinks to links, inline equations ($...$) to equations, and line
+// breaks (<br>) to new lines.
+func ParseInline(source string) []notion.RichText {
+	segments := parseInline(source, notion.Annotations{}, "")
+
+	richTexts := make([]notion.RichText, 0, len(segments))
+
+	for _, s := range mergeSegments(segments) {
+		richTexts = append(richTexts, s.richTexts()...)
+	}
+
+	return richTexts
+}
+
+// nolint: cyclop, funlen, gocognit
+func parseInline(source string, annotations notion.Annotations, link string) []segment {
+	var (
+		segments []segment
+		text     strings.Builder
+	)
+
+	flush := func() {
+		if text.Len() > 0 {
+			segments = append(segments, segment{content: text.String(), annotations: annotations, link: link})
+			text.Reset()
+		}
+	}
+
+	nested := func(inner string, a notion.Annotations, l string) {
+		flush()
+		segments = append(segments, parseInline(inner, a, l)...)
+	}
+
+	for i := 0; i < len(source); {
+		rest := source[i:]
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && isASCIIPunct(rest[1]):
+			text.WriteByte(rest[1])
+			i += 2
+
+			continue
+
+		case rest[0] == '`':
+			fence := rest[:countPrefix(rest, '`')]
+			if end := strings.Index(rest[len(fence):], fence); end >= 0 {
+				code := rest[len(fence) : len(fence)+end]
+				if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+					code = code[1 : len(code)-1]
+				}
+
+				a := annotations
+				a.Code = true
+
+				flush()
+				segments = append(segments, segment{content: code, annotations: a, link: link})
+				i += 2*len(fence) + end
+
+				continue
+			}
+
+			text.WriteString(fence)
+			i += len(fence)
+
+			continue
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if inner, n, ok := delimited(source, i, rest[:2]); ok {
+				a := annotations
+				a.Bold = true
+
+				nested(inner, a, link)
+				i += n
+
+				continue
+			}
+
+		case strings.HasPrefix(rest, "~~"):
+			if inner, n, ok := delimited(source, i, "~~"); ok {
+				a := annotations
+				a.Strikethrough = true
+
+				nested(inner, a, link)
+				i += n
+
+				continue
+			}
+
+		case rest[0] == '*' || rest[0] == '_':
+			if inner, n, ok := delimited(source, i, rest[:1]); ok {
+				a := annotations
+				a.Italic = true
+
+				nested(inner, a, link)
+				i += n
+
+				continue
+			}
+
+		case strings.HasPrefix(rest, "<u>"):
+			if end := strings.Index(rest, "</u>"); end > len("<u>") {
+				a := annotations
+				a.Underline = true
+
+				nested(rest[len("<u>"):end], a, link)
+				i += end + len("</u>")
+
+				continue
+			}
+
+		case strings.HasPrefix(rest, "<br>") || strings.HasPrefix(rest, "<br/>"):
+			text.WriteByte('\n')
+			i += strings.IndexByte(rest, '>') + 1
+
+			continue
+
+		case rest[0] == '<':
+			if end := strings.IndexByte(rest, '>'); end > 0 && isURL(rest[1:end]) {
+				url := rest[1:end]
+
+				flush()
+				segments = append(segments, segment{content: url, annotations: annotations, link: url})
+				i += end + 1
+
+				continue
+			}
+
+		case rest[0] == '[' && link == "":
+			if label, url, n, ok := parseLink(rest); ok {
+				nested(label, annotations, url)
+				i += n
+
+				continue
+			}
+
+		case rest[0] == '$':
+			if end := strings.IndexByte(rest[1:], '$'); end > 0 && isEquation(rest, end+1) {
+				flush()
+				segments = append(segments, segment{content: rest[1 : end+1], annotations: annotations, equation: true})
+				i += end + 2
+
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		text.WriteString(rest[:size])
+		i += size
+	}
+
+	flush()
+
+	return segments
+}
+
+// delimited returns the text between the delimiter at position i and its closing delimiter,
+// and the length of the whole delimited text.
+func delimited(source string, i int, delimiter string) (string, int, bool) {
+	start := i + len(delimiter)
+	if start >= len(source) || source[start] == ' ' {
+		return "", 0, false
+	}
+
+	// An underscore inside of a word is not a delimiter, e.g. snake_case.
+	if delimiter[0] == '_' && i > 0 && isWordByte(source[i-1]) {
+		return "", 0, false
+	}
+
+	for j := start + 1; j+len(delimiter) <= len(source); j++ {
+		switch {
+		case source[j-1] == '\\':
+			continue
+
+		case source[j] == '`':
+			// Delimiters inside of code spans do not count.
+			if end := strings.IndexByte(source[j+1:], '`'); end >= 0 {
+				j += end + 1
+			}
+
+			continue
+		}
+
+		if !strings.HasPrefix(source[j:], delimiter) || source[j-1] == ' ' {
+			continue
+		}
+
+		end := j + len(delimiter)
+
+		// A single delimiter is not closed by the first character of a double one, e.g. *a **b***.
+		if len(delimiter) == 1 && end < len(source) && source[end] == delimiter[0] {
+			j++
+
+			continue
+		}
+
+		if delimiter[0] == '_' && end < len(source) && isWordByte(source[end]) {
+			continue
+		}
+
+		return source[start:j], end - i, true
+	}
+
+	return "", 0, false
+}
+
+// parseLink parses an inline link, e.g. [label](https://example.com "title").
+func parseLink(source string) (string, string, int, bool) {
+	depth := 0
+
+	for i := 0; i < len(source); i++ {
+		switch source[i] {
+		case '\\':
+			i++
+
+		case '[':
+			depth++
+
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+
+			if i+1 >= len(source) || source[i+1] != '(' {
+				return "", "", 0, false
+			}
+
+			if strings.HasPrefix(source[i+2:], "<") {
+				url, n, ok := parseAngleDestination(source[i+2:])
+				if !ok {
+					return "", "", 0, false
+				}
+
+				end := strings.IndexByte(source[i+2+n:], ')')
+				if end < 0 {
+					return "", "", 0, false
+				}
+
+				return source[1:i], url, i + 3 + n + end, true
+			}
+
+			end := strings.IndexByte(source[i+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+
+			destination := strings.Fields(source[i+2 : i+2+end])
+			if len(destination) == 0 {
+				return "", "", 0, false
+			}
+
+			return source[1:i], strings.Trim(destination[0], "<>"), i + 3 + end, true
+		}
+	}
+
+	return "", "", 0, false
+}
+
+// parseAngleDestination parses a link destination between angle brackets at the start of the source, e.g.
+// <https://example.com/a b>, returning the unescaped URL and the length of the destination.
+func parseAngleDestination(source string) (string, int, bool) {
+	var url strings.Builder
+
+	for i := 1; i < len(source); i++ {
+		switch c := source[i]; {
+		case c == '\\' && i+1 < len(source) && isASCIIPunct(source[i+1]):
+			i++
+			url.WriteByte(source[i])
+
+		case c == '>':
+			return url.String(), i + 1, true
+
+		case c == '<' || c == '\n':
+			return "", 0, false
+
+		default:
+			url.WriteByte(c)
+		}
+	}
+
+	return "", 0, false
+}
+
+// isEquation reports whether the dollars at position 0 and end of the source delimit an inline equation:
+// the equation must not start or end with a space, and the closing dollar must not be followed by a digit,
+// so that prices like "$3 and $4" are not equations.
+func isEquation(source string, end int) bool {
+	if source[1] == ' ' || source[end-1] == ' ' {
+		return false
+	}
+
+	return end+1 >= len(source) || !unicode.IsDigit(rune(source[end+1]))
+}
+
+func isURL(s string) bool {
+	return (strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "mailto:")) &&
+		!strings.ContainsAny(s, " <")
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+func isWordByte(c byte) bool {
+	return c >= utf8.RuneSelf || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+func countPrefix(s string, c byte) int {
+	n := 0
+	for n < len(s) && s[n] == c {
+		n++
+	}
+
+	return n
+}
+
+func mergeSegments(segments []segment) []segment {
+	merged := make([]segment, 0, len(segments))
+
+	for _, s := range segments {
+		if s.content == "" {
+			continue
+		}
+
+		if n := len(merged); n > 0 && !s.equation && !merged[n-1].equation &&
+			merged[n-1].annotations == s.annotations && merged[n-1].link == s.link {
+			merged[n-1].content += s.content
+
+			continue
+		}
+
+		merged = append(merged, s)
+	}
+
+	return merged
+}
+
+// richTexts converts the segment to rich texts, split to respect the maximum length of a rich text.
+func (s segment) richTexts() []notion.RichText {
+	var annotations *notion.Annotations
+
+	if s.annotations != (notion.Annotations{}) {
+		a := s.annotations
+		a.Color = notion.ColorDefault
+		annotations = &a
+	}
+
+	if s.equation {
+		return []notion.RichText{
+			notion.RichTextEquation{
+				BaseRichText: notion.BaseRichText{Type: notion.RichTextTypeEquation, Annotations: annotations},
+				Equation:     notion.EquationObject{Expression: s.content},
+			},
+		}
+	}
+
+	var richTexts []notion.RichText
+
+	for _, content := range splitRunes(s.content, maxRichTextLength) {
+		text := notion.RichTextText{
+			BaseRichText: notion.BaseRichText{Type: notion.RichTextTypeText, Annotations: annotations},
+			Text:         notion.TextObject{Content: content},
+		}
+
+		if s.link != "" {
+			text.Text.Link = &notion.Link{URL: s.link}
+		}
+
+		richTexts = append(richTexts, text)
+	}
+
+	return richTexts
+}
+
+func splitRunes(s string, n int) []string {
+	var chunks []string
+
+	for utf8.RuneCountInString(s) > n {
+		i, count := 0, 0
+		for count < n {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+			count++
+		}
+
+		chunks = append(chunks, s[:i])
+		s = s[i:]
+	}
+
+	return append(chunks, s)
+}
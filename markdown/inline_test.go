@@ -0,0 +1,117 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInline(t *testing.T) {
+	annotated := func(content string, annotations notion.Annotations) notion.RichText {
+		annotations.Color = notion.ColorDefault
+
+		return notion.RichTextText{
+			BaseRichText: notion.BaseRichText{Type: notion.RichTextTypeText, Annotations: &annotations},
+			Text:         notion.TextObject{Content: content},
+		}
+	}
+
+	linked := func(content, url string) notion.RichText {
+		return notion.RichTextText{
+			BaseRichText: notion.BaseRichText{Type: notion.RichTextTypeText},
+			Text:         notion.TextObject{Content: content, Link: &notion.Link{URL: url}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   []notion.RichText
+	}{
+		{
+			name:   "Plain text with escapes",
+			source: `2 \* 3 = \[6\]`,
+			want:   []notion.RichText{text("2 * 3 = [6]")},
+		},
+		{
+			name:   "Nested emphasis",
+			source: "**bold _and italic_** text",
+			want: []notion.RichText{
+				annotated("bold ", notion.Annotations{Bold: true}),
+				annotated("and italic", notion.Annotations{Bold: true, Italic: true}),
+				text(" text"),
+			},
+		},
+		{
+			name:   "Strikethrough, underline and code",
+			source: "~~old~~ <u>new</u> ``a `b` c``",
+			want: []notion.RichText{
+				annotated("old", notion.Annotations{Strikethrough: true}),
+				text(" "),
+				annotated("new", notion.Annotations{Underline: true}),
+				text(" "),
+				annotated("a `b` c", notion.Annotations{Code: true}),
+			},
+		},
+		{
+			name:   "Delimiters inside of words and code spans",
+			source: "snake_case_name and `*not emphasis*`",
+			want: []notion.RichText{
+				text("snake_case_name and "),
+				annotated("*not emphasis*", notion.Annotations{Code: true}),
+			},
+		},
+		{
+			name:   "Links",
+			source: "[**kale**](https://example.com/kale \"Kale\") or <https://example.com>",
+			want: []notion.RichText{
+				notion.RichTextText{
+					BaseRichText: notion.BaseRichText{
+						Type:        notion.RichTextTypeText,
+						Annotations: &notion.Annotations{Bold: true, Color: notion.ColorDefault},
+					},
+					Text: notion.TextObject{Content: "kale", Link: &notion.Link{URL: "https://example.com/kale"}},
+				},
+				text(" or "),
+				linked("https://example.com", "https://example.com"),
+			},
+		},
+		{
+			name:   "Links between angle brackets",
+			source: `[kale](<https://example.com/kale (raw)/\<1\>> "Kale") and [garlic](<https://example.com>)`,
+			want: []notion.RichText{
+				linked("kale", "https://example.com/kale (raw)/<1>"),
+				text(" and "),
+				linked("garlic", "https://example.com"),
+			},
+		},
+		{
+			name:   "Equations and prices",
+			source: "$3 and $4, $E = mc^2$",
+			want: []notion.RichText{
+				text("$3 and $4, "),
+				notion.RichTextEquation{
+					BaseRichText: notion.BaseRichText{Type: notion.RichTextTypeEquation},
+					Equation:     notion.EquationObject{Expression: "E = mc^2"},
+				},
+			},
+		},
+		{
+			name:   "Unclosed delimiters",
+			source: "**kale and *garlic",
+			want:   []notion.RichText{text("**kale and *garlic")},
+		},
+		{
+			name:   "Line break",
+			source: "kale<br>garlic",
+			want:   []notion.RichText{text("kale\ngarlic")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseInline(tt.source))
+		})
+	}
+}
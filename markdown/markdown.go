@@ -0,0 +1,329 @@
+// Package markdown converts Notion blocks and rich texts to and from CommonMark with GitHub Flavored Markdown
+// extensions.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// Blocks renders blocks as Markdown. The children set in the blocks, e.g. RichTextBlock.Children, are rendered too.
+func Blocks(blocks []notion.Block) string {
+	nodes := make([]*notion.BlockNode, 0, len(blocks))
+
+	for _, block := range blocks {
+		nodes = append(nodes, &notion.BlockNode{Block: block})
+	}
+
+	return Tree(nodes)
+}
+
+// Tree renders a tree of blocks, e.g. from Blocks().Tree, as Markdown.
+// The children of a node are taken from the block itself when the node has none.
+func Tree(nodes []*notion.BlockNode) string {
+	var r renderer
+
+	return r.render(nodes)
+}
+
+type renderer struct{}
+
+func (r *renderer) render(nodes []*notion.BlockNode) string {
+	var (
+		b        strings.Builder
+		previous notion.BlockType
+		number   int
+	)
+
+	for _, node := range nodes {
+		blockType := node.Block.GetBase().Type
+
+		if blockType == notion.BlockTypeNumberedListItem {
+			if previous != notion.BlockTypeNumberedListItem {
+				number = 0
+			}
+
+			number++
+		}
+
+		chunk := r.renderBlock(node, number)
+		if chunk == "" {
+			continue
+		}
+
+		if b.Len() > 0 {
+			if isListItem(previous) && previous == blockType {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+
+		b.WriteString(chunk)
+
+		previous = blockType
+	}
+
+	return b.String()
+}
+
+// nolint: cyclop, funlen
+func (r *renderer) renderBlock(node *notion.BlockNode, number int) string {
+	switch block := deref(node.Block).(type) {
+	case notion.ParagraphBlock:
+		// Indenting the children by 4 spaces would turn them into an indented code block.
+		return r.withChildren(escapeBlockStart(RichText(block.Paragraph.Text)), "", r.children(node, block.Paragraph.Children), false)
+
+	case notion.Heading1Block:
+		return "# " + RichText(block.Heading1.Text)
+
+	case notion.Heading2Block:
+		return "## " + RichText(block.Heading2.Text)
+
+	case notion.Heading3Block:
+		return "### " + RichText(block.Heading3.Text)
+
+	case notion.BulletedListItemBlock:
+		return r.withChildren("- "+RichText(block.BulletedListItem.Text), "  ", r.children(node, block.BulletedListItem.Children), true)
+
+	case notion.NumberedListItemBlock:
+		marker := fmt.Sprintf("%d. ", number)
+
+		return r.withChildren(marker+RichText(block.NumberedListItem.Text), strings.Repeat(" ", len(marker)),
+			r.children(node, block.NumberedListItem.Children), true)
+
+	case notion.ToDoBlock:
+		check := "[ ]"
+		if block.ToDo.Checked {
+			check = "[x]"
+		}
+
+		return r.withChildren("- "+check+" "+RichText(block.ToDo.Text), "  ", r.children(node, block.ToDo.Children), true)
+
+	case notion.ToggleBlock:
+		// Markdown is not rendered inside of a summary, so only its plain text is kept.
+		content := "<details>\n<summary>" + html.EscapeString(notion.PlainText(block.Toggle.Text)) + "</summary>"
+		if children := r.render(r.children(node, block.Toggle.Children)); children != "" {
+			content += "\n\n" + children
+		}
+
+		return content + "\n\n</details>"
+
+	case notion.QuoteBlock:
+		return quote(r.withChildren(escapeBlockStart(RichText(block.Quote.Text)), "", r.children(node, block.Quote.Children), false))
+
+	case notion.CalloutBlock:
+		text := escapeBlockStart(RichText(block.Callout.Text))
+		if block.Callout.Icon != nil && block.Callout.Icon.Emoji != "" {
+			text = block.Callout.Icon.Emoji + " " + text
+		}
+
+		return quote(r.withChildren(text, "", r.children(node, block.Callout.Children), false))
+
+	case notion.CodeBlock:
+		return codeBlock(notion.PlainText(block.Code.Text), block.Code.Language)
+
+	case notion.EquationBlock:
+		return "$$\n" + block.Equation.Expression + "\n$$"
+
+	case notion.DividerBlock:
+		return "---"
+
+	case notion.ImageBlock:
+		return "![" + RichText(block.Image.Caption) + "](" + fileURL(block.Image) + ")"
+
+	case notion.VideoBlock:
+		return link(block.Video.Caption, fileURL(block.Video))
+
+	case notion.FileBlock:
+		return link(block.File.Caption, fileURL(block.File))
+
+	case notion.PDFBlock:
+		return link(block.PDF.Caption, fileURL(block.PDF))
+
+	case notion.BookmarkBlock:
+		return link(block.Bookmark.Caption, block.Bookmark.URL)
+
+	case notion.EmbedBlock:
+		return link(block.Embed.Caption, block.Embed.URL)
+
+	case notion.LinkPreviewBlock:
+		return link(block.LinkPreview.Caption, block.LinkPreview.URL)
+
+	case notion.TableBlock:
+		return r.table(block.Table, r.children(node, block.Table.Children))
+
+	case notion.ChildPageBlock:
+		return "[" + escaper.Replace(block.ChildPage.Title) + "](" + notionURL(block.ID) + ")"
+
+	case notion.ChildDatabaseBlock:
+		return "[" + escaper.Replace(block.ChildDatabase.Title) + "](" + notionURL(block.ID) + ")"
+
+	case notion.LinkToPageBlock:
+		id := block.LinkToPage.PageID
+		if block.LinkToPage.Type == notion.LinkToPageTypeDatabase {
+			id = block.LinkToPage.DatabaseID
+		}
+
+		return "[" + notionURL(id) + "](" + notionURL(id) + ")"
+
+	case notion.ColumnListBlock:
+		return r.render(r.children(node, block.ColumnList.Children))
+
+	case notion.ColumnBlock:
+		return r.render(r.children(node, block.Column.Children))
+
+	case notion.SyncedBlock:
+		return r.render(r.children(node, block.SyncedBlock.Children))
+
+	case notion.TemplateBlock:
+		return r.render(r.children(node, block.Template.Children))
+	}
+
+	// Breadcrumbs, tables of contents and unsupported blocks have no Markdown equivalent.
+	return ""
+}
+
+// children returns the children of the node, or the children set in the block if the node has none.
+func (r *renderer) children(node *notion.BlockNode, inline []notion.Block) []*notion.BlockNode {
+	if len(node.Children) > 0 {
+		return node.Children
+	}
+
+	nodes := make([]*notion.BlockNode, 0, len(inline))
+
+	for _, block := range inline {
+		nodes = append(nodes, &notion.BlockNode{Block: block})
+	}
+
+	return nodes
+}
+
+// withChildren renders the children below the content, indented with the given prefix.
+// A nested list right below a list item is kept tight.
+func (r *renderer) withChildren(content, indent string, children []*notion.BlockNode, listItem bool) string {
+	rendered := r.render(children)
+	if rendered == "" {
+		return content
+	}
+
+	separator := "\n\n"
+	if listItem && isListItem(children[0].Block.GetBase().Type) {
+		separator = "\n"
+	}
+
+	return content + separator + indentLines(rendered, indent)
+}
+
+func (r *renderer) table(table notion.TableObject, rows []*notion.BlockNode) string {
+	var lines []string
+
+	cells := func(row []string) string {
+		return "| " + strings.Join(row, " | ") + " |"
+	}
+
+	header := make([]string, table.TableWidth)
+	separator := make([]string, table.TableWidth)
+
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	for i, node := range rows {
+		row, ok := deref(node.Block).(notion.TableRowBlock)
+		if !ok {
+			continue
+		}
+
+		rendered := make([]string, table.TableWidth)
+		for j := 0; j < len(row.TableRow.Cells) && j < table.TableWidth; j++ {
+			rendered[j] = strings.ReplaceAll(RichText(row.TableRow.Cells[j]), "\n", "<br>")
+		}
+
+		if i == 0 && table.HasColumnHeader {
+			header = rendered
+
+			continue
+		}
+
+		lines = append(lines, cells(rendered))
+	}
+
+	return strings.Join(append([]string{cells(header), cells(separator)}, lines...), "\n")
+}
+
+func isListItem(blockType notion.BlockType) bool {
+	switch blockType {
+	case notion.BlockTypeBulletedListItem, notion.BlockTypeNumberedListItem, notion.BlockTypeToDo:
+		return true
+	}
+
+	return false
+}
+
+func indentLines(s, indent string) string {
+	lines := strings.Split(s, "\n")
+
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func quote(s string) string {
+	lines := strings.Split(s, "\n")
+
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func codeBlock(code, language string) string {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	if language == "plain text" {
+		language = ""
+	}
+
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+func link(caption []notion.RichText, url string) string {
+	text := RichText(caption)
+	if text == "" {
+		return "<" + url + ">"
+	}
+
+	return "[" + text + "](" + linkDestination(url) + ")"
+}
+
+func fileURL(file notion.FileObject) string {
+	switch {
+	case file.External != nil:
+		return file.External.URL
+
+	case file.File != nil:
+		return file.File.URL
+	}
+
+	return ""
+}
+
+func notionURL(id string) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(id, "-", "")
+}
@@ -0,0 +1,173 @@
+package markdown
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		assert.NoError(t, ioutil.WriteFile(path, []byte(got), 0o600))
+	}
+
+	want, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), got)
+}
+
+func readJSON(t *testing.T, name string, v interface{}) {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, v))
+}
+
+func TestBlocks(t *testing.T) {
+	var blocks notion.BlocksChildrenListResponse
+
+	readJSON(t, "blocks.json", &blocks)
+
+	assertGolden(t, "blocks", Blocks(blocks.Results)+"\n")
+}
+
+func TestPage(t *testing.T) {
+	var (
+		page   notion.Page
+		blocks notion.BlocksChildrenListResponse
+	)
+
+	readJSON(t, "page.json", &page)
+	readJSON(t, "blocks.json", &blocks)
+
+	nodes := make([]*notion.BlockNode, 0, 2)
+	for _, block := range blocks.Results[:2] {
+		nodes = append(nodes, &notion.BlockNode{Block: block})
+	}
+
+	got, err := Page(page, nodes)
+	assert.NoError(t, err)
+
+	assertGolden(t, "page", got)
+}
+
+func TestTree(t *testing.T) {
+	paragraph := func(content string) notion.Block {
+		return notion.ParagraphBlock{
+			BlockBase: notion.BlockBase{Object: notion.ObjectTypeBlock, Type: notion.BlockTypeParagraph},
+			Paragraph: notion.RichTextBlock{
+				Text: []notion.RichText{notion.RichTextText{Text: notion.TextObject{Content: content}}},
+			},
+		}
+	}
+
+	item := notion.BulletedListItemBlock{
+		BlockBase: notion.BlockBase{Object: notion.ObjectTypeBlock, Type: notion.BlockTypeBulletedListItem},
+		BulletedListItem: notion.RichTextBlock{
+			Text: []notion.RichText{notion.RichTextText{Text: notion.TextObject{Content: "Kale"}}},
+		},
+	}
+
+	nodes := []*notion.BlockNode{
+		{Block: item, Children: []*notion.BlockNode{{Block: paragraph("Fetched from the tree.")}}},
+		{Block: paragraph("Done.")},
+	}
+
+	assert.Equal(t, "- Kale\n\n  Fetched from the tree.\n\nDone.", Tree(nodes))
+}
+
+func TestBlocks_EscapeBlockStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "Heading", content: "## not a heading", want: `\## not a heading`},
+		{name: "Indented list item", content: "  * not a list", want: `  \* not a list`},
+		{name: "Ordered list item", content: "10) not numbered", want: `10\) not numbered`},
+		{name: "Setext underline", content: "Kale\n---", want: "Kale\n\\---"},
+		{name: "Heading on the second line", content: "Kale\n# not a heading", want: "Kale\n\\# not a heading"},
+		{name: "Not a marker", content: "#kale -5 1.5 --x", want: "#kale -5 1.5 --x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Blocks([]notion.Block{notion.Paragraph(notion.Text(tt.content))}))
+		})
+	}
+}
+
+func TestRichText(t *testing.T) {
+	tests := []struct {
+		name      string
+		richTexts []notion.RichText
+		want      string
+	}{
+		{
+			name:      "Escape Markdown characters",
+			richTexts: []notion.RichText{notion.RichTextText{Text: notion.TextObject{Content: "2 * 3 = [6]"}}},
+			want:      `2 \* 3 = \[6\]`,
+		},
+		{
+			name: "Keep whitespaces outside of emphasis",
+			richTexts: []notion.RichText{
+				notion.RichTextText{Text: notion.TextObject{Content: "Lacinato "}, BaseRichText: notion.BaseRichText{
+					Annotations: &notion.Annotations{Bold: true, Italic: true},
+				}},
+				notion.RichTextText{Text: notion.TextObject{Content: "kale"}},
+			},
+			want: `_**Lacinato**_ kale`,
+		},
+		{
+			name: "Code with backticks",
+			richTexts: []notion.RichText{
+				notion.RichTextText{Text: notion.TextObject{Content: "a `b` c"}, BaseRichText: notion.BaseRichText{
+					Annotations: &notion.Annotations{Code: true},
+				}},
+			},
+			want: "``a `b` c``",
+		},
+		{
+			name: "Link",
+			richTexts: []notion.RichText{
+				notion.RichTextText{Text: notion.TextObject{Content: "kale", Link: &notion.Link{URL: "https://example.com"}}},
+			},
+			want: "[kale](https://example.com)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RichText(tt.richTexts))
+		})
+	}
+}
+
+func TestFrontMatter_Rollups(t *testing.T) {
+	var page notion.Page
+
+	readJSON(t, "../../testdata/page_rollups_and_mentions.json", &page)
+
+	delete(page.Properties, "Name")
+
+	got, err := FrontMatter(page.Properties)
+	assert.NoError(t, err)
+	assert.Equal(t, "---\n"+
+		"Next due: \"2021-05-24\"\n"+
+		"Owners: null\n"+
+		"Task names: [\"Buy kale\",3]\n"+
+		"Total budget: 1250.5\n"+
+		"---\n", got)
+}
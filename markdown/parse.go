@@ -0,0 +1,499 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var (
+	headingPattern      = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextPattern       = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	dividerPattern      = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fencePattern        = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`\\s]*)")
+	listItemPattern     = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])(?:([ \t]+)(.*))?$`)
+	taskPattern         = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+(.*))?$`)
+	quotePattern        = regexp.MustCompile(`^ {0,3}> ?`)
+	imagePattern        = regexp.MustCompile(`^ {0,3}!\[(.*)\]\(\s*<?([^\s>)]+)>?(?:\s+"[^"]*")?\s*\)[ \t]*$`)
+	tableDividerPattern = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	bookmarkPattern     = regexp.MustCompile(`^ {0,3}<(https?://[^\s<>]+)>[ \t]*$`)
+	summaryPattern      = regexp.MustCompile(`^\s*<summary>(.*)</summary>\s*$`)
+)
+
+// languages maps the common info strings of fenced code blocks to the languages supported by Notion.
+var languages = map[string]string{
+	"":          "plain text",
+	"text":      "plain text",
+	"txt":       "plain text",
+	"plaintext": "plain text",
+	"js":        "javascript",
+	"ts":        "typescript",
+	"py":        "python",
+	"rb":        "ruby",
+	"sh":        "shell",
+	"zsh":       "shell",
+	"yml":       "yaml",
+	"md":        "markdown",
+	"cpp":       "c++",
+	"cs":        "c#",
+	"csharp":    "c#",
+	"golang":    "go",
+	"rs":        "rust",
+	"kt":        "kotlin",
+}
+
+// Parse parses a Markdown document, CommonMark with the GitHub Flavored Markdown tables and task lists, into
+// blocks which can be used as the children of a new page or appended to a block. Nested list items, quotes
+// and toggles (<details>) keep their content as children, display equations ($$), standalone images and
+// standalone autolinks become equation, image and bookmark blocks, and the inline Markdown of every text is
+// parsed with ParseInline.
+func Parse(source string) []notion.Block {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+
+	return parseBlocks(strings.Split(source, "\n"))
+}
+
+func parseBlocks(lines []string) []notion.Block {
+	var blocks []notion.Block
+
+	for i := 0; i < len(lines); {
+		if isBlank(lines[i]) {
+			i++
+
+			continue
+		}
+
+		block, n := parseBlock(lines[i:])
+		if block != nil {
+			blocks = append(blocks, block)
+		}
+
+		i += n
+	}
+
+	return blocks
+}
+
+// parseBlock parses the block starting at the first line, and returns the number of lines it spans.
+// nolint: cyclop
+func parseBlock(lines []string) (notion.Block, int) {
+	line := lines[0]
+	trimmed := strings.TrimSpace(line)
+
+	switch {
+	case fencePattern.MatchString(line):
+		return parseCode(lines)
+
+	case strings.HasPrefix(trimmed, "$$"):
+		return parseEquation(lines)
+
+	case headingPattern.MatchString(line):
+		m := headingPattern.FindStringSubmatch(line)
+
+		return heading(len(m[1]), ParseInline(m[2])), 1
+
+	case dividerPattern.MatchString(line):
+		return notion.Divider(), 1
+
+	case quotePattern.MatchString(line):
+		return parseQuote(lines)
+
+	case listItemPattern.MatchString(line):
+		return parseListItem(lines)
+
+	case strings.HasPrefix(trimmed, "<details"):
+		return parseToggle(lines)
+
+	case imagePattern.MatchString(line):
+		m := imagePattern.FindStringSubmatch(line)
+
+		return notion.Image(m[2]).WithCaption(ParseInline(m[1])...), 1
+
+	case bookmarkPattern.MatchString(line):
+		return notion.Bookmark(bookmarkPattern.FindStringSubmatch(line)[1]), 1
+
+	case len(lines) > 1 && strings.Contains(line, "|") && strings.Contains(lines[1], "|") &&
+		tableDividerPattern.MatchString(lines[1]):
+		return parseTable(lines)
+	}
+
+	return parseParagraph(lines)
+}
+
+func parseParagraph(lines []string) (notion.Block, int) {
+	var text strings.Builder
+
+	n := 0
+
+	for ; n < len(lines); n++ {
+		line := lines[n]
+
+		if n > 0 {
+			if isBlank(line) || interruptsParagraph(line) {
+				break
+			}
+
+			if m := setextPattern.FindStringSubmatch(line); m != nil {
+				level := 1
+				if m[1][0] == '-' {
+					level = 2
+				}
+
+				return heading(level, ParseInline(text.String())), n + 1
+			}
+
+			text.WriteString(lineBreak(lines[n-1]))
+		}
+
+		text.WriteString(strings.TrimSuffix(strings.TrimSpace(line), "\\"))
+	}
+
+	return notion.Paragraph(ParseInline(text.String())...), n
+}
+
+// lineBreak returns the separator between a line of a paragraph and the next one: a new line for hard line
+// breaks (a line ending with two spaces or a backslash), a space otherwise.
+func lineBreak(line string) string {
+	if strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\") {
+		return "\n"
+	}
+
+	return " "
+}
+
+// interruptsParagraph reports whether the line starts a block even without a blank line before it.
+func interruptsParagraph(line string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return fencePattern.MatchString(line) ||
+		strings.HasPrefix(trimmed, "$$") ||
+		strings.HasPrefix(trimmed, "<details") ||
+		headingPattern.MatchString(line) ||
+		dividerPattern.MatchString(line) && !setextPattern.MatchString(line) ||
+		quotePattern.MatchString(line) ||
+		interruptsWithListItem(line)
+}
+
+// interruptsWithListItem reports whether the line is a list item which can interrupt a paragraph: it must not
+// be empty, and an ordered list must start with 1, so that a line like "2021. was a year" is not an item.
+func interruptsWithListItem(line string) bool {
+	m := listItemPattern.FindStringSubmatch(line)
+	if m == nil || isBlank(m[4]) {
+		return false
+	}
+
+	return isBullet(m[2]) || m[2][:len(m[2])-1] == "1"
+}
+
+func isBullet(marker string) bool {
+	return marker == "-" || marker == "*" || marker == "+"
+}
+
+func parseCode(lines []string) (notion.Block, int) {
+	m := fencePattern.FindStringSubmatch(lines[0])
+	indent := len(lines[0]) - len(strings.TrimLeft(lines[0], " "))
+	fence := m[1]
+
+	var code []string
+
+	n := 1
+
+	for ; n < len(lines); n++ {
+		if trimmed := strings.TrimSpace(lines[n]); strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			n++
+
+			break
+		}
+
+		code = append(code, trimLeftSpaces(lines[n], indent))
+	}
+
+	language := strings.ToLower(m[2])
+	if alias, ok := languages[language]; ok {
+		language = alias
+	}
+
+	return notion.Code(language, plainRichTexts(strings.Join(code, "\n"))...), n
+}
+
+func parseEquation(lines []string) (notion.Block, int) {
+	first := strings.TrimPrefix(strings.TrimSpace(lines[0]), "$$")
+
+	var (
+		expression []string
+		n          = 1
+	)
+
+	if strings.HasSuffix(first, "$$") {
+		expression = append(expression, strings.TrimSuffix(first, "$$"))
+	} else {
+		expression = append(expression, first)
+
+		for ; n < len(lines); n++ {
+			if trimmed := strings.TrimSpace(lines[n]); strings.HasSuffix(trimmed, "$$") {
+				expression = append(expression, strings.TrimSuffix(trimmed, "$$"))
+				n++
+
+				break
+			}
+
+			expression = append(expression, lines[n])
+		}
+	}
+
+	return notion.Equation(strings.TrimSpace(strings.Join(expression, "\n"))), n
+}
+
+func parseQuote(lines []string) (notion.Block, int) {
+	var content []string
+
+	n := 0
+
+	for ; n < len(lines); n++ {
+		line := lines[n]
+
+		switch {
+		case quotePattern.MatchString(line):
+			content = append(content, quotePattern.ReplaceAllString(line, ""))
+
+		// A lazy continuation line of the paragraph of the quote.
+		case n > 0 && !isBlank(line) && !isBlank(content[len(content)-1]) && !interruptsParagraph(line):
+			content = append(content, line)
+
+		default:
+			return quoteBlock(parseBlocks(content)), n
+		}
+	}
+
+	return quoteBlock(parseBlocks(content)), n
+}
+
+func quoteBlock(blocks []notion.Block) notion.Block {
+	text, children := splitText(blocks)
+
+	return notion.Quote(text...).WithChildren(children...)
+}
+
+// nolint: funlen
+func parseListItem(lines []string) (notion.Block, int) {
+	m := listItemPattern.FindStringSubmatch(lines[0])
+	indent := len(m[1])
+	offset := indent + len(m[2]) + len(m[3])
+
+	content := []string{m[4]}
+
+	n := 1
+
+	for ; n < len(lines); n++ {
+		line := lines[n]
+
+		if isBlank(line) {
+			// A blank line is part of the item only if the item goes on after it.
+			next := n + 1
+			for next < len(lines) && isBlank(lines[next]) {
+				next++
+			}
+
+			if next == len(lines) || indentation(lines[next]) <= indent {
+				break
+			}
+
+			content = append(content, "")
+
+			continue
+		}
+
+		if indentation(line) <= indent {
+			// A lazy continuation line of the paragraph of the item.
+			if isBlank(content[len(content)-1]) || interruptsParagraph(line) || listItemPattern.MatchString(line) {
+				break
+			}
+		}
+
+		content = append(content, trimLeftSpaces(line, offset))
+	}
+
+	if isBullet(m[2]) {
+		if task := taskPattern.FindStringSubmatch(m[4]); task != nil {
+			content[0] = task[2]
+			text, children := splitText(parseBlocks(content))
+
+			return notion.ToDo(task[1] != " ", text...).WithChildren(children...), n
+		}
+	}
+
+	text, children := splitText(parseBlocks(content))
+
+	if isBullet(m[2]) {
+		return notion.BulletedListItem(text...).WithChildren(children...), n
+	}
+
+	return notion.NumberedListItem(text...).WithChildren(children...), n
+}
+
+// parseToggle parses a <details> element, whose summary is the text of the toggle.
+func parseToggle(lines []string) (notion.Block, int) {
+	var (
+		summary string
+		content []string
+		depth   int
+		n       int
+	)
+
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		trimmed := strings.TrimSpace(line)
+
+		if n == 0 {
+			// The summary may be on the same line as the opening tag.
+			line = trimmed[strings.IndexByte(trimmed, '>')+1:]
+			trimmed = strings.TrimSpace(line)
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "<details"):
+			depth++
+
+		case trimmed == "</details>":
+			if depth == 0 {
+				n++
+
+				return toggleBlock(summary, content), n
+			}
+
+			depth--
+
+		case summary == "" && summaryPattern.MatchString(line):
+			summary = html.UnescapeString(summaryPattern.FindStringSubmatch(line)[1])
+
+			continue
+		}
+
+		content = append(content, line)
+	}
+
+	return toggleBlock(summary, content), n
+}
+
+func toggleBlock(summary string, content []string) notion.Block {
+	return notion.Toggle(plainRichTexts(summary)...).WithChildren(parseBlocks(content)...)
+}
+
+func parseTable(lines []string) (notion.Block, int) {
+	header := splitRow(lines[0])
+	rows := [][]string{header}
+
+	n := 2
+
+	for ; n < len(lines) && !isBlank(lines[n]) && strings.Contains(lines[n], "|"); n++ {
+		rows = append(rows, splitRow(lines[n]))
+	}
+
+	tableRows := make([]notion.TableRowBlock, 0, len(rows))
+
+	for _, row := range rows {
+		tableRow := notion.TableRow()
+
+		tableRow.TableRow.Cells = make([][]notion.RichText, len(header))
+		for i := 0; i < len(row) && i < len(header); i++ {
+			tableRow.TableRow.Cells[i] = ParseInline(row[i])
+		}
+
+		tableRows = append(tableRows, tableRow)
+	}
+
+	return notion.Table(tableRows...).WithColumnHeader(), n
+}
+
+// splitRow splits a row of a table into its cells, on the pipes which are not escaped.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	var (
+		cells []string
+		cell  strings.Builder
+	)
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// splitText returns the text of the first paragraph of the blocks, which is the text of the block containing
+// them, and the remaining blocks, which are its children.
+func splitText(blocks []notion.Block) ([]notion.RichText, []notion.Block) {
+	if len(blocks) == 0 {
+		return []notion.RichText{}, nil
+	}
+
+	paragraph, ok := blocks[0].(notion.ParagraphBlock)
+	if !ok {
+		return []notion.RichText{}, blocks
+	}
+
+	if len(blocks) == 1 {
+		return paragraph.Paragraph.Text, nil
+	}
+
+	return paragraph.Paragraph.Text, blocks[1:]
+}
+
+func heading(level int, text []notion.RichText) notion.Block {
+	switch level {
+	case 1:
+		return notion.Heading1(text...)
+
+	case 2: // nolint: gomnd
+		return notion.Heading2(text...)
+	}
+
+	// Notion has no heading deeper than the third level.
+	return notion.Heading3(text...)
+}
+
+// plainRichTexts returns the text as rich texts without annotations, split to respect the maximum length
+// of a rich text.
+func plainRichTexts(text string) []notion.RichText {
+	if text == "" {
+		return []notion.RichText{}
+	}
+
+	return segment{content: text}.richTexts()
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// trimLeftSpaces removes at most n leading spaces of the line.
+func trimLeftSpaces(line string, n int) string {
+	if i := indentation(line); i < n {
+		n = i
+	}
+
+	return line[n:]
+}
@@ -0,0 +1,185 @@
+package markdown
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func text(content string) notion.RichText {
+	return notion.RichTextText{
+		BaseRichText: notion.BaseRichText{Type: notion.RichTextTypeText},
+		Text:         notion.TextObject{Content: content},
+	}
+}
+
+func blockBase(blockType notion.BlockType) notion.BlockBase {
+	return notion.BlockBase{Object: notion.ObjectTypeBlock, Type: blockType}
+}
+
+func TestParse(t *testing.T) {
+	type args struct {
+		source string
+	}
+
+	type wants struct {
+		blocks []notion.Block
+	}
+
+	type test struct {
+		name  string
+		args  args
+		wants wants
+	}
+
+	tests := []test{
+		{
+			name: "Headings and paragraphs",
+			args: args{source: "# One\n\n#### Four\n\nLine one\nline two  \nline three\n\nSetext\n---"},
+			wants: wants{blocks: []notion.Block{
+				notion.Heading1Block{BlockBase: blockBase(notion.BlockTypeHeading1), Heading1: notion.HeadingBlock{
+					Text: []notion.RichText{text("One")},
+				}},
+				notion.Heading3Block{BlockBase: blockBase(notion.BlockTypeHeading3), Heading3: notion.HeadingBlock{
+					Text: []notion.RichText{text("Four")},
+				}},
+				notion.ParagraphBlock{BlockBase: blockBase(notion.BlockTypeParagraph), Paragraph: notion.RichTextBlock{
+					Text: []notion.RichText{text("Line one line two\nline three")},
+				}},
+				notion.Heading2Block{BlockBase: blockBase(notion.BlockTypeHeading2), Heading2: notion.HeadingBlock{
+					Text: []notion.RichText{text("Setext")},
+				}},
+			}},
+		},
+		{
+			name: "Nested lists",
+			args: args{source: "1. Wash\n   - [x] Rinse\n\n     Twice.\n2. Cook"},
+			wants: wants{blocks: []notion.Block{
+				notion.NumberedListItemBlock{BlockBase: blockBase(notion.BlockTypeNumberedListItem), NumberedListItem: notion.RichTextBlock{
+					Text: []notion.RichText{text("Wash")},
+					Children: []notion.Block{
+						notion.ToDoBlock{BlockBase: blockBase(notion.BlockTypeToDo), ToDo: notion.RichTextWithCheckBlock{
+							Text:    []notion.RichText{text("Rinse")},
+							Checked: true,
+							Children: []notion.Block{
+								notion.ParagraphBlock{BlockBase: blockBase(notion.BlockTypeParagraph), Paragraph: notion.RichTextBlock{
+									Text: []notion.RichText{text("Twice.")},
+								}},
+							},
+						}},
+					},
+				}},
+				notion.NumberedListItemBlock{BlockBase: blockBase(notion.BlockTypeNumberedListItem), NumberedListItem: notion.RichTextBlock{
+					Text: []notion.RichText{text("Cook")},
+				}},
+			}},
+		},
+		{
+			name: "Quote with a lazy continuation line",
+			args: args{source: "> Eat your\ngreens.\n\nDone."},
+			wants: wants{blocks: []notion.Block{
+				notion.QuoteBlock{BlockBase: blockBase(notion.BlockTypeQuote), Quote: notion.RichTextBlock{
+					Text: []notion.RichText{text("Eat your greens.")},
+				}},
+				notion.ParagraphBlock{BlockBase: blockBase(notion.BlockTypeParagraph), Paragraph: notion.RichTextBlock{
+					Text: []notion.RichText{text("Done.")},
+				}},
+			}},
+		},
+		{
+			name: "Code block with a language alias",
+			args: args{source: "~~~js\nconsole.log(1)\n~~~"},
+			wants: wants{blocks: []notion.Block{
+				notion.CodeBlock{BlockBase: blockBase(notion.BlockTypeCode), Code: notion.CodeObject{
+					Text:     []notion.RichText{text("console.log(1)")},
+					Language: "javascript",
+				}},
+			}},
+		},
+		{
+			name: "Unclosed code block",
+			args: args{source: "```\nkale"},
+			wants: wants{blocks: []notion.Block{
+				notion.CodeBlock{BlockBase: blockBase(notion.BlockTypeCode), Code: notion.CodeObject{
+					Text:     []notion.RichText{text("kale")},
+					Language: "plain text",
+				}},
+			}},
+		},
+		{
+			name: "Table without a trailing pipe",
+			args: args{source: "| Name | Price\n|:--|--:\n| Kale | 2.5 | extra"},
+			wants: wants{blocks: []notion.Block{
+				notion.TableBlock{BlockBase: blockBase(notion.BlockTypeTable), Table: notion.TableObject{
+					TableWidth:      2,
+					HasColumnHeader: true,
+					Children: []notion.Block{
+						notion.TableRowBlock{BlockBase: blockBase(notion.BlockTypeTableRow), TableRow: notion.TableRowObject{
+							Cells: [][]notion.RichText{{text("Name")}, {text("Price")}},
+						}},
+						notion.TableRowBlock{BlockBase: blockBase(notion.BlockTypeTableRow), TableRow: notion.TableRowObject{
+							Cells: [][]notion.RichText{{text("Kale")}, {text("2.5")}},
+						}},
+					},
+				}},
+			}},
+		},
+		{
+			name: "Toggle",
+			args: args{source: "<details><summary>Fish &amp; chips</summary>\n\n- Cod\n</details>"},
+			wants: wants{blocks: []notion.Block{
+				notion.ToggleBlock{BlockBase: blockBase(notion.BlockTypeToggle), Toggle: notion.RichTextBlock{
+					Text: []notion.RichText{text("Fish & chips")},
+					Children: []notion.Block{
+						notion.BulletedListItemBlock{BlockBase: blockBase(notion.BlockTypeBulletedListItem), BulletedListItem: notion.RichTextBlock{
+							Text: []notion.RichText{text("Cod")},
+						}},
+					},
+				}},
+			}},
+		},
+		{
+			name: "Ordered list not interrupting a paragraph",
+			args: args{source: "It was\n2021. A year"},
+			wants: wants{blocks: []notion.Block{
+				notion.ParagraphBlock{BlockBase: blockBase(notion.BlockTypeParagraph), Paragraph: notion.RichTextBlock{
+					Text: []notion.RichText{text("It was 2021. A year")},
+				}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wants.blocks, Parse(tt.args.source))
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	source, err := ioutil.ReadFile(filepath.Join("testdata", "blocks.golden"))
+	assert.NoError(t, err)
+
+	blocks := Parse(string(source))
+
+	assert.Equal(t, string(source), Blocks(blocks)+"\n")
+
+	// The blocks can be used as the children of a new page.
+	data, err := json.Marshal(notion.PagesCreateParameters{Children: blocks})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"bulleted_list_item":{"text":[{"type":"text","text":{"content":"Kale"}}],"children":[`)
+}
+
+func TestParseLongText(t *testing.T) {
+	blocks := Parse(strings.Repeat("a", 4500))
+
+	paragraph, ok := blocks[0].(notion.ParagraphBlock)
+	assert.True(t, ok)
+	assert.Len(t, paragraph.Paragraph.Text, 3)
+	assert.Equal(t, strings.Repeat("a", 4500), notion.PlainText(paragraph.Paragraph.Text))
+}
@@ -0,0 +1,163 @@
+package markdown
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`~`, `\~`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`|`, `\|`,
+	`$`, `\$`,
+)
+
+// destinationEscaper escapes the characters of a link destination between angle brackets.
+var destinationEscaper = strings.NewReplacer(`\`, `\\`, `<`, `\<`, `>`, `\>`)
+
+// blockStartPattern matches the lines starting like a heading, a list item, a thematic break or a setext heading
+// underline. Quotes, fences and the other block markers are escaped by the escaper.
+var blockStartPattern = regexp.MustCompile(`^ {0,3}(#{1,6}(?:[ \t]|$)|[-+](?:[ \t]|$)|[-=]+[ \t]*$|\d{1,9}[.)](?:[ \t]|$))`)
+
+// escapeBlockStart escapes the block markers at the start of the lines of rendered inline Markdown, so that e.g.
+// a paragraph "# not a heading" is not read as a heading.
+func escapeBlockStart(text string) string {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		match := blockStartPattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+
+		// The delimiter of an ordered list item is escaped, the first character of the other markers.
+		at := match[2]
+		if marker := line[match[2]:match[3]]; marker[0] >= '0' && marker[0] <= '9' {
+			at += strings.IndexAny(marker, ".)")
+		}
+
+		lines[i] = line[:at] + `\` + line[at:]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// RichText renders rich texts as inline Markdown, with their annotations and links.
+func RichText(richTexts []notion.RichText) string {
+	var b strings.Builder
+
+	for _, richText := range richTexts {
+		b.WriteString(renderRichText(richText))
+	}
+
+	return b.String()
+}
+
+func renderRichText(richText notion.RichText) string {
+	switch r := deref(richText).(type) {
+	case notion.RichTextText:
+		href := r.Href
+		if r.Text.Link != nil {
+			href = r.Text.Link.URL
+		}
+
+		return annotate(r.Text.Content, r.Annotations, href)
+
+	case notion.RichTextEquation:
+		return "$" + r.Equation.Expression + "$"
+
+	case notion.RichTextMention:
+		return annotate(r.PlainText, r.Annotations, r.Href)
+	}
+
+	return ""
+}
+
+// annotate renders a text with its annotations, keeping the surrounding whitespaces outside of the emphasis
+// delimiters as required by CommonMark.
+func annotate(content string, annotations *notion.Annotations, href string) string {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return content
+	}
+
+	start := strings.Index(content, trimmed)
+	leading, trailing := content[:start], content[start+len(trimmed):]
+
+	var text string
+
+	if annotations != nil && annotations.Code {
+		text = codeSpan(trimmed)
+	} else {
+		text = escaper.Replace(trimmed)
+	}
+
+	if annotations != nil {
+		if annotations.Bold {
+			text = "**" + text + "**"
+		}
+
+		if annotations.Italic {
+			text = "_" + text + "_"
+		}
+
+		if annotations.Strikethrough {
+			text = "~~" + text + "~~"
+		}
+
+		if annotations.Underline {
+			text = "<u>" + text + "</u>"
+		}
+	}
+
+	if href != "" {
+		text = "[" + text + "](" + linkDestination(href) + ")"
+	}
+
+	return leading + text + trailing
+}
+
+// linkDestination returns the URL as the destination of a link, between angle brackets when it has spaces,
+// angle brackets, parentheses or backslashes, which would end the destination or be read as escapes.
+func linkDestination(url string) string {
+	if !strings.ContainsAny(url, ` <>()\`) {
+		return url
+	}
+
+	return "<" + destinationEscaper.Replace(url) + ">"
+}
+
+// codeSpan wraps the text with enough backticks so that the backticks of the text are not delimiters.
+func codeSpan(text string) string {
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return fence + " " + text + " " + fence
+	}
+
+	return fence + text + fence
+}
+
+// deref returns the value pointed by v if v is a pointer, so that blocks, rich texts and property values
+// decoded from the API (pointers) and built locally (values) are handled the same way.
+func deref(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		return rv.Elem().Interface()
+	}
+
+	return v
+}
@@ -0,0 +1,122 @@
+package notionmock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var (
+	_ notion.BlocksInterface         = (*Blocks)(nil)
+	_ notion.BlocksChildrenInterface = (*BlocksChildren)(nil)
+)
+
+// Blocks is a mock of notion.BlocksInterface. Its children are mocked by ChildrenMock, which is returned by Children.
+type Blocks struct {
+	Mock
+
+	ChildrenMock *BlocksChildren
+	once         sync.Once
+
+	TreeFunc     func(ctx context.Context, params notion.BlocksTreeParameters) (*notion.BlocksTreeResponse, error)
+	RetrieveFunc func(ctx context.Context, params notion.BlocksRetrieveParameters) (*notion.BlocksRetrieveResponse, error)
+	UpdateFunc   func(ctx context.Context, params notion.BlocksUpdateParameters) (*notion.BlocksUpdateResponse, error)
+	DeleteFunc   func(ctx context.Context, params notion.BlocksDeleteParameters) (*notion.BlocksDeleteResponse, error)
+}
+
+// Children returns ChildrenMock, which is created when it is not set. The call is not recorded.
+func (m *Blocks) Children() notion.BlocksChildrenInterface {
+	return m.children()
+}
+
+func (m *Blocks) children() *BlocksChildren {
+	m.once.Do(func() {
+		if m.ChildrenMock == nil {
+			m.ChildrenMock = &BlocksChildren{}
+		}
+	})
+
+	return m.ChildrenMock
+}
+
+// Tree walks the children of ChildrenMock unless TreeFunc is set.
+func (m *Blocks) Tree(ctx context.Context, params notion.BlocksTreeParameters) (*notion.BlocksTreeResponse, error) {
+	m.record("Tree", params)
+
+	if m.TreeFunc != nil {
+		return m.TreeFunc(ctx, params)
+	}
+
+	return notion.FetchBlocksTree(ctx, m.children(), params)
+}
+
+func (m *Blocks) Retrieve(ctx context.Context, params notion.BlocksRetrieveParameters) (*notion.BlocksRetrieveResponse, error) {
+	m.record("Retrieve", params)
+
+	if m.RetrieveFunc == nil {
+		return nil, unexpected("Retrieve", params)
+	}
+
+	return m.RetrieveFunc(ctx, params)
+}
+
+func (m *Blocks) Update(ctx context.Context, params notion.BlocksUpdateParameters) (*notion.BlocksUpdateResponse, error) {
+	m.record("Update", params)
+
+	if m.UpdateFunc == nil {
+		return nil, unexpected("Update", params)
+	}
+
+	return m.UpdateFunc(ctx, params)
+}
+
+func (m *Blocks) Delete(ctx context.Context, params notion.BlocksDeleteParameters) (*notion.BlocksDeleteResponse, error) {
+	m.record("Delete", params)
+
+	if m.DeleteFunc == nil {
+		return nil, unexpected("Delete", params)
+	}
+
+	return m.DeleteFunc(ctx, params)
+}
+
+// BlocksChildren is a mock of notion.BlocksChildrenInterface.
+type BlocksChildren struct {
+	Mock
+
+	ListFunc    func(ctx context.Context, params notion.BlocksChildrenListParameters) (*notion.BlocksChildrenListResponse, error)
+	AppendFunc  func(ctx context.Context, params notion.BlocksChildrenAppendParameters) (*notion.BlocksChildrenAppendResponse, error)
+	ListAllFunc func(ctx context.Context, params notion.BlocksChildrenListParameters) *notion.BlocksIterator
+}
+
+func (m *BlocksChildren) List(ctx context.Context, params notion.BlocksChildrenListParameters) (*notion.BlocksChildrenListResponse, error) {
+	m.record("List", params)
+
+	if m.ListFunc == nil {
+		return nil, unexpected("List", params)
+	}
+
+	return m.ListFunc(ctx, params)
+}
+
+func (m *BlocksChildren) Append(ctx context.Context, params notion.BlocksChildrenAppendParameters) (*notion.BlocksChildrenAppendResponse, error) {
+	m.record("Append", params)
+
+	if m.AppendFunc == nil {
+		return nil, unexpected("Append", params)
+	}
+
+	return m.AppendFunc(ctx, params)
+}
+
+// ListAll pages through List unless ListAllFunc is set.
+func (m *BlocksChildren) ListAll(ctx context.Context, params notion.BlocksChildrenListParameters) *notion.BlocksIterator {
+	m.record("ListAll", params)
+
+	if m.ListAllFunc != nil {
+		return m.ListAllFunc(ctx, params)
+	}
+
+	return notion.NewBlocksIterator(ctx, m, params)
+}
@@ -0,0 +1,35 @@
+package notionmock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocks_Tree(t *testing.T) {
+	toggle := notion.Toggle(notion.Text("Steps"))
+	toggle.ID, toggle.HasChildren = "toggle", true
+
+	children := map[string][]notion.Block{
+		"page":   {notion.Paragraph(notion.Text("Intro")), toggle},
+		"toggle": {notion.Paragraph(notion.Text("Rinse"))},
+	}
+
+	var blocks Blocks
+
+	blocks.Children().(*BlocksChildren).ListFunc = func(ctx context.Context, params notion.BlocksChildrenListParameters) (*notion.BlocksChildrenListResponse, error) {
+		return &notion.BlocksChildrenListResponse{Results: children[params.BlockID]}, nil
+	}
+
+	tree, err := blocks.Tree(context.Background(), notion.BlocksTreeParameters{BlockID: "page"})
+	require.NoError(t, err)
+	require.Len(t, tree.Children, 2)
+	require.Len(t, tree.Children[1].Children, 1)
+	assert.Equal(t, children["toggle"][0], tree.Children[1].Children[0].Block)
+
+	assert.True(t, blocks.AssertCalled(t, "Tree", notion.BlocksTreeParameters{BlockID: "page"}))
+	assert.Len(t, blocks.ChildrenMock.Calls("List"), 2)
+}
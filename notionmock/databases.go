@@ -0,0 +1,94 @@
+package notionmock
+
+import (
+	"context"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var _ notion.DatabasesInterface = (*Databases)(nil)
+
+// Databases is a mock of notion.DatabasesInterface.
+type Databases struct {
+	Mock
+
+	CreateFunc   func(ctx context.Context, params notion.DatabasesCreateParameters) (*notion.DatabasesCreateResponse, error)
+	RetrieveFunc func(ctx context.Context, params notion.DatabasesRetrieveParameters) (*notion.DatabasesRetrieveResponse, error)
+	UpdateFunc   func(ctx context.Context, params notion.DatabasesUpdateParameters) (*notion.DatabasesUpdateResponse, error)
+	ListFunc     func(ctx context.Context, params notion.DatabasesListParameters) (*notion.DatabasesListResponse, error)
+	QueryFunc    func(ctx context.Context, params notion.DatabasesQueryParameters) (*notion.DatabasesQueryResponse, error)
+	ListAllFunc  func(ctx context.Context, params notion.DatabasesListParameters) *notion.DatabasesIterator
+	QueryAllFunc func(ctx context.Context, params notion.DatabasesQueryParameters) *notion.PagesIterator
+}
+
+func (m *Databases) Create(ctx context.Context, params notion.DatabasesCreateParameters) (*notion.DatabasesCreateResponse, error) {
+	m.record("Create", params)
+
+	if m.CreateFunc == nil {
+		return nil, unexpected("Create", params)
+	}
+
+	return m.CreateFunc(ctx, params)
+}
+
+func (m *Databases) Retrieve(ctx context.Context, params notion.DatabasesRetrieveParameters) (*notion.DatabasesRetrieveResponse, error) {
+	m.record("Retrieve", params)
+
+	if m.RetrieveFunc == nil {
+		return nil, unexpected("Retrieve", params)
+	}
+
+	return m.RetrieveFunc(ctx, params)
+}
+
+func (m *Databases) Update(ctx context.Context, params notion.DatabasesUpdateParameters) (*notion.DatabasesUpdateResponse, error) {
+	m.record("Update", params)
+
+	if m.UpdateFunc == nil {
+		return nil, unexpected("Update", params)
+	}
+
+	return m.UpdateFunc(ctx, params)
+}
+
+func (m *Databases) List(ctx context.Context, params notion.DatabasesListParameters) (*notion.DatabasesListResponse, error) {
+	m.record("List", params)
+
+	if m.ListFunc == nil {
+		return nil, unexpected("List", params)
+	}
+
+	return m.ListFunc(ctx, params)
+}
+
+func (m *Databases) Query(ctx context.Context, params notion.DatabasesQueryParameters) (*notion.DatabasesQueryResponse, error) {
+	m.record("Query", params)
+
+	if m.QueryFunc == nil {
+		return nil, unexpected("Query", params)
+	}
+
+	return m.QueryFunc(ctx, params)
+}
+
+// ListAll pages through List unless ListAllFunc is set.
+func (m *Databases) ListAll(ctx context.Context, params notion.DatabasesListParameters) *notion.DatabasesIterator {
+	m.record("ListAll", params)
+
+	if m.ListAllFunc != nil {
+		return m.ListAllFunc(ctx, params)
+	}
+
+	return notion.NewDatabasesIterator(ctx, m, params)
+}
+
+// QueryAll pages through Query unless QueryAllFunc is set.
+func (m *Databases) QueryAll(ctx context.Context, params notion.DatabasesQueryParameters) *notion.PagesIterator {
+	m.record("QueryAll", params)
+
+	if m.QueryAllFunc != nil {
+		return m.QueryAllFunc(ctx, params)
+	}
+
+	return notion.NewPagesIterator(ctx, m, params)
+}
@@ -0,0 +1,229 @@
+// Package notionmock provides programmable mocks of the clients of the notion package, which record their calls
+// and check expectations. Each method of a mock calls the function of the same name, e.g. Users.RetrieveFunc, and
+// returns ErrUnexpectedCall when it is not set:
+//
+//	var mock notionmock.API
+//
+//	mock.Users.RetrieveFunc = func(ctx context.Context, params notion.UsersRetrieveParameters) (*notion.UsersRetrieveResponse, error) {
+//		return &notion.UsersRetrieveResponse{User: &notion.PersonUser{}}, nil
+//	}
+//	mock.Users.Expect("Retrieve", notion.UsersRetrieveParameters{UserID: "ada"})
+//
+//	err := codeUnderTest(mock.Client())
+//
+//	mock.AssertExpectations(t)
+//
+// The methods iterating over every result, such as Users.ListAll, page through the mocked List, Query and Search
+// methods unless their own function is set, and Blocks.Tree walks the mocked children.
+package notionmock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// ErrUnexpectedCall is returned by the methods of the mocks whose function is not set.
+var ErrUnexpectedCall = errors.New("notionmock: unexpected call")
+
+// Anything matches any parameters in an expectation.
+const Anything = anything("notionmock.Anything")
+
+type anything string
+
+// TestingT is the part of *testing.T used to report unmet expectations.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Call is a recorded call to a method of a mock.
+type Call struct {
+	Method string
+	Params interface{}
+}
+
+func (c Call) String() string {
+	return fmt.Sprintf("%s(%+v)", c.Method, c.Params)
+}
+
+// Mock records the calls to the methods of a mock and checks them against expectations. It is embedded in every mock
+// and is safe for concurrent use.
+type Mock struct {
+	mu       sync.Mutex
+	calls    []Call
+	expected []Call
+}
+
+// Calls returns the calls made to the mock, in order, or only the calls to the given methods.
+func (m *Mock) Calls(methods ...string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []Call
+
+	for _, call := range m.calls {
+		if len(methods) == 0 || contains(methods, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Expect declares a call which must be made to the mock with the given parameters, or with any parameters when they
+// are Anything. A call expected several times must be made as many times.
+func (m *Mock) Expect(method string, params interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.expected = append(m.expected, Call{Method: method, Params: params})
+}
+
+// AssertExpectations reports the expected calls which were not made, and returns whether they all were.
+func (m *Mock) AssertExpectations(t TestingT) bool {
+	t.Helper()
+
+	missing := m.missing()
+
+	for _, call := range missing {
+		t.Errorf("notionmock: expected call %s was not made, calls made: %s", call, m.describeCalls(call.Method))
+	}
+
+	return len(missing) == 0
+}
+
+// AssertCalled reports whether a method was called with the given parameters, or with any parameters when they are
+// Anything.
+func (m *Mock) AssertCalled(t TestingT, method string, params interface{}) bool {
+	t.Helper()
+
+	for _, call := range m.Calls(method) {
+		if matches(params, call.Params) {
+			return true
+		}
+	}
+
+	t.Errorf("notionmock: %s was not called with %+v, calls made: %s", method, params, m.describeCalls(method))
+
+	return false
+}
+
+// AssertNotCalled reports whether a method was not called at all.
+func (m *Mock) AssertNotCalled(t TestingT, method string) bool {
+	t.Helper()
+
+	if calls := m.Calls(method); len(calls) > 0 {
+		t.Errorf("notionmock: %s was called %d times: %s", method, len(calls), m.describeCalls(method))
+
+		return false
+	}
+
+	return true
+}
+
+func (m *Mock) record(method string, params interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, Call{Method: method, Params: params})
+}
+
+// unexpected returns ErrUnexpectedCall with the details of a call to a method which is not mocked.
+func unexpected(method string, params interface{}) error {
+	return fmt.Errorf("%w: %s, set %sFunc to mock it", ErrUnexpectedCall, Call{Method: method, Params: params}, method)
+}
+
+// missing returns the expected calls which were not made, each call meeting at most one expectation.
+func (m *Mock) missing() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	used := make([]bool, len(m.calls))
+
+	var missing []Call
+
+	for _, expected := range m.expected {
+		found := false
+
+		for i, call := range m.calls {
+			if !used[i] && call.Method == expected.Method && matches(expected.Params, call.Params) {
+				used[i], found = true, true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, expected)
+		}
+	}
+
+	return missing
+}
+
+func (m *Mock) describeCalls(method string) string {
+	calls := m.Calls(method)
+	if len(calls) == 0 {
+		return "none"
+	}
+
+	descriptions := make([]string, 0, len(calls))
+	for _, call := range calls {
+		descriptions = append(descriptions, call.String())
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
+func matches(expected, actual interface{}) bool {
+	return expected == Anything || reflect.DeepEqual(expected, actual)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// API is a mock of every client of the API.
+type API struct {
+	Users     Users
+	Databases Databases
+	Pages     Pages
+	Blocks    Blocks
+	Search    Search
+}
+
+// Client returns an API using the mocks.
+func (a *API) Client() *notion.API {
+	return notion.NewWithClients(notion.Clients{
+		Users:     &a.Users,
+		Databases: &a.Databases,
+		Pages:     &a.Pages,
+		Blocks:    &a.Blocks,
+		Search:    &a.Search,
+	})
+}
+
+// AssertExpectations reports the expected calls which were not made to any of the mocks, and returns whether they
+// all were.
+func (a *API) AssertExpectations(t TestingT) bool {
+	t.Helper()
+
+	ok := true
+
+	for _, mock := range []*Mock{&a.Users.Mock, &a.Databases.Mock, &a.Pages.Mock, &a.Blocks.Mock, &a.Blocks.children().Mock, &a.Search.Mock} {
+		ok = mock.AssertExpectations(t) && ok
+	}
+
+	return ok
+}
@@ -0,0 +1,123 @@
+package notionmock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeT records the errors reported by the assertions.
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func person(name string) notion.User {
+	user := &notion.PersonUser{}
+	user.Name = name
+
+	return user
+}
+
+func TestAPI_Users(t *testing.T) {
+	var mock API
+
+	mock.Users.RetrieveFunc = func(ctx context.Context, params notion.UsersRetrieveParameters) (*notion.UsersRetrieveResponse, error) {
+		return &notion.UsersRetrieveResponse{User: person(params.UserID)}, nil
+	}
+	mock.Users.ListFunc = func(ctx context.Context, params notion.UsersListParameters) (*notion.UsersListResponse, error) {
+		if params.StartCursor == "" {
+			return &notion.UsersListResponse{
+				PaginatedList: notion.PaginatedList{HasMore: true, NextCursor: "next"},
+				Results:       []notion.User{person("Ada")},
+			}, nil
+		}
+
+		return &notion.UsersListResponse{Results: []notion.User{person("Grace")}}, nil
+	}
+
+	mock.Users.Expect("Retrieve", notion.UsersRetrieveParameters{UserID: "Ada"})
+	mock.Users.Expect("List", Anything)
+	mock.Users.Expect("List", Anything)
+
+	c := mock.Client()
+
+	resp, err := c.Users().Retrieve(context.Background(), notion.UsersRetrieveParameters{UserID: "Ada"})
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", resp.User.(*notion.PersonUser).Name)
+
+	users, err := c.Users().ListAll(context.Background(), notion.UsersListParameters{}).Collect(0)
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	assert.Equal(t, []Call{
+		{Method: "Retrieve", Params: notion.UsersRetrieveParameters{UserID: "Ada"}},
+		{Method: "ListAll", Params: notion.UsersListParameters{}},
+		{Method: "List", Params: notion.UsersListParameters{}},
+		{Method: "List", Params: notion.UsersListParameters{PaginationParameters: notion.PaginationParameters{StartCursor: "next"}}},
+	}, mock.Users.Calls())
+	assert.Len(t, mock.Users.Calls("List"), 2)
+
+	assert.True(t, mock.AssertExpectations(t))
+	assert.True(t, mock.Users.AssertCalled(t, "Retrieve", notion.UsersRetrieveParameters{UserID: "Ada"}))
+	assert.True(t, mock.Users.AssertNotCalled(t, "Search"))
+}
+
+func TestAPI_UnexpectedCall(t *testing.T) {
+	var mock API
+
+	c := mock.Client()
+
+	_, err := c.Pages().Create(context.Background(), notion.PagesCreateParameters{})
+	assert.ErrorIs(t, err, ErrUnexpectedCall)
+	assert.Contains(t, err.Error(), "CreateFunc")
+
+	_, err = c.Search(context.Background(), notion.SearchParameters{Query: "kale"})
+	assert.ErrorIs(t, err, ErrUnexpectedCall)
+
+	_, err = c.Databases().QueryAll(context.Background(), notion.DatabasesQueryParameters{DatabaseID: "d1"}).Collect(0)
+	assert.ErrorIs(t, err, ErrUnexpectedCall, "QueryAll pages through the unmocked Query")
+
+	_, err = c.Blocks().Children().Append(context.Background(), notion.BlocksChildrenAppendParameters{BlockID: "b1"})
+	assert.ErrorIs(t, err, ErrUnexpectedCall)
+
+	assert.Len(t, mock.Blocks.ChildrenMock.Calls("Append"), 1, "unexpected calls are recorded")
+}
+
+func TestMock_AssertExpectations(t *testing.T) {
+	var mock API
+
+	mock.Pages.RetrieveFunc = func(ctx context.Context, params notion.PagesRetrieveParameters) (*notion.PagesRetrieveResponse, error) {
+		return &notion.PagesRetrieveResponse{}, nil
+	}
+
+	mock.Pages.Expect("Retrieve", notion.PagesRetrieveParameters{PageID: "p1"})
+	mock.Pages.Expect("Retrieve", notion.PagesRetrieveParameters{PageID: "p1"})
+	mock.Blocks.Children().(*BlocksChildren).Expect("List", Anything)
+
+	_, err := mock.Client().Pages().Retrieve(context.Background(), notion.PagesRetrieveParameters{PageID: "p1"})
+	require.NoError(t, err)
+
+	var ft fakeT
+
+	assert.False(t, mock.AssertExpectations(&ft))
+	assert.Equal(t, []string{
+		"notionmock: expected call Retrieve({PageID:p1}) was not made, calls made: Retrieve({PageID:p1})",
+		"notionmock: expected call List(notionmock.Anything) was not made, calls made: none",
+	}, ft.errors)
+
+	ft = fakeT{}
+
+	assert.False(t, mock.Pages.AssertCalled(&ft, "Retrieve", notion.PagesRetrieveParameters{PageID: "p2"}))
+	assert.False(t, mock.Pages.AssertNotCalled(&ft, "Retrieve"))
+	assert.Len(t, ft.errors, 2)
+}
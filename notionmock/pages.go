@@ -0,0 +1,48 @@
+package notionmock
+
+import (
+	"context"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var _ notion.PagesInterface = (*Pages)(nil)
+
+// Pages is a mock of notion.PagesInterface.
+type Pages struct {
+	Mock
+
+	RetrieveFunc func(ctx context.Context, params notion.PagesRetrieveParameters) (*notion.PagesRetrieveResponse, error)
+	UpdateFunc   func(ctx context.Context, params notion.PagesUpdateParameters) (*notion.PagesUpdateResponse, error)
+	CreateFunc   func(ctx context.Context, params notion.PagesCreateParameters) (*notion.PagesCreateResponse, error)
+}
+
+func (m *Pages) Retrieve(ctx context.Context, params notion.PagesRetrieveParameters) (*notion.PagesRetrieveResponse, error) {
+	m.record("Retrieve", params)
+
+	if m.RetrieveFunc == nil {
+		return nil, unexpected("Retrieve", params)
+	}
+
+	return m.RetrieveFunc(ctx, params)
+}
+
+func (m *Pages) Update(ctx context.Context, params notion.PagesUpdateParameters) (*notion.PagesUpdateResponse, error) {
+	m.record("Update", params)
+
+	if m.UpdateFunc == nil {
+		return nil, unexpected("Update", params)
+	}
+
+	return m.UpdateFunc(ctx, params)
+}
+
+func (m *Pages) Create(ctx context.Context, params notion.PagesCreateParameters) (*notion.PagesCreateResponse, error) {
+	m.record("Create", params)
+
+	if m.CreateFunc == nil {
+		return nil, unexpected("Create", params)
+	}
+
+	return m.CreateFunc(ctx, params)
+}
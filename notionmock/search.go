@@ -0,0 +1,38 @@
+package notionmock
+
+import (
+	"context"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var _ notion.SearchInterface = (*Search)(nil)
+
+// Search is a mock of notion.SearchInterface.
+type Search struct {
+	Mock
+
+	SearchFunc    func(ctx context.Context, params notion.SearchParameters) (*notion.SearchResponse, error)
+	SearchAllFunc func(ctx context.Context, params notion.SearchParameters) *notion.SearchIterator
+}
+
+func (m *Search) Search(ctx context.Context, params notion.SearchParameters) (*notion.SearchResponse, error) {
+	m.record("Search", params)
+
+	if m.SearchFunc == nil {
+		return nil, unexpected("Search", params)
+	}
+
+	return m.SearchFunc(ctx, params)
+}
+
+// SearchAll pages through Search unless SearchAllFunc is set.
+func (m *Search) SearchAll(ctx context.Context, params notion.SearchParameters) *notion.SearchIterator {
+	m.record("SearchAll", params)
+
+	if m.SearchAllFunc != nil {
+		return m.SearchAllFunc(ctx, params)
+	}
+
+	return notion.NewSearchIterator(ctx, m, params)
+}
@@ -0,0 +1,49 @@
+package notionmock
+
+import (
+	"context"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var _ notion.UsersInterface = (*Users)(nil)
+
+// Users is a mock of notion.UsersInterface.
+type Users struct {
+	Mock
+
+	RetrieveFunc func(ctx context.Context, params notion.UsersRetrieveParameters) (*notion.UsersRetrieveResponse, error)
+	ListFunc     func(ctx context.Context, params notion.UsersListParameters) (*notion.UsersListResponse, error)
+	ListAllFunc  func(ctx context.Context, params notion.UsersListParameters) *notion.UsersIterator
+}
+
+func (m *Users) Retrieve(ctx context.Context, params notion.UsersRetrieveParameters) (*notion.UsersRetrieveResponse, error) {
+	m.record("Retrieve", params)
+
+	if m.RetrieveFunc == nil {
+		return nil, unexpected("Retrieve", params)
+	}
+
+	return m.RetrieveFunc(ctx, params)
+}
+
+func (m *Users) List(ctx context.Context, params notion.UsersListParameters) (*notion.UsersListResponse, error) {
+	m.record("List", params)
+
+	if m.ListFunc == nil {
+		return nil, unexpected("List", params)
+	}
+
+	return m.ListFunc(ctx, params)
+}
+
+// ListAll pages through List unless ListAllFunc is set.
+func (m *Users) ListAll(ctx context.Context, params notion.UsersListParameters) *notion.UsersIterator {
+	m.record("ListAll", params)
+
+	if m.ListAllFunc != nil {
+		return m.ListAllFunc(ctx, params)
+	}
+
+	return notion.NewUsersIterator(ctx, m, params)
+}
@@ -0,0 +1,190 @@
+package notiontest
+
+// blockFields are the fields of the blocks which are not the content of their type.
+var blockFields = []string{"object", "id", "created_time", "last_edited_time", "has_children", "archived"} // nolint:gochecknoglobals
+
+// insertBlocks stores blocks, with the children in their content, as the last children of a page or a block.
+func (s *Server) insertBlocks(parentID string, children []interface{}) ([]string, error) {
+	_, isPage := s.pages.get(parentID)
+	_, isBlock := s.blocks.get(parentID)
+
+	if !isPage && !isBlock {
+		return nil, notFound(parentID)
+	}
+
+	ids := make([]string, 0, len(children))
+	now := s.timestamp()
+
+	for i, raw := range children {
+		block := asObject(raw)
+
+		blockType := typeOf(block, blockFields...)
+		if blockType == "" {
+			return nil, validationErrorf("body failed validation: body.children[%d].type should be defined.", i)
+		}
+
+		content := clone(asObject(block[blockType]))
+		if content == nil {
+			content = object{}
+		}
+
+		nested := asArray(content["children"])
+		delete(content, "children")
+		fillPlainTexts(content["text"])
+
+		id := normalizeID(asString(block["id"]))
+		if id == "" {
+			id = s.newID()
+		}
+
+		s.blocks.add(object{
+			"object":           "block",
+			"id":               id,
+			"type":             blockType,
+			"created_time":     now,
+			"last_edited_time": now,
+			"archived":         false,
+			blockType:          content,
+		})
+		s.children[parentID] = append(s.children[parentID], id)
+		ids = append(ids, id)
+
+		if len(nested) > 0 {
+			if _, err := s.insertBlocks(id, nested); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+func (s *Server) retrieveBlock(req request) (interface{}, error) {
+	block, ok := s.blocks.get(req.id)
+	if !ok {
+		return nil, notFound(req.id)
+	}
+
+	return s.renderBlock(block), nil
+}
+
+func (s *Server) updateBlock(req request) (interface{}, error) {
+	block, ok := s.blocks.get(req.id)
+	if !ok {
+		return nil, notFound(req.id)
+	}
+
+	blockType := asString(block["type"])
+
+	for key, value := range req.body {
+		switch key {
+		case "archived":
+			archived, _ := value.(bool)
+			block["archived"] = archived
+		case "type":
+			if value != blockType {
+				return nil, validationErrorf("The type of the block cannot be changed from %s to %v.", blockType, value)
+			}
+		case blockType:
+			content := asObject(block[blockType])
+
+			for field, v := range asObject(value) {
+				if field != "children" {
+					content[field] = cloneValue(v)
+				}
+			}
+
+			fillPlainTexts(content["text"])
+		default:
+			return nil, validationErrorf("body failed validation: body.%s is not a field of a %s block.", key, blockType)
+		}
+	}
+
+	block["last_edited_time"] = s.timestamp()
+
+	return s.renderBlock(block), nil
+}
+
+func (s *Server) deleteBlock(req request) (interface{}, error) {
+	block, ok := s.blocks.get(req.id)
+	if !ok {
+		return nil, notFound(req.id)
+	}
+
+	block["archived"] = true
+	block["last_edited_time"] = s.timestamp()
+
+	return s.renderBlock(block), nil
+}
+
+func (s *Server) listChildren(req request) (interface{}, error) {
+	_, isPage := s.pages.get(req.id)
+	_, isBlock := s.blocks.get(req.id)
+
+	if !isPage && !isBlock {
+		return nil, notFound(req.id)
+	}
+
+	children := s.activeChildren(req.id)
+
+	results := make([]object, 0, len(children))
+	for _, child := range children {
+		results = append(results, s.renderBlock(child))
+	}
+
+	return paginate(results, req)
+}
+
+func (s *Server) appendChildren(req request) (interface{}, error) {
+	if _, err := s.insertBlocks(req.id, asArray(req.body["children"])); err != nil {
+		return nil, err
+	}
+
+	if page, ok := s.pages.get(req.id); ok {
+		page["last_edited_time"] = s.timestamp()
+
+		return s.childPageBlock(page), nil
+	}
+
+	block, _ := s.blocks.get(req.id)
+	block["last_edited_time"] = s.timestamp()
+
+	return s.renderBlock(block), nil
+}
+
+// activeChildren returns the children of a page or a block which are not archived.
+func (s *Server) activeChildren(id string) []object {
+	var children []object
+
+	for _, childID := range s.children[id] {
+		if child, ok := s.blocks.get(childID); ok && child["archived"] != true {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+// renderBlock returns a block as returned by the API.
+func (s *Server) renderBlock(block object) object {
+	rendered := clone(block)
+	rendered["has_children"] = len(s.activeChildren(asString(block["id"]))) > 0
+
+	return rendered
+}
+
+// childPageBlock returns a page as a child_page block, as returned when appending blocks to a page.
+func (s *Server) childPageBlock(page object) object {
+	id := asString(page["id"])
+
+	return object{
+		"object":           "block",
+		"id":               id,
+		"type":             "child_page",
+		"created_time":     page["created_time"],
+		"last_edited_time": page["last_edited_time"],
+		"has_children":     len(s.activeChildren(id)) > 0,
+		"archived":         page["archived"],
+		"child_page":       object{"title": pageTitle(s.renderPage(page))},
+	}
+}
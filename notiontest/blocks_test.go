@@ -0,0 +1,90 @@
+package notiontest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Blocks(t *testing.T) {
+	server, _, ids := newTasksServer(t)
+	c := server.Client()
+
+	appended, err := c.Blocks().Children().Append(context.Background(), notion.BlocksChildrenAppendParameters{
+		BlockID: ids[0],
+		Children: []notion.Block{
+			notion.Paragraph(notion.Text("Groceries")),
+			notion.ToDo(false, notion.Text("Kale")).WithChildren(
+				notion.Paragraph(notion.Text("Curly")),
+			),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, notion.BlockTypeChildPage, appended.Block.GetBase().Type)
+	assert.True(t, appended.Block.GetBase().HasChildren)
+
+	children, err := c.Blocks().Children().List(context.Background(), notion.BlocksChildrenListParameters{BlockID: ids[0]})
+	require.NoError(t, err)
+	require.Len(t, children.Results, 2)
+	assert.False(t, children.Results[0].GetBase().HasChildren)
+
+	todo := children.Results[1].(*notion.ToDoBlock)
+	assert.True(t, todo.HasChildren)
+	assert.Equal(t, "Kale", notion.PlainText(todo.ToDo.Text))
+
+	nested, err := c.Blocks().Children().List(context.Background(), notion.BlocksChildrenListParameters{BlockID: todo.ID})
+	require.NoError(t, err)
+	require.Len(t, nested.Results, 1)
+	assert.Equal(t, "Curly", notion.PlainText(nested.Results[0].(*notion.ParagraphBlock).Paragraph.Text))
+
+	updated, err := c.Blocks().Update(context.Background(), notion.BlocksUpdateParameters{
+		BlockID: todo.ID,
+		Block:   notion.ToDo(true, notion.Text("Kale")),
+	})
+	require.NoError(t, err)
+	assert.True(t, updated.Block.(*notion.ToDoBlock).ToDo.Checked)
+
+	retrieved, err := c.Blocks().Retrieve(context.Background(), notion.BlocksRetrieveParameters{BlockID: todo.ID})
+	require.NoError(t, err)
+	assert.Equal(t, updated.Block, retrieved.Block)
+
+	_, err = c.Blocks().Update(context.Background(), notion.BlocksUpdateParameters{
+		BlockID: todo.ID,
+		Block:   notion.Paragraph(notion.Text("Kale")),
+	})
+	assert.ErrorIs(t, err, notion.ErrValidationError)
+
+	deleted, err := c.Blocks().Delete(context.Background(), notion.BlocksDeleteParameters{BlockID: nested.Results[0].GetBase().ID})
+	require.NoError(t, err)
+	assert.True(t, deleted.Block.GetBase().Archived)
+
+	retrieved, err = c.Blocks().Retrieve(context.Background(), notion.BlocksRetrieveParameters{BlockID: todo.ID})
+	require.NoError(t, err)
+	assert.False(t, retrieved.Block.GetBase().HasChildren, "archived children are not listed")
+
+	_, err = c.Blocks().Retrieve(context.Background(), notion.BlocksRetrieveParameters{BlockID: "unknown"})
+	assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+}
+
+func TestServer_AddBlocks(t *testing.T) {
+	server, _, ids := newTasksServer(t)
+
+	blockIDs := server.AddBlocks(ids[1], notion.Toggle(notion.Text("Steps")).WithChildren(
+		notion.Paragraph(notion.Text("Rinse")),
+		notion.Paragraph(notion.Text("Chop")),
+	))
+	require.Len(t, blockIDs, 1)
+
+	blocks, err := server.Client().Blocks().Children().ListAll(context.Background(), notion.BlocksChildrenListParameters{
+		PaginationParameters: notion.PaginationParameters{PageSize: 1},
+		BlockID:              blockIDs[0],
+	}).Collect(0)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "Chop", notion.PlainText(blocks[1].(*notion.ParagraphBlock).Paragraph.Text))
+
+	assert.Panics(t, func() { server.AddBlocks("unknown", notion.Paragraph()) })
+}
@@ -0,0 +1,372 @@
+package notiontest
+
+import (
+	"time"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// propertyTypes are the types of the properties of the databases.
+var propertyTypes = map[string]bool{ // nolint:gochecknoglobals
+	"title": true, "rich_text": true, "number": true, "select": true, "multi_select": true, "date": true,
+	"people": true, "files": true, "checkbox": true, "url": true, "email": true, "phone_number": true,
+	"formula": true, "relation": true, "rollup": true, "created_time": true, "created_by": true,
+	"last_edited_time": true, "last_edited_by": true,
+}
+
+func (s *Server) listDatabases(req request) (interface{}, error) {
+	return paginate(s.databases.list(), req)
+}
+
+func (s *Server) retrieveDatabase(req request) (interface{}, error) {
+	database, ok := s.databases.get(req.id)
+	if !ok {
+		return nil, notFound(req.id)
+	}
+
+	return database, nil
+}
+
+func (s *Server) createDatabase(req request) (interface{}, error) {
+	parent := normalizeParent(asObject(req.body["parent"]))
+	if parent == nil || parent["type"] != "page_id" {
+		return nil, validationErrorf("body failed validation: body.parent.page_id should be defined.")
+	}
+
+	if _, ok := s.pages.get(normalizeID(asString(parent["page_id"]))); !ok {
+		return nil, notFound(asString(parent["page_id"]))
+	}
+
+	body := clone(req.body)
+	delete(body, "id")
+	delete(body, "created_time")
+	delete(body, "last_edited_time")
+
+	return s.insertDatabase(body)
+}
+
+// insertDatabase stores a database, keeping its IDs and timestamps when they are set.
+func (s *Server) insertDatabase(obj object) (object, error) {
+	now := s.timestamp()
+
+	database := object{
+		"object":           "database",
+		"id":               normalizeID(asString(obj["id"])),
+		"created_time":     orDefault(timestamp(obj["created_time"]), now),
+		"last_edited_time": orDefault(timestamp(obj["last_edited_time"]), now),
+		"title":            obj["title"],
+		"properties":       object{},
+	}
+
+	if database["id"] == "" {
+		database["id"] = s.newID()
+	}
+
+	if database["title"] == nil {
+		database["title"] = []interface{}{}
+	}
+
+	fillPlainTexts(database["title"])
+
+	if parent := normalizeParent(asObject(obj["parent"])); parent != nil {
+		database["parent"] = parent
+	}
+
+	properties := asObject(database["properties"])
+	titles := 0
+
+	for name, raw := range asObject(obj["properties"]) {
+		property, err := s.newProperty(name, asObject(raw), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if property["type"] == "title" {
+			titles++
+		}
+
+		properties[name] = property
+	}
+
+	if titles != 1 {
+		return nil, validationErrorf("A database must have exactly one title property.")
+	}
+
+	s.databases.add(database)
+	s.searchables = append(s.searchables, asString(database["id"]))
+
+	return database, nil
+}
+
+func (s *Server) updateDatabase(req request) (interface{}, error) { // nolint:cyclop
+	database, ok := s.databases.get(req.id)
+	if !ok {
+		return nil, notFound(req.id)
+	}
+
+	if title := req.body["title"]; title != nil {
+		title = cloneValue(title)
+		fillPlainTexts(title)
+		database["title"] = title
+	}
+
+	properties := asObject(database["properties"])
+
+	for key, raw := range asObject(req.body["properties"]) {
+		name, current := findProperty(properties, key)
+
+		if raw == nil {
+			if current == nil {
+				return nil, validationErrorf("Could not find property with name or id: %s", key)
+			}
+
+			delete(properties, name)
+
+			continue
+		}
+
+		update := asObject(raw)
+		if update == nil {
+			return nil, validationErrorf("body failed validation: body.properties.%s should be an object or null.", key)
+		}
+
+		newName := asString(update["name"])
+
+		if typeOf(update, "id", "name") == "" {
+			if current == nil {
+				return nil, validationErrorf("Could not find property with name or id: %s", key)
+			}
+		} else {
+			if current == nil {
+				name = key
+			}
+
+			property, err := s.newProperty(name, update, current)
+			if err != nil {
+				return nil, err
+			}
+
+			current = property
+		}
+
+		if newName == "" {
+			newName = name
+		}
+
+		if other, exists := properties[newName]; exists && asObject(other)["id"] != current["id"] {
+			return nil, validationErrorf("Property %s already exists.", newName)
+		}
+
+		delete(properties, name)
+		properties[newName] = current
+	}
+
+	database["last_edited_time"] = s.timestamp()
+
+	return database, nil
+}
+
+// newProperty returns a property of a database, keeping the ID of the current property and the IDs of its options.
+func (s *Server) newProperty(name string, property, current object) (object, error) {
+	propertyType := typeOf(property, "id", "name")
+	if !propertyTypes[propertyType] {
+		return nil, validationErrorf("Invalid property type for %s: %q.", name, propertyType)
+	}
+
+	if current != nil && current["type"] == "title" && propertyType != "title" {
+		return nil, validationErrorf("Cannot change the type of the title property %s.", name)
+	}
+
+	configuration := clone(asObject(property[propertyType]))
+	if configuration == nil {
+		configuration = object{}
+	}
+
+	id := asString(property["id"])
+
+	switch {
+	case propertyType == "title":
+		id = "title"
+	case current != nil:
+		id = asString(current["id"])
+	case id == "":
+		id = s.newPropertyID()
+	}
+
+	if propertyType == "select" || propertyType == "multi_select" {
+		var currentOptions []interface{}
+		if current != nil && current["type"] == propertyType {
+			currentOptions = asArray(asObject(current[propertyType])["options"])
+		}
+
+		for _, raw := range asArray(configuration["options"]) {
+			option := asObject(raw)
+
+			if existing := findOption(currentOptions, option); existing != nil && option["id"] == nil {
+				option["id"] = existing["id"]
+				option["color"] = existing["color"]
+			}
+
+			s.fillOption(option)
+		}
+	}
+
+	return object{"id": id, "type": propertyType, propertyType: configuration}, nil
+}
+
+func (s *Server) fillOption(option object) {
+	if asString(option["id"]) == "" {
+		option["id"] = s.newID()
+	}
+
+	if asString(option["color"]) == "" {
+		option["color"] = string(notion.ColorDefault)
+	}
+}
+
+// findProperty returns the name and the property with the given name or ID.
+func findProperty(properties object, key string) (string, object) {
+	if property := asObject(properties[key]); property != nil {
+		return key, property
+	}
+
+	for name, property := range properties {
+		if asObject(property)["id"] == key {
+			return name, asObject(property)
+		}
+	}
+
+	return "", nil
+}
+
+// findOption returns the option with the ID or the name of the given option.
+func findOption(options []interface{}, option object) object {
+	for _, raw := range options {
+		o := asObject(raw)
+
+		if id := asString(option["id"]); id != "" && o["id"] == id {
+			return o
+		}
+
+		if name := asString(option["name"]); name != "" && o["name"] == name {
+			return o
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) queryDatabase(req request) (interface{}, error) {
+	obj, ok := s.databases.get(req.id)
+	if !ok {
+		return nil, notFound(req.id)
+	}
+
+	var database notion.Database
+
+	if err := decode(obj, &database); err != nil {
+		return nil, err
+	}
+
+	params := notion.DatabasesQueryParameters{DatabaseID: req.id}
+
+	if raw := req.body["filter"]; raw != nil {
+		filter, err := decodeFilter(raw)
+		if err != nil {
+			return nil, validationErrorf("body failed validation: body.filter: %v", err)
+		}
+
+		params.Filter = filter
+	}
+
+	if raw := req.body["sorts"]; raw != nil {
+		if err := decode(raw, &params.Sorts); err != nil {
+			return nil, validationErrorf("body failed validation: body.sorts: %v", err)
+		}
+	}
+
+	if err := notion.ValidateQuery(&database, params); err != nil {
+		return nil, validationErrorf("%v", err)
+	}
+
+	rendered := make(map[string]object)
+	pages := make([]notion.Page, 0)
+
+	for _, page := range s.pages.list() {
+		if asObject(page["parent"])["database_id"] != req.id || page["archived"] == true {
+			continue
+		}
+
+		r := s.renderPage(page)
+
+		var p notion.Page
+
+		if err := decode(r, &p); err != nil {
+			return nil, err
+		}
+
+		rendered[p.ID] = r
+		pages = append(pages, p)
+	}
+
+	pages, err := s.evaluateQuery(pages, params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]object, 0, len(pages))
+	for _, page := range pages {
+		results = append(results, rendered[page.ID])
+	}
+
+	return paginate(results, req)
+}
+
+func (s *Server) evaluateQuery(pages []notion.Page, params notion.DatabasesQueryParameters) ([]notion.Page, error) {
+	if params.Filter != nil {
+		filtered, err := notion.FilterPages(pages, params.Filter, s.now())
+		if err != nil {
+			return nil, validationErrorf("%v", err)
+		}
+
+		pages = filtered
+	}
+
+	if err := notion.SortPages(pages, params.Sorts); err != nil {
+		return nil, validationErrorf("%v", err)
+	}
+
+	return pages, nil
+}
+
+// normalizeParent returns a parent with its type, e.g. {"type": "page_id", "page_id": "..."}, or nil.
+func normalizeParent(parent object) object {
+	parentType := typeOf(parent)
+
+	switch parentType {
+	case "database_id", "page_id":
+		return object{"type": parentType, parentType: normalizeID(asString(parent[parentType]))}
+	case "workspace":
+		return object{"type": parentType, parentType: true}
+	}
+
+	return nil
+}
+
+// timestamp returns a time of an object, or an empty string when the time is not set.
+func timestamp(v interface{}) string {
+	t, err := time.Parse(time.RFC3339, asString(v))
+	if err != nil || t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(timeLayout)
+}
+
+func orDefault(s, defaultValue string) string {
+	if s == "" {
+		return defaultValue
+	}
+
+	return s
+}
@@ -0,0 +1,219 @@
+package notiontest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func titles(t *testing.T, pages []notion.Page) []string {
+	t.Helper()
+
+	names := make([]string, 0, len(pages))
+
+	for _, page := range pages {
+		value, ok := page.Properties["Name"].(*notion.TitlePropertyValue)
+		require.True(t, ok, "the Name of %s is a %T", page.ID, page.Properties["Name"])
+
+		names = append(names, notion.PlainText(value.Title))
+	}
+
+	return names
+}
+
+func TestServer_RetrieveDatabase(t *testing.T) {
+	server, databaseID, _ := newTasksServer(t)
+
+	database, err := server.Client().Databases().Retrieve(context.Background(), notion.DatabasesRetrieveParameters{DatabaseID: databaseID})
+	require.NoError(t, err)
+
+	assert.Equal(t, databaseID, database.ID)
+	assert.Equal(t, "Tasks", notion.PlainText(database.Title))
+	assert.Equal(t, now, database.CreatedTime)
+	require.Len(t, database.Properties, 4)
+	assert.Equal(t, "title", database.Properties["Name"].(*notion.TitleProperty).ID)
+
+	status := database.Properties["Status"].(*notion.SelectProperty)
+	require.Len(t, status.Select.Options, 2)
+	assert.Equal(t, "Todo", status.Select.Options[0].Name)
+	assert.Equal(t, notion.ColorRed, status.Select.Options[0].Color)
+	assert.NotEmpty(t, status.Select.Options[0].ID)
+
+	list, err := server.Client().Databases().List(context.Background(), notion.DatabasesListParameters{})
+	require.NoError(t, err)
+	require.Len(t, list.Results, 1)
+	assert.Equal(t, databaseID, list.Results[0].ID)
+
+	_, err = server.Client().Databases().Retrieve(context.Background(), notion.DatabasesRetrieveParameters{DatabaseID: "unknown"})
+	assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+}
+
+func TestServer_CreateDatabase(t *testing.T) {
+	server, _, ids := newTasksServer(t)
+	c := server.Client()
+
+	database, err := c.Databases().Create(context.Background(), notion.DatabasesCreateParameters{
+		Parent: notion.PageParentInput{PageID: ids[0]},
+		Title:  []notion.RichText{notion.Text("Groceries")},
+		Properties: map[string]notion.Property{
+			"Item":     notion.TitleProperty{},
+			"Quantity": notion.NumberProperty{Number: notion.NumberPropertyOption{Format: notion.NumberFormatNumber}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Groceries", notion.PlainText(database.Title))
+	assert.Equal(t, notion.NumberFormatNumber, database.Properties["Quantity"].(*notion.NumberProperty).Number.Format)
+
+	retrieved, err := c.Databases().Retrieve(context.Background(), notion.DatabasesRetrieveParameters{DatabaseID: database.ID})
+	require.NoError(t, err)
+	assert.Equal(t, database.Database, retrieved.Database)
+
+	_, err = c.Databases().Create(context.Background(), notion.DatabasesCreateParameters{
+		Parent:     notion.PageParentInput{PageID: "unknown"},
+		Title:      []notion.RichText{notion.Text("Groceries")},
+		Properties: map[string]notion.Property{"Item": notion.TitleProperty{}},
+	})
+	assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+
+	_, err = c.Databases().Create(context.Background(), notion.DatabasesCreateParameters{
+		Parent:     notion.PageParentInput{PageID: ids[0]},
+		Properties: map[string]notion.Property{"Quantity": notion.NumberProperty{}},
+	})
+	assert.ErrorIs(t, err, notion.ErrValidationError)
+}
+
+func TestServer_UpdateDatabase(t *testing.T) {
+	server, databaseID, ids := newTasksServer(t)
+	c := server.Client()
+
+	database, err := c.Databases().Update(context.Background(), notion.DatabasesUpdateParameters{
+		DatabaseID: databaseID,
+		Title:      []notion.RichText{notion.Text("Chores")},
+		Properties: map[string]notion.Property{
+			"Price": notion.RenameProperty("Cost"),
+			"Done":  nil,
+			"Status": notion.SelectProperty{Select: notion.SelectPropertyOption{Options: []notion.SelectOption{
+				{Name: "Todo"},
+				{Name: "Doing", Color: notion.ColorYellow},
+			}}},
+			"Notes": notion.RichTextProperty{},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Chores", notion.PlainText(database.Title))
+	assert.NotContains(t, database.Properties, "Price")
+	assert.NotContains(t, database.Properties, "Done")
+	assert.IsType(t, &notion.NumberProperty{}, database.Properties["Cost"])
+	assert.IsType(t, &notion.RichTextProperty{}, database.Properties["Notes"])
+
+	options := database.Properties["Status"].(*notion.SelectProperty).Select.Options
+	require.Len(t, options, 2)
+	assert.Equal(t, notion.ColorRed, options[0].Color, "the color of an existing option is kept")
+	assert.Equal(t, "Doing", options[1].Name)
+
+	page, err := c.Pages().Retrieve(context.Background(), notion.PagesRetrieveParameters{PageID: ids[2]})
+	require.NoError(t, err)
+	assert.Equal(t, float64(12), page.Properties["Cost"].(*notion.NumberPropertyValue).Number, "values follow the renamed property")
+	assert.Empty(t, page.Properties["Notes"].(*notion.RichTextPropertyValue).RichText, "new properties are empty")
+
+	_, err = c.Databases().Update(context.Background(), notion.DatabasesUpdateParameters{
+		DatabaseID: databaseID,
+		Properties: map[string]notion.Property{"Cost": notion.RenameProperty("Status")},
+	})
+	assert.ErrorIs(t, err, notion.ErrValidationError)
+}
+
+func TestServer_QueryDatabase(t *testing.T) {
+	server, databaseID, _ := newTasksServer(t)
+	c := server.Client()
+
+	todo, price := "Todo", float64(10)
+
+	tests := []struct {
+		name   string
+		params notion.DatabasesQueryParameters
+		want   []string
+	}{
+		{
+			name: "Query all pages",
+			want: []string{"Buy kale", "Cook kale", "Eat kale"},
+		},
+		{
+			name: "Filter by select",
+			params: notion.DatabasesQueryParameters{
+				Filter: notion.SingleSelectFilter{
+					SinglePropertyFilter: notion.SinglePropertyFilter{Property: "Status"},
+					Select:               notion.SelectFilter{Equals: &todo},
+				},
+			},
+			want: []string{"Cook kale", "Eat kale"},
+		},
+		{
+			name: "Filter by compound filter",
+			params: notion.DatabasesQueryParameters{
+				Filter: notion.CompoundFilter{Or: []notion.Filter{
+					notion.SingleNumberFilter{
+						SinglePropertyFilter: notion.SinglePropertyFilter{Property: "Price"},
+						Number:               notion.NumberFilter{GreaterThan: &price},
+					},
+					notion.SingleTextFilter{
+						SinglePropertyFilter: notion.SinglePropertyFilter{Property: "Name"},
+						Text:                 &notion.TextFilter{StartsWith: stringPtr("Buy")},
+					},
+				}},
+			},
+			want: []string{"Buy kale", "Cook kale"},
+		},
+		{
+			name: "Sort by number",
+			params: notion.DatabasesQueryParameters{
+				Sorts: []notion.Sort{{Property: "Price", Direction: notion.SortDirectionDescending}},
+			},
+			want: []string{"Cook kale", "Eat kale", "Buy kale"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.DatabaseID = databaseID
+
+			resp, err := c.Databases().Query(context.Background(), tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, titles(t, resp.Results))
+		})
+	}
+}
+
+func TestServer_QueryDatabasePagination(t *testing.T) {
+	server, databaseID, _ := newTasksServer(t)
+	c := server.Client()
+
+	params := notion.DatabasesQueryParameters{
+		PaginationParameters: notion.PaginationParameters{PageSize: 2},
+		DatabaseID:           databaseID,
+		Sorts:                []notion.Sort{{Property: "Name", Direction: notion.SortDirectionDescending}},
+	}
+
+	first, err := c.Databases().Query(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Eat kale", "Cook kale"}, titles(t, first.Results))
+	assert.True(t, first.HasMore)
+
+	pages, err := c.Databases().QueryAll(context.Background(), params).Collect(0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Eat kale", "Cook kale", "Buy kale"}, titles(t, pages))
+
+	_, err = c.Databases().Query(context.Background(), notion.DatabasesQueryParameters{
+		DatabaseID: databaseID,
+		Sorts:      []notion.Sort{{Property: "Unknown"}},
+	})
+	assert.ErrorIs(t, err, notion.ErrValidationError)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
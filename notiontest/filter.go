@@ -0,0 +1,80 @@
+package notiontest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mkfsn/notion-go"
+)
+
+var errUnknownFilter = errors.New("unknown filter")
+
+// newFilters return the filters of the client by the key of their condition, e.g. the number filter for
+// {"property": "Price", "number": {"equals": 1}}.
+var newFilters = map[string]func() notion.Filter{ // nolint:gochecknoglobals
+	"text":             func() notion.Filter { return &notion.SingleTextFilter{} },
+	"title":            func() notion.Filter { return &notion.SingleTextFilter{} },
+	"rich_text":        func() notion.Filter { return &notion.SingleTextFilter{} },
+	"url":              func() notion.Filter { return &notion.SingleTextFilter{} },
+	"email":            func() notion.Filter { return &notion.SingleTextFilter{} },
+	"phone":            func() notion.Filter { return &notion.SingleTextFilter{} },
+	"number":           func() notion.Filter { return &notion.SingleNumberFilter{} },
+	"checkbox":         func() notion.Filter { return &notion.SingleCheckboxFilter{} },
+	"select":           func() notion.Filter { return &notion.SingleSelectFilter{} },
+	"multi_select":     func() notion.Filter { return &notion.SingleMultiSelectFilter{} },
+	"date":             func() notion.Filter { return &notion.SingleDateFilter{} },
+	"created_time":     func() notion.Filter { return &notion.SingleDateFilter{} },
+	"last_edited_time": func() notion.Filter { return &notion.SingleDateFilter{} },
+	"people":           func() notion.Filter { return &notion.SinglePeopleFilter{} },
+	"created_by":       func() notion.Filter { return &notion.SinglePeopleFilter{} },
+	"last_edited_by":   func() notion.Filter { return &notion.SinglePeopleFilter{} },
+	"files":            func() notion.Filter { return &notion.SingleFilesFilter{} },
+	"relation":         func() notion.Filter { return &notion.SingleRelationFilter{} },
+	"formula":          func() notion.Filter { return &notion.SingleFormulaFilter{} },
+}
+
+// decodeFilter decodes the filter of a query into a filter of the client, to evaluate it with notion.FilterPages.
+func decodeFilter(raw interface{}) (notion.Filter, error) {
+	obj := asObject(raw)
+	if obj == nil {
+		return nil, fmt.Errorf("%w: %v", errUnknownFilter, raw)
+	}
+
+	if obj["and"] != nil || obj["or"] != nil {
+		var compound notion.CompoundFilter
+
+		for key, filters := range map[string]*[]notion.Filter{"and": &compound.And, "or": &compound.Or} {
+			for _, f := range asArray(obj[key]) {
+				filter, err := decodeFilter(f)
+				if err != nil {
+					return nil, err
+				}
+
+				*filters = append(*filters, filter)
+			}
+		}
+
+		return compound, nil
+	}
+
+	for key, newFilter := range newFilters {
+		if obj[key] == nil {
+			continue
+		}
+
+		filter := newFilter()
+
+		if key == "title" {
+			obj = clone(obj)
+			obj["text"], obj["title"] = obj["title"], nil
+		}
+
+		if err := decode(obj, filter); err != nil {
+			return nil, err
+		}
+
+		return filter, nil
+	}
+
+	return nil, fmt.Errorf("%w: no condition on property %v", errUnknownFilter, obj["property"])
+}
@@ -0,0 +1,185 @@
+package notiontest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// object is a JSON object of the API, the objects are stored as returned by the API.
+type object = map[string]interface{}
+
+// collection stores objects by ID, in the order of their creation.
+type collection struct {
+	ids     []string
+	objects map[string]object
+}
+
+func newCollection() collection {
+	return collection{objects: make(map[string]object)}
+}
+
+func (c *collection) add(obj object) {
+	id := asString(obj["id"])
+
+	if _, ok := c.objects[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+
+	c.objects[id] = obj
+}
+
+func (c *collection) get(id string) (object, bool) {
+	obj, ok := c.objects[id]
+
+	return obj, ok
+}
+
+// list returns the objects in the order of their creation.
+func (c *collection) list() []object {
+	objects := make([]object, 0, len(c.ids))
+	for _, id := range c.ids {
+		objects = append(objects, c.objects[id])
+	}
+
+	return objects
+}
+
+// mustObject encodes a value of the client as an object.
+func mustObject(v interface{}) object {
+	obj, err := toObject(v)
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: %v", err))
+	}
+
+	return obj
+}
+
+func toObject(v interface{}) (object, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %w", v, err)
+	}
+
+	var obj object
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %T: %w", v, err)
+	}
+
+	return obj, nil
+}
+
+// decode decodes an object into a value of the client, e.g. a notion.Page.
+func decode(obj interface{}, v interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to unmarshal %T: %w", v, err)
+	}
+
+	return nil
+}
+
+func asObject(v interface{}) object {
+	obj, _ := v.(object)
+
+	return obj
+}
+
+func asArray(v interface{}) []interface{} {
+	array, _ := v.([]interface{})
+
+	return array
+}
+
+func asString(v interface{}) string {
+	s, _ := v.(string)
+
+	return s
+}
+
+// clone returns a deep copy of an object, so that the stored objects are not changed by the requests.
+func clone(obj object) object {
+	cloned, _ := cloneValue(obj).(object)
+
+	return cloned
+}
+
+func cloneValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case object:
+		cloned := make(object, len(v))
+		for key, value := range v {
+			cloned[key] = cloneValue(value)
+		}
+
+		return cloned
+	case []interface{}:
+		cloned := make([]interface{}, 0, len(v))
+		for _, value := range v {
+			cloned = append(cloned, cloneValue(value))
+		}
+
+		return cloned
+	}
+
+	return v
+}
+
+// typeOf returns the type of an object with a type, e.g. a block or a property, or the only key which is not one of
+// the given keys when the type is not set.
+func typeOf(obj object, keys ...string) string {
+	if t := asString(obj["type"]); t != "" {
+		return t
+	}
+
+	var found string
+
+	for key := range obj {
+		if key == "type" || contains(keys, key) {
+			continue
+		}
+
+		if found != "" {
+			return ""
+		}
+
+		found = key
+	}
+
+	return found
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fillPlainTexts sets the plain text of the rich texts without one, from their content.
+func fillPlainTexts(richTexts interface{}) {
+	for _, richText := range asArray(richTexts) {
+		r := asObject(richText)
+		if r == nil || asString(r["plain_text"]) != "" {
+			continue
+		}
+
+		if r["type"] == nil {
+			r["type"] = typeOf(r, "plain_text", "href", "annotations")
+		}
+
+		switch r["type"] {
+		case "text":
+			r["plain_text"] = asString(asObject(r["text"])["content"])
+		case "equation":
+			r["plain_text"] = asString(asObject(r["equation"])["expression"])
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package notiontest
+
+import (
+	"strings"
+)
+
+// readOnlyPropertyTypes are the types of the properties whose values are computed by Notion.
+var readOnlyPropertyTypes = map[string]bool{ // nolint:gochecknoglobals
+	"formula": true, "rollup": true, "created_time": true, "created_by": true, "last_edited_time": true,
+	"last_edited_by": true,
+}
+
+// titleSchema is the schema of the pages which are not in a database, with only a title.
+var titleSchema = object{"title": object{"id": "title", "type": "title"}} // nolint:gochecknoglobals
+
+func (s *Server) createPage(req request) (interface{}, error) {
+	parent := normalizeParent(asObject(req.body["parent"]))
+	if parent == nil || parent["type"] == "workspace" {
+		return nil, validationErrorf("body failed validation: body.parent.database_id or body.parent.page_id should be defined.")
+	}
+
+	switch id := asString(parent[asString(parent["type"])]); parent["type"] {
+	case "database_id":
+		if _, ok := s.databases.get(id); !ok {
+			return nil, notFound(id)
+		}
+	case "page_id":
+		if _, ok := s.pages.get(id); !ok {
+			return nil, notFound(id)
+		}
+	}
+
+	body := clone(req.body)
+	delete(body, "id")
+	delete(body, "created_time")
+	delete(body, "last_edited_time")
+	delete(body, "archived")
+
+	return s.insertPage(body, false)
+}
+
+// insertPage stores a page, keeping its ID and timestamps when they are set. The values of the properties are
+// stored by property ID, so that they follow the renames of the properties of the database. The values of
+// read-only properties are only accepted when the page is added with AddPage.
+func (s *Server) insertPage(obj object, seeding bool) (object, error) {
+	parent := normalizeParent(asObject(obj["parent"]))
+	if parent == nil {
+		return nil, validationErrorf("body failed validation: body.parent should be defined.")
+	}
+
+	now := s.timestamp()
+
+	page := object{
+		"object":           "page",
+		"id":               normalizeID(asString(obj["id"])),
+		"created_time":     orDefault(timestamp(obj["created_time"]), now),
+		"last_edited_time": orDefault(timestamp(obj["last_edited_time"]), now),
+		"parent":           parent,
+		"archived":         obj["archived"] == true,
+		"properties":       object{},
+	}
+
+	if page["id"] == "" {
+		page["id"] = s.newID()
+	}
+
+	id := asString(page["id"])
+	page["url"] = "https://www.notion.so/" + strings.ReplaceAll(id, "-", "")
+
+	if err := s.setPropertyValues(page, asObject(obj["properties"]), seeding); err != nil {
+		return nil, err
+	}
+
+	s.pages.add(page)
+	s.searchables = append(s.searchables, id)
+
+	if children := asArray(obj["children"]); len(children) > 0 {
+		if _, err := s.insertBlocks(id, children); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.renderPage(page), nil
+}
+
+func (s *Server) retrievePage(req request) (interface{}, error) {
+	page, ok := s.pages.get(req.id)
+	if !ok {
+		return nil, notFound(req.id)
+	}
+
+	return s.renderPage(page), nil
+}
+
+func (s *Server) updatePage(req request) (interface{}, error) {
+	page, ok := s.pages.get(req.id)
+	if !ok {
+		return nil, notFound(req.id)
+	}
+
+	if err := s.setPropertyValues(page, asObject(req.body["properties"]), false); err != nil {
+		return nil, err
+	}
+
+	if archived, ok := req.body["archived"].(bool); ok {
+		page["archived"] = archived
+	}
+
+	page["last_edited_time"] = s.timestamp()
+
+	return s.renderPage(page), nil
+}
+
+// schemaOf returns the properties of the database of a page, the title of a page outside of a database, or nil
+// when the database does not exist.
+func (s *Server) schemaOf(page object) object {
+	parent := asObject(page["parent"])
+	if parent["type"] != "database_id" {
+		return titleSchema
+	}
+
+	database, ok := s.databases.get(asString(parent["database_id"]))
+	if !ok {
+		return nil
+	}
+
+	return asObject(database["properties"])
+}
+
+// setPropertyValues stores the values of the properties of a page. The values of the pages whose database does not
+// exist are stored as they are, by name.
+func (s *Server) setPropertyValues(page, values object, seeding bool) error { // nolint:cyclop
+	schema := s.schemaOf(page)
+	stored := asObject(page["properties"])
+
+	for key, raw := range values {
+		value := asObject(raw)
+		if value == nil {
+			return validationErrorf("body failed validation: body.properties.%s should be an object.", key)
+		}
+
+		name, property := key, object{"id": key, "type": typeOf(value, "id")}
+
+		if schema != nil {
+			if name, property = findProperty(schema, key); property == nil {
+				return validationErrorf("%s is not a property that exists.", key)
+			}
+		}
+
+		propertyType := asString(property["type"])
+
+		if t := typeOf(value, "id"); t != "" && t != propertyType {
+			return validationErrorf("%s is expected to be %s.", name, propertyType)
+		}
+
+		if readOnlyPropertyTypes[propertyType] && !seeding {
+			return validationErrorf("%s is a %s property which cannot be set.", name, propertyType)
+		}
+
+		content := cloneValue(value[propertyType])
+
+		switch propertyType {
+		case "title", "rich_text":
+			fillPlainTexts(content)
+		case "select":
+			option, err := s.resolveOption(name, property, content)
+			if err != nil {
+				return err
+			}
+
+			content = option
+		case "multi_select":
+			options := make([]interface{}, 0, len(asArray(content)))
+
+			for _, raw := range asArray(content) {
+				option, err := s.resolveOption(name, property, raw)
+				if err != nil {
+					return err
+				}
+
+				options = append(options, option)
+			}
+
+			content = options
+		}
+
+		stored[asString(property["id"])] = object{"id": property["id"], "type": propertyType, propertyType: content}
+	}
+
+	return nil
+}
+
+// resolveOption returns the option of a select or multi select property with the ID or the name of the given option,
+// and adds the option to the database when it does not exist, as the API does.
+func (s *Server) resolveOption(name string, property object, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	option := asObject(value)
+	configuration := asObject(property[asString(property["type"])])
+
+	if existing := findOption(asArray(configuration["options"]), option); existing != nil {
+		return clone(existing), nil
+	}
+
+	if asString(option["name"]) == "" {
+		return nil, validationErrorf("%s has no option with ID %s.", name, asString(option["id"]))
+	}
+
+	option = clone(option)
+	s.fillOption(option)
+
+	if configuration != nil {
+		configuration["options"] = append(asArray(configuration["options"]), clone(option))
+	}
+
+	return option, nil
+}
+
+// renderPage returns a page as returned by the API, with the values of every property of its database by name,
+// empty when they are not set.
+func (s *Server) renderPage(page object) object {
+	rendered := clone(page)
+	stored := asObject(page["properties"])
+
+	schema := s.schemaOf(page)
+	if schema == nil {
+		return rendered
+	}
+
+	properties := object{}
+
+	for name, raw := range schema {
+		property := asObject(raw)
+		id, propertyType := asString(property["id"]), asString(property["type"])
+
+		if value := asObject(stored[id]); value != nil && value["type"] == propertyType {
+			properties[name] = clone(value)
+
+			continue
+		}
+
+		if content, ok := emptyValue(propertyType, page); ok {
+			properties[name] = object{"id": id, "type": propertyType, propertyType: content}
+		}
+	}
+
+	rendered["properties"] = properties
+
+	return rendered
+}
+
+// emptyValue returns the value of a property which is not set, or false when the value is computed by Notion.
+func emptyValue(propertyType string, page object) (interface{}, bool) {
+	switch propertyType {
+	case "title", "rich_text", "multi_select", "people", "files", "relation":
+		return []interface{}{}, true
+	case "checkbox":
+		return false, true
+	case "created_time", "last_edited_time":
+		return page[propertyType], true
+	case "formula", "rollup", "created_by", "last_edited_by":
+		return nil, false
+	}
+
+	return nil, true
+}
+
+// pageTitle returns the plain text of the title of a rendered page.
+func pageTitle(page object) string {
+	for _, raw := range asObject(page["properties"]) {
+		if value := asObject(raw); value["type"] == "title" {
+			return plainText(value["title"])
+		}
+	}
+
+	return ""
+}
+
+// plainText concatenates the plain texts of rich texts.
+func plainText(richTexts interface{}) string {
+	var b strings.Builder
+
+	for _, richText := range asArray(richTexts) {
+		b.WriteString(asString(asObject(richText)["plain_text"]))
+	}
+
+	return b.String()
+}
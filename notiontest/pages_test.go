@@ -0,0 +1,130 @@
+package notiontest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_CreatePage(t *testing.T) {
+	server, databaseID, ids := newTasksServer(t)
+	c := server.Client()
+
+	page, err := c.Pages().Create(context.Background(), notion.PagesCreateParameters{
+		Parent: notion.DatabaseParentInput{DatabaseID: databaseID},
+		Properties: map[string]notion.PropertyValue{
+			"Name":   notion.TitlePropertyValue{Title: []notion.RichText{notion.Text("Wash kale")}},
+			"Done":   notion.CheckboxPropertyValue{Checkbox: true},
+			"Status": notion.SelectPropertyValue{Select: notion.SelectPropertyValueOption{Name: "Later"}},
+		},
+		Children: []notion.Block{notion.Paragraph(notion.Text("Twice."))},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Wash kale"}, titles(t, []notion.Page{page.Page}))
+	assert.True(t, page.Properties["Done"].(*notion.CheckboxPropertyValue).Checkbox)
+	assert.Equal(t, now, page.CreatedTime)
+	assert.Equal(t, databaseID, page.Parent.(*notion.DatabaseParent).DatabaseID)
+
+	status := page.Properties["Status"].(*notion.SelectPropertyValue).Select
+	assert.Equal(t, "Later", status.Name)
+	assert.NotEmpty(t, status.ID)
+
+	database, err := c.Databases().Retrieve(context.Background(), notion.DatabasesRetrieveParameters{DatabaseID: databaseID})
+	require.NoError(t, err)
+	assert.Len(t, database.Properties["Status"].(*notion.SelectProperty).Select.Options, 3, "new options are added to the database")
+
+	children, err := c.Blocks().Children().List(context.Background(), notion.BlocksChildrenListParameters{BlockID: page.ID})
+	require.NoError(t, err)
+	require.Len(t, children.Results, 1)
+	assert.Equal(t, "Twice.", notion.PlainText(children.Results[0].(*notion.ParagraphBlock).Paragraph.Text))
+
+	subpage, err := c.Pages().Create(context.Background(), notion.PagesCreateParameters{
+		Parent: notion.PageParentInput{PageID: ids[0]},
+		Properties: map[string]notion.PropertyValue{
+			"title": notion.TitlePropertyValue{Title: []notion.RichText{notion.Text("Notes")}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Notes", notion.PlainText(subpage.Properties["title"].(*notion.TitlePropertyValue).Title))
+
+	tests := []struct {
+		name   string
+		params notion.PagesCreateParameters
+		want   error
+	}{
+		{
+			name: "Unknown database",
+			params: notion.PagesCreateParameters{
+				Parent:     notion.DatabaseParentInput{DatabaseID: "unknown"},
+				Properties: map[string]notion.PropertyValue{},
+			},
+			want: notion.ErrObjectNotFound,
+		},
+		{
+			name: "Unknown property",
+			params: notion.PagesCreateParameters{
+				Parent: notion.DatabaseParentInput{DatabaseID: databaseID},
+				Properties: map[string]notion.PropertyValue{
+					"Owner": notion.RichTextPropertyValue{RichText: []notion.RichText{notion.Text("Ada")}},
+				},
+			},
+			want: notion.ErrValidationError,
+		},
+		{
+			name: "Mismatched type",
+			params: notion.PagesCreateParameters{
+				Parent: notion.DatabaseParentInput{DatabaseID: databaseID},
+				Properties: map[string]notion.PropertyValue{
+					"Price": notion.CheckboxPropertyValue{Checkbox: true},
+				},
+			},
+			want: notion.ErrValidationError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Pages().Create(context.Background(), tt.params)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestServer_UpdatePage(t *testing.T) {
+	later := now.Add(time.Hour)
+	clock := now
+
+	server, _, ids := newTasksServer(t)
+	server.now = func() time.Time { return clock }
+	c := server.Client()
+
+	clock = later
+
+	page, err := c.Pages().Update(context.Background(), notion.PagesUpdateParameters{
+		PageID: ids[1],
+		Properties: map[string]notion.PropertyValue{
+			"Price": notion.NumberPropertyValue{Number: 5},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(5), page.Properties["Price"].(*notion.NumberPropertyValue).Number)
+	assert.Equal(t, "Done", page.Properties["Status"].(*notion.SelectPropertyValue).Select.Name, "other values are kept")
+	assert.Equal(t, now, page.CreatedTime)
+	assert.Equal(t, later, page.LastEditedTime)
+
+	retrieved, err := c.Pages().Retrieve(context.Background(), notion.PagesRetrieveParameters{PageID: ids[1]})
+	require.NoError(t, err)
+	assert.Equal(t, page.Page, retrieved.Page)
+
+	_, err = c.Pages().Update(context.Background(), notion.PagesUpdateParameters{
+		PageID:     "unknown",
+		Properties: map[string]notion.PropertyValue{},
+	})
+	assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+}
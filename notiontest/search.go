@@ -0,0 +1,75 @@
+package notiontest
+
+import (
+	"sort"
+	"strings"
+)
+
+func (s *Server) search(req request) (interface{}, error) {
+	query := strings.ToLower(asString(req.body["query"]))
+
+	var kind string
+
+	if filter := asObject(req.body["filter"]); filter["property"] == "object" {
+		kind = asString(filter["value"])
+	}
+
+	if kind != "" && kind != "page" && kind != "database" {
+		return nil, validationErrorf("body failed validation: body.filter.value should be \"page\" or \"database\".")
+	}
+
+	var results []object
+
+	for _, id := range s.searchables {
+		var (
+			result object
+			title  string
+		)
+
+		if database, ok := s.databases.get(id); ok {
+			result, title = clone(database), plainText(database["title"])
+		} else if page, ok := s.pages.get(id); ok && page["archived"] != true {
+			result = s.renderPage(page)
+			title = pageTitle(result)
+		} else {
+			continue
+		}
+
+		if kind != "" && result["object"] != kind || !strings.Contains(strings.ToLower(title), query) {
+			continue
+		}
+
+		results = append(results, result)
+	}
+
+	if err := sortSearchResults(results, asObject(req.body["sort"])); err != nil {
+		return nil, err
+	}
+
+	return paginate(results, req)
+}
+
+// sortSearchResults sorts the results by last edited time when it is requested, in the order of their creation
+// otherwise.
+func sortSearchResults(results []object, sorting object) error {
+	switch timestamp := asString(sorting["timestamp"]); timestamp {
+	case "":
+		return nil
+	case "last_edited_time":
+	default:
+		return validationErrorf("body failed validation: body.sort.timestamp should be \"last_edited_time\", instead was %q.", timestamp)
+	}
+
+	descending := sorting["direction"] == "descending"
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := asString(results[i]["last_edited_time"]), asString(results[j]["last_edited_time"])
+		if descending {
+			return a > b
+		}
+
+		return a < b
+	})
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package notiontest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Search(t *testing.T) {
+	server, databaseID, ids := newTasksServer(t)
+	c := server.Client()
+
+	server.now = func() time.Time { return now.Add(time.Hour) }
+
+	_, err := c.Pages().Update(context.Background(), notion.PagesUpdateParameters{
+		PageID:     ids[1],
+		Properties: map[string]notion.PropertyValue{"Price": notion.NumberPropertyValue{Number: 3}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params notion.SearchParameters
+		want   []string
+	}{
+		{
+			name: "Search everything",
+			want: []string{ids[0], databaseID, ids[1], ids[2], ids[3]},
+		},
+		{
+			name:   "Search by title",
+			params: notion.SearchParameters{Query: "KALE"},
+			want:   []string{ids[1], ids[2], ids[3]},
+		},
+		{
+			name: "Search databases",
+			params: notion.SearchParameters{
+				Filter: notion.SearchFilter{Property: notion.SearchFilterPropertyObject, Value: notion.SearchFilterValueDatabase},
+			},
+			want: []string{databaseID},
+		},
+		{
+			name: "Sort by last edited time",
+			params: notion.SearchParameters{
+				Query: "kale",
+				Sort:  notion.SearchSort{Timestamp: notion.SearchSortTimestampLastEditedTime, Direction: notion.SearchSortDirectionAscending},
+			},
+			want: []string{ids[2], ids[3], ids[1]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := c.SearchAll(context.Background(), tt.params).Collect(0)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(results))
+
+			for _, result := range results {
+				switch result := result.(type) {
+				case *notion.Page:
+					got = append(got, result.ID)
+				case *notion.Database:
+					got = append(got, result.ID)
+				}
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,374 @@
+// Package notiontest provides an in-memory fake of the Notion API for tests, so that code using the client can be
+// tested offline without hand-written handlers:
+//
+//	server := notiontest.NewServer()
+//	defer server.Close()
+//
+//	pageID := server.AddPage(page)
+//	c := notion.New("token", notion.WithBaseURL(server.URL))
+//
+// The server stores users, databases, pages and blocks, and implements the endpoints of the client: listing,
+// retrieving, creating and updating them, querying databases with filters and sorts, searching, and listing and
+// appending the children of blocks, with the same pagination as the API. Databases and pages are created with
+// the API, or added with AddDatabase and AddPage since the API cannot create top level pages. Users can only be
+// added with AddUser.
+//
+// The fake is not complete: the values of formulas and rollups are not computed, and the validation of the
+// requests is limited to the common mistakes, e.g. unknown properties or objects.
+package notiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// timeLayout is the layout of the times in the objects, e.g. 2021-05-13T08:00:00.000Z, which sort as strings.
+const timeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// Option configures a Server.
+type Option func(s *Server)
+
+// WithClock sets the function returning the current time, used for the timestamps of the objects and for
+// relative dates in filters, e.g. to return a fixed time.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithAuthToken makes the server reject the requests without the given token. By default any token is accepted.
+func WithAuthToken(token string) Option {
+	return func(s *Server) {
+		s.authToken = token
+	}
+}
+
+// Server is an in-memory fake of the Notion API.
+type Server struct {
+	// URL of the server, to use with notion.WithBaseURL.
+	URL string
+
+	server    *httptest.Server
+	now       func() time.Time
+	authToken string
+
+	mu        sync.Mutex
+	ids       int
+	users     collection
+	databases collection
+	pages     collection
+	blocks    collection
+	// IDs of the child blocks of the pages and blocks.
+	children map[string][]string
+	// IDs of the databases and pages in the order of their creation, for search.
+	searchables []string
+}
+
+// NewServer starts a fake of the Notion API, which must be closed with Close.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		now:       time.Now,
+		users:     newCollection(),
+		databases: newCollection(),
+		pages:     newCollection(),
+		blocks:    newCollection(),
+		children:  make(map[string][]string),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client of the server.
+func (s *Server) Client(setters ...notion.APISetting) *notion.API {
+	token := s.authToken
+	if token == "" {
+		token = "secret_notiontest"
+	}
+
+	return notion.New(token, append([]notion.APISetting{notion.WithBaseURL(s.URL)}, setters...)...)
+}
+
+// AddUser adds a user, and returns its ID. An ID is generated when the user has none.
+func (s *Server) AddUser(user notion.User) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj := mustObject(user)
+	obj["object"] = string(notion.ObjectTypeUser)
+
+	if asString(obj["id"]) == "" {
+		obj["id"] = s.newID()
+	}
+
+	s.users.add(obj)
+
+	return asString(obj["id"])
+}
+
+// AddDatabase adds a database, and returns its ID. The IDs of the database and its properties, and the timestamps,
+// are generated when they are not set. It panics when the database is invalid, e.g. without a title property.
+func (s *Server) AddDatabase(database notion.Database) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, err := s.insertDatabase(mustObject(database))
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: invalid database: %v", err))
+	}
+
+	return asString(obj["id"])
+}
+
+// AddPage adds a page, and returns its ID. The parent of the page is not required to exist, and the values of
+// formulas and rollups can be set, unlike with the API. The ID and the timestamps are generated when they are not
+// set. It panics when the page is invalid, e.g. with a property missing from its database.
+func (s *Server) AddPage(page notion.Page) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, err := s.insertPage(mustObject(page), true)
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: invalid page: %v", err))
+	}
+
+	return asString(obj["id"])
+}
+
+// AddBlocks appends blocks, with their children, to the children of a page or a block, and returns their IDs.
+func (s *Server) AddBlocks(parentID string, blocks ...notion.Block) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	children := make([]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		children = append(children, mustObject(block))
+	}
+
+	ids, err := s.insertBlocks(normalizeID(parentID), children)
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: invalid blocks: %v", err))
+	}
+
+	return ids
+}
+
+// request is a request to the API, with the ID in its path if any.
+type request struct {
+	id    string
+	query url.Values
+	body  object
+}
+
+// param returns a parameter from the body, or from the query string.
+func (r request) param(name string) string {
+	switch v := r.body[name].(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprint(v)
+	}
+
+	return r.query.Get(name)
+}
+
+type handler func(s *Server, req request) (interface{}, error)
+
+// handlers are the handlers of the endpoints by method and path, where {id} stands for the ID in the path.
+var handlers = map[string]handler{ // nolint:gochecknoglobals
+	"GET users":                  (*Server).listUsers,
+	"GET users/{id}":             (*Server).retrieveUser,
+	"GET databases":              (*Server).listDatabases,
+	"POST databases":             (*Server).createDatabase,
+	"GET databases/{id}":         (*Server).retrieveDatabase,
+	"PATCH databases/{id}":       (*Server).updateDatabase,
+	"POST databases/{id}/query":  (*Server).queryDatabase,
+	"POST pages":                 (*Server).createPage,
+	"GET pages/{id}":             (*Server).retrievePage,
+	"PATCH pages/{id}":           (*Server).updatePage,
+	"GET blocks/{id}":            (*Server).retrieveBlock,
+	"PATCH blocks/{id}":          (*Server).updateBlock,
+	"DELETE blocks/{id}":         (*Server).deleteBlock,
+	"GET blocks/{id}/children":   (*Server).listChildren,
+	"PATCH blocks/{id}/children": (*Server).appendChildren,
+	"POST search":                (*Server).search,
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") || s.authToken != "" && token != s.authToken {
+		writeError(w, errorf(http.StatusUnauthorized, notion.ErrorCodeUnauthorized, "API token is invalid."))
+
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "v1" {
+		writeError(w, errorf(http.StatusBadRequest, notion.ErrorCodeInvalidRequestURI, "Invalid request URL."))
+
+		return
+	}
+
+	req := request{query: r.URL.Query()}
+
+	if len(segments) > 2 {
+		req.id = normalizeID(segments[2])
+		segments[2] = "{id}"
+	}
+
+	handle, ok := handlers[r.Method+" "+strings.Join(segments[1:], "/")]
+	if !ok {
+		writeError(w, errorf(http.StatusBadRequest, notion.ErrorCodeInvalidRequestURI, "Invalid request URL."))
+
+		return
+	}
+
+	if r.ContentLength != 0 && r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&req.body); err != nil {
+			writeError(w, errorf(http.StatusBadRequest, notion.ErrorCodeInvalidJSON, "Error parsing JSON body."))
+
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := handle(s, req)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	// The response is encoded with the lock held, the results share maps with the stored objects.
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// apiError is an error returned by the API.
+type apiError struct {
+	status  int
+	code    notion.ErrorCode
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func errorf(status int, code notion.ErrorCode, format string, args ...interface{}) error {
+	return &apiError{status: status, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+func validationErrorf(format string, args ...interface{}) error {
+	return errorf(http.StatusBadRequest, notion.ErrorCodeValidationError, format, args...)
+}
+
+func notFound(id string) error {
+	return errorf(http.StatusNotFound, notion.ErrorCodeObjectNotFound,
+		"Could not find object with ID: %s. Make sure the relevant pages and databases are shared with your integration.", id)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*apiError) // nolint:errorlint
+	if !ok {
+		e = &apiError{status: http.StatusInternalServerError, code: notion.ErrorCodeInternalServerError, message: err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	_ = json.NewEncoder(w).Encode(object{"object": "error", "status": e.status, "code": e.code, "message": e.message})
+}
+
+// paginate returns the page of the results starting at the start_cursor parameter, of at most page_size results.
+// The cursors are the IDs of the results.
+func paginate(results []object, req request) (object, error) {
+	pageSize := 100
+
+	if size := req.param("page_size"); size != "" {
+		if _, err := fmt.Sscan(size, &pageSize); err != nil || pageSize < 1 || pageSize > 100 {
+			return nil, validationErrorf("body failed validation: body.page_size should be a number between 1 and 100.")
+		}
+	}
+
+	start := 0
+
+	if cursor := req.param("start_cursor"); cursor != "" {
+		start = -1
+
+		for i, result := range results {
+			if result["id"] == cursor {
+				start = i
+			}
+		}
+
+		if start < 0 {
+			return nil, validationErrorf("The start_cursor provided is invalid: %s", cursor)
+		}
+	}
+
+	end := start + pageSize
+	if end > len(results) {
+		end = len(results)
+	}
+
+	page := make([]interface{}, 0, end-start)
+	for _, result := range results[start:end] {
+		page = append(page, result)
+	}
+
+	var nextCursor interface{}
+	if end < len(results) {
+		nextCursor = results[end]["id"]
+	}
+
+	return object{"object": "list", "results": page, "next_cursor": nextCursor, "has_more": end < len(results)}, nil
+}
+
+// newID returns a new UUID, made of a counter so that the IDs are deterministic.
+func (s *Server) newID() string {
+	s.ids++
+
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.ids)
+}
+
+// newPropertyID returns a new ID of a property of a database, e.g. p%3A7, which are short unlike the other IDs.
+func (s *Server) newPropertyID() string {
+	s.ids++
+
+	return fmt.Sprintf("p%%3A%d", s.ids)
+}
+
+// timestamp returns the current time in the format of the API.
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(timeLayout)
+}
+
+// normalizeID adds the dashes to an ID without them, the API accepts both.
+func normalizeID(id string) string {
+	if len(id) != 32 || strings.Contains(id, "-") {
+		return id
+	}
+
+	return id[:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:]
+}
@@ -0,0 +1,140 @@
+package notiontest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2021, 5, 20, 9, 0, 0, 0, time.UTC)
+
+// newTasksServer returns a server with a workspace page, a database of tasks in it, and three tasks.
+func newTasksServer(t *testing.T) (*Server, string, []string) {
+	t.Helper()
+
+	server := NewServer(WithClock(func() time.Time { return now }))
+	t.Cleanup(server.Close)
+
+	workspace := notion.WorkspaceParent{}
+	workspace.Type = notion.ParentTypeWorkspace
+
+	rootID := server.AddPage(notion.Page{
+		Parent: workspace,
+		Properties: map[string]notion.PropertyValue{
+			"title": notion.TitlePropertyValue{Title: []notion.RichText{notion.Text("Home")}},
+		},
+	})
+
+	databaseID := server.AddDatabase(notion.Database{
+		Title: []notion.RichText{notion.Text("Tasks")},
+		Properties: map[string]notion.Property{
+			"Name":  notion.TitleProperty{},
+			"Price": notion.NumberProperty{},
+			"Done":  notion.CheckboxProperty{},
+			"Status": notion.SelectProperty{Select: notion.SelectPropertyOption{Options: []notion.SelectOption{
+				{Name: "Todo", Color: notion.ColorRed},
+				{Name: "Done", Color: notion.ColorGreen},
+			}}},
+		},
+	})
+
+	parent := notion.DatabaseParent{DatabaseID: databaseID}
+	parent.Type = notion.ParentTypeDatabase
+
+	var pageIDs []string
+
+	for _, task := range []struct {
+		name   string
+		price  float64
+		status string
+	}{
+		{"Buy kale", 4, "Done"},
+		{"Cook kale", 12, "Todo"},
+		{"Eat kale", 8, "Todo"},
+	} {
+		pageIDs = append(pageIDs, server.AddPage(notion.Page{
+			Parent: parent,
+			Properties: map[string]notion.PropertyValue{
+				"Name":   notion.TitlePropertyValue{Title: []notion.RichText{notion.Text(task.name)}},
+				"Price":  notion.NumberPropertyValue{Number: task.price},
+				"Status": notion.SelectPropertyValue{Select: notion.SelectPropertyValueOption{Name: task.status}},
+			},
+		}))
+	}
+
+	return server, databaseID, append([]string{rootID}, pageIDs...)
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	server := NewServer(WithAuthToken("secret_right"))
+	defer server.Close()
+
+	_, err := notion.New("secret_wrong", notion.WithBaseURL(server.URL)).Users().List(context.Background(), notion.UsersListParameters{})
+	assert.ErrorIs(t, err, notion.ErrUnauthorized)
+
+	_, err = server.Client().Users().List(context.Background(), notion.UsersListParameters{})
+	assert.NoError(t, err)
+}
+
+func TestServer_InvalidRequestURL(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/v1/comments", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer secret")
+
+	resp, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestServer_Users(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	var ids []string
+
+	for _, name := range []string{"Ada", "Grace", "Alan"} {
+		user := notion.PersonUser{Person: notion.Person{Email: name + "@example.com"}}
+		user.Name, user.Type = name, notion.UserTypePerson
+
+		ids = append(ids, server.AddUser(user))
+	}
+
+	c := server.Client()
+
+	resp, err := c.Users().List(context.Background(), notion.UsersListParameters{
+		PaginationParameters: notion.PaginationParameters{PageSize: 2},
+	})
+	require.NoError(t, err)
+	assert.Len(t, resp.Results, 2)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, ids[2], resp.NextCursor)
+
+	users, err := c.Users().ListAll(context.Background(), notion.UsersListParameters{
+		PaginationParameters: notion.PaginationParameters{PageSize: 2},
+	}).Collect(0)
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+
+	user, err := c.Users().Retrieve(context.Background(), notion.UsersRetrieveParameters{UserID: ids[1]})
+	require.NoError(t, err)
+	assert.Equal(t, "Grace", user.User.(*notion.PersonUser).Name)
+	assert.Equal(t, "Grace@example.com", user.User.(*notion.PersonUser).Person.Email)
+
+	_, err = c.Users().Retrieve(context.Background(), notion.UsersRetrieveParameters{UserID: "unknown"})
+	assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+
+	_, err = c.Users().List(context.Background(), notion.UsersListParameters{
+		PaginationParameters: notion.PaginationParameters{StartCursor: "unknown"},
+	})
+	assert.ErrorIs(t, err, notion.ErrValidationError)
+}
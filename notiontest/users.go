@@ -0,0 +1,14 @@
+package notiontest
+
+func (s *Server) listUsers(req request) (interface{}, error) {
+	return paginate(s.users.list(), req)
+}
+
+func (s *Server) retrieveUser(req request) (interface{}, error) {
+	user, ok := s.users.get(req.id)
+	if !ok {
+		return nil, notFound(req.id)
+	}
+
+	return user, nil
+}
@@ -185,9 +185,9 @@ type SelectPropertyValue struct {
 }
 
 type MultiSelectPropertyValueOption struct {
-	ID    string `json:"id"`
+	ID    string `json:"id,omitempty"`
 	Name  string `json:"name"`
-	Color Color  `json:"color"`
+	Color Color  `json:"color,omitempty"`
 }
 
 type MultiSelectPropertyValue struct {
@@ -273,24 +273,56 @@ type RollupValueType interface {
 }
 
 type baseRollupValueType struct {
-	Type string `json:"type"`
+	Type RollupValueKind `json:"type"`
+	// The function computing the value from the related pages.
+	Function RollupFunction `json:"function,omitempty"`
 }
 
 func (b baseRollupValueType) isRollupValueType() {}
 
 type NumberRollupValue struct {
 	baseRollupValueType
-	Number float64 `json:"number"`
+	Number *float64 `json:"number"`
 }
 
 type DateRollupValue struct {
 	baseRollupValueType
-	Date DatePropertyValue `json:"date"`
+	Date Date `json:"date"`
 }
 
 type ArrayRollupValue struct {
 	baseRollupValueType
-	Array []interface{} `json:"array"`
+	// The values of the rolled up property of the related pages, e.g. *TitlePropertyValue.
+	// The values have no ID.
+	Array []PropertyValue `json:"array"`
+}
+
+func (a *ArrayRollupValue) UnmarshalJSON(data []byte) error {
+	type Alias ArrayRollupValue
+
+	alias := struct {
+		*Alias
+		Array []propertyValueDecoder `json:"array"`
+	}{
+		Alias: (*Alias)(a),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal ArrayRollupValue: %w", err)
+	}
+
+	a.Array = make([]PropertyValue, 0, len(alias.Array))
+
+	for _, decoder := range alias.Array {
+		a.Array = append(a.Array, decoder.PropertyValue)
+	}
+
+	return nil
+}
+
+// UnsupportedRollupValue is a rollup whose value is not returned by the API, of type unsupported or incomplete.
+type UnsupportedRollupValue struct {
+	baseRollupValueType
 }
 
 type RollupPropertyValue struct {
@@ -298,11 +330,53 @@ type RollupPropertyValue struct {
 	Rollup RollupValueType `json:"rollup"`
 }
 
+func (r *RollupPropertyValue) UnmarshalJSON(data []byte) error {
+	type Alias RollupPropertyValue
+
+	alias := struct {
+		*Alias
+		Rollup rollupValueDecoder `json:"rollup"`
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal RollupPropertyValue: %w", err)
+	}
+
+	r.Rollup = alias.Rollup.RollupValueType
+
+	return nil
+}
+
 type PeoplePropertyValue struct {
 	basePropertyValue
 	People []User `json:"people"`
 }
 
+func (p *PeoplePropertyValue) UnmarshalJSON(data []byte) error {
+	type Alias PeoplePropertyValue
+
+	alias := struct {
+		*Alias
+		People []userDecoder `json:"people"`
+	}{
+		Alias: (*Alias)(p),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal PeoplePropertyValue: %w", err)
+	}
+
+	p.People = make([]User, 0, len(alias.People))
+
+	for _, decoder := range alias.People {
+		p.People = append(p.People, decoder.User)
+	}
+
+	return nil
+}
+
 type File struct {
 	Name string `json:"name"`
 }
@@ -342,6 +416,25 @@ type CreatedByPropertyValue struct {
 	CreatedBy User `json:"created_by"`
 }
 
+func (c *CreatedByPropertyValue) UnmarshalJSON(data []byte) error {
+	type Alias CreatedByPropertyValue
+
+	alias := struct {
+		*Alias
+		CreatedBy userDecoder `json:"created_by"`
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal CreatedByPropertyValue: %w", err)
+	}
+
+	c.CreatedBy = alias.CreatedBy.User
+
+	return nil
+}
+
 type LastEditedTimePropertyValue struct {
 	basePropertyValue
 	LastEditedTime time.Time `json:"last_edited_time"`
@@ -352,6 +445,25 @@ type LastEditedByPropertyValue struct {
 	LastEditedBy User `json:"last_edited_by"`
 }
 
+func (l *LastEditedByPropertyValue) UnmarshalJSON(data []byte) error {
+	type Alias LastEditedByPropertyValue
+
+	alias := struct {
+		*Alias
+		LastEditedBy userDecoder `json:"last_edited_by"`
+	}{
+		Alias: (*Alias)(l),
+	}
+
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return fmt.Errorf("failed to unmarshal LastEditedByPropertyValue: %w", err)
+	}
+
+	l.LastEditedBy = alias.LastEditedBy.User
+
+	return nil
+}
+
 type PagesRetrieveParameters struct {
 	PageID string `json:"-" url:"-"`
 }
@@ -416,6 +528,7 @@ func (p *pagesClient) Update(ctx context.Context, params PagesUpdateParameters)
 	var failure HTTPError
 
 	err := p.restClient.New().Patch().
+		Idempotent().
 		Endpoint(strings.Replace(APIPagesUpdateEndpoint, "{page_id}", params.PageID, 1)).
 		QueryStruct(params).
 		BodyJSON(params).
@@ -495,6 +608,36 @@ func (p *parentDecoder) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, p.Parent)
 }
 
+type rollupValueDecoder struct {
+	RollupValueType
+}
+
+func (r *rollupValueDecoder) UnmarshalJSON(data []byte) error {
+	var decoder struct {
+		Type RollupValueKind `json:"type"`
+	}
+
+	if err := json.Unmarshal(data, &decoder); err != nil {
+		return fmt.Errorf("failed to unmarshal RollupValueType: %w", err)
+	}
+
+	switch decoder.Type {
+	case RollupValueKindNumber:
+		r.RollupValueType = &NumberRollupValue{}
+
+	case RollupValueKindDate:
+		r.RollupValueType = &DateRollupValue{}
+
+	case RollupValueKindArray:
+		r.RollupValueType = &ArrayRollupValue{}
+
+	case RollupValueKindUnsupported, RollupValueKindIncomplete:
+		r.RollupValueType = &UnsupportedRollupValue{}
+	}
+
+	return json.Unmarshal(data, &r.RollupValueType)
+}
+
 type propertyValueDecoder struct {
 	PropertyValue
 }
@@ -2,6 +2,7 @@ package notion
 
 import (
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/mkfsn/notion-go/rest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_pagesClient_Retrieve(t *testing.T) {
@@ -750,3 +752,120 @@ func Test_pagesClient_Update(t *testing.T) {
 		})
 	}
 }
+
+// readFixturePage reads a page recorded from the API, with rollups and mentions of every type.
+func readFixturePage(t *testing.T) Page {
+	t.Helper()
+
+	data, err := ioutil.ReadFile("testdata/page_rollups_and_mentions.json")
+	assert.NoError(t, err)
+
+	var page Page
+
+	assert.NoError(t, json.Unmarshal(data, &page))
+
+	return page
+}
+
+func TestRollupPropertyValue_UnmarshalJSON(t *testing.T) {
+	page := readFixturePage(t)
+	totalBudget := 1250.5
+
+	tests := []struct {
+		property string
+		want     PropertyValue
+	}{
+		{
+			property: "Total budget",
+			want: &RollupPropertyValue{
+				basePropertyValue: basePropertyValue{ID: "Ue%3Dk", Type: PropertyValueTypeRollup},
+				Rollup: &NumberRollupValue{
+					baseRollupValueType: baseRollupValueType{Type: RollupValueKindNumber, Function: RollupFunctionSum},
+					Number:              &totalBudget,
+				},
+			},
+		},
+		{
+			property: "Next due",
+			want: &RollupPropertyValue{
+				basePropertyValue: basePropertyValue{ID: "g%7Cs%3F", Type: PropertyValueTypeRollup},
+				Rollup: &DateRollupValue{
+					baseRollupValueType: baseRollupValueType{Type: RollupValueKindDate, Function: RollupFunctionEarliestDate},
+					Date:                Date{Start: "2021-05-24"},
+				},
+			},
+		},
+		{
+			property: "Task names",
+			want: &RollupPropertyValue{
+				basePropertyValue: basePropertyValue{ID: "%40hKd", Type: PropertyValueTypeRollup},
+				Rollup: &ArrayRollupValue{
+					baseRollupValueType: baseRollupValueType{Type: RollupValueKindArray, Function: RollupFunctionShowOriginal},
+					Array: []PropertyValue{
+						&TitlePropertyValue{
+							basePropertyValue: basePropertyValue{Type: PropertyValueTypeTitle},
+							Title: []RichText{
+								&RichTextText{
+									BaseRichText: BaseRichText{
+										PlainText:   "Buy kale",
+										Type:        RichTextTypeText,
+										Annotations: &Annotations{Color: ColorDefault},
+									},
+									Text: TextObject{Content: "Buy kale"},
+								},
+							},
+						},
+						&NumberPropertyValue{
+							basePropertyValue: basePropertyValue{Type: PropertyValueTypeNumber},
+							Number:            3,
+						},
+					},
+				},
+			},
+		},
+		{
+			property: "Owners",
+			want: &RollupPropertyValue{
+				basePropertyValue: basePropertyValue{ID: "OWn%5B", Type: PropertyValueTypeRollup},
+				Rollup: &UnsupportedRollupValue{
+					baseRollupValueType: baseRollupValueType{Type: RollupValueKindUnsupported, Function: RollupFunctionShowOriginal},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.property, func(t *testing.T) {
+			assert.Equal(t, tt.want, page.Properties[tt.property])
+		})
+	}
+}
+
+func TestParentDecoder_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want Parent
+	}{
+		{
+			data: `{"type": "database_id", "database_id": "48f8fee9-cd79-4180-bc2f-ec0398253067"}`,
+			want: &DatabaseParent{baseParent: baseParent{Type: ParentTypeDatabase}, DatabaseID: "48f8fee9-cd79-4180-bc2f-ec0398253067"},
+		},
+		{
+			data: `{"type": "page_id", "page_id": "98ad959b-2b6a-4774-80ee-00246fb0ea9b"}`,
+			want: &PageParent{baseParent: baseParent{Type: ParentTypePage}, PageID: "98ad959b-2b6a-4774-80ee-00246fb0ea9b"},
+		},
+		{
+			data: `{"type": "workspace", "workspace": true}`,
+			want: &WorkspaceParent{baseParent: baseParent{Type: ParentTypeWorkspace}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var decoder parentDecoder
+
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &decoder))
+			assert.Equal(t, tt.want, decoder.Parent)
+		})
+	}
+}
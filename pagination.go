@@ -1,5 +1,9 @@
 package notion
 
+import (
+	"context"
+)
+
 type PaginationParameters struct {
 	// If supplied, this endpoint will return a page of results starting after the cursor provided.
 	// If not supplied, this endpoint will return the first page of results.
@@ -13,3 +17,69 @@ type PaginatedList struct {
 	HasMore    bool       `json:"has_more"`
 	NextCursor string     `json:"next_cursor"`
 }
+
+// pageFetcher fetches the page of results starting at the given cursor.
+type pageFetcher func(ctx context.Context, startCursor string) ([]interface{}, PaginatedList, error)
+
+// pager walks through every result of a paginated list endpoint, fetching the pages on demand.
+type pager struct {
+	ctx    context.Context
+	fetch  pageFetcher
+	cursor string
+
+	fetched bool
+	hasMore bool
+	items   []interface{}
+	index   int
+	value   interface{}
+	err     error
+}
+
+func newPager(ctx context.Context, startCursor string, fetch pageFetcher) *pager {
+	return &pager{
+		ctx:    ctx,
+		fetch:  fetch,
+		cursor: startCursor,
+	}
+}
+
+// Next advances to the next result, fetching the next page when needed.
+// It returns false when there are no more results, or when an error occurred or the context is done.
+func (p *pager) Next() bool {
+	if p.err != nil {
+		return false
+	}
+
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+
+		return false
+	}
+
+	for p.index >= len(p.items) {
+		if p.fetched && !p.hasMore {
+			return false
+		}
+
+		items, list, err := p.fetch(p.ctx, p.cursor)
+		if err != nil {
+			p.err = err
+
+			return false
+		}
+
+		p.fetched = true
+		p.items, p.index = items, 0
+		p.hasMore, p.cursor = list.HasMore && list.NextCursor != "", list.NextCursor
+	}
+
+	p.value = p.items[p.index]
+	p.index++
+
+	return true
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (p *pager) Err() error {
+	return p.err
+}
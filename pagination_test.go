@@ -0,0 +1,236 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMultiPageHandler serves the given pages of results, each page is addressed by the cursor "cursor-<index>".
+func newMultiPageHandler(t *testing.T, method, path string, pages [][]string) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, method, request.Method)
+		assert.Equal(t, path, request.URL.Path)
+
+		index := 0
+
+		if cursor := request.URL.Query().Get("start_cursor"); cursor != "" {
+			_, err := fmt.Sscanf(cursor, "cursor-%d", &index)
+			assert.NoError(t, err)
+		}
+
+		nextCursor, hasMore := "null", false
+		if index+1 < len(pages) {
+			nextCursor, hasMore = fmt.Sprintf(`"cursor-%d"`, index+1), true
+		}
+
+		results := "["
+
+		for i, result := range pages[index] {
+			if i > 0 {
+				results += ","
+			}
+
+			results += result
+		}
+
+		results += "]"
+
+		writer.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprintf(writer, `{"object": "list", "results": %s, "next_cursor": %s, "has_more": %t}`, results, nextCursor, hasMore)
+		assert.NoError(t, err)
+	})
+}
+
+func userJSON(id string) string {
+	return fmt.Sprintf(`{"object": "user", "id": %q, "type": "bot", "bot": {}}`, id)
+}
+
+func databaseJSON(id string) string {
+	return fmt.Sprintf(`{"object": "database", "id": %q, "title": [], "properties": {}}`, id)
+}
+
+func pageJSON(id string) string {
+	return fmt.Sprintf(`{"object": "page", "id": %q, "parent": {"type": "workspace"}, "properties": {}}`, id)
+}
+
+func blockJSON(id string) string {
+	return fmt.Sprintf(`{"object": "block", "id": %q, "type": "unsupported"}`, id)
+}
+
+func TestUsersIterator(t *testing.T) {
+	mockHTTPServer := httptest.NewServer(newMultiPageHandler(t, http.MethodGet, "/v1/users", [][]string{
+		{userJSON("1"), userJSON("2")},
+		{userJSON("3")},
+		{userJSON("4"), userJSON("5")},
+	}))
+	defer mockHTTPServer.Close()
+
+	sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+	users, err := sut.Users().ListAll(context.Background(), UsersListParameters{}).Collect(0)
+	assert.NoError(t, err)
+
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.(*BotUser).ID)
+	}
+
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ids)
+}
+
+func TestDatabasesIterator(t *testing.T) {
+	mockHTTPServer := httptest.NewServer(newMultiPageHandler(t, http.MethodGet, "/v1/databases", [][]string{
+		{databaseJSON("1")},
+		{databaseJSON("2"), databaseJSON("3")},
+	}))
+	defer mockHTTPServer.Close()
+
+	sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+	iterator := sut.Databases().ListAll(context.Background(), DatabasesListParameters{})
+
+	var ids []string
+	for iterator.Next() {
+		ids = append(ids, iterator.Value().ID)
+	}
+
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+}
+
+func TestPagesIterator(t *testing.T) {
+	mockHTTPServer := httptest.NewServer(newMultiPageHandler(t, http.MethodPost, "/v1/databases/db/query", [][]string{
+		{pageJSON("1"), pageJSON("2")},
+		{pageJSON("3"), pageJSON("4")},
+		{pageJSON("5")},
+	}))
+	defer mockHTTPServer.Close()
+
+	sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+	tests := []struct {
+		name     string
+		params   DatabasesQueryParameters
+		maxItems int
+		wantIDs  []string
+	}{
+		{
+			name:    "Collect every page",
+			params:  DatabasesQueryParameters{DatabaseID: "db"},
+			wantIDs: []string{"1", "2", "3", "4", "5"},
+		},
+		{
+			name:     "Collect at most 3 pages",
+			params:   DatabasesQueryParameters{DatabaseID: "db"},
+			maxItems: 3,
+			wantIDs:  []string{"1", "2", "3"},
+		},
+		{
+			name: "Start at the cursor of the parameters",
+			params: DatabasesQueryParameters{
+				PaginationParameters: PaginationParameters{StartCursor: "cursor-1"},
+				DatabaseID:           "db",
+			},
+			wantIDs: []string{"3", "4", "5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages, err := sut.Databases().QueryAll(context.Background(), tt.params).Collect(tt.maxItems)
+			assert.NoError(t, err)
+
+			ids := make([]string, 0, len(pages))
+			for _, page := range pages {
+				ids = append(ids, page.ID)
+			}
+
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestBlocksIterator(t *testing.T) {
+	mockHTTPServer := httptest.NewServer(newMultiPageHandler(t, http.MethodGet, "/v1/blocks/parent/children", [][]string{
+		{blockJSON("1")},
+		{blockJSON("2")},
+	}))
+	defer mockHTTPServer.Close()
+
+	sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+	blocks, err := sut.Blocks().Children().ListAll(context.Background(), BlocksChildrenListParameters{BlockID: "parent"}).Collect(0)
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 2)
+	assert.Equal(t, "2", blocks[1].(*UnsupportedBlock).ID)
+}
+
+func TestSearchIterator(t *testing.T) {
+	mockHTTPServer := httptest.NewServer(newMultiPageHandler(t, http.MethodPost, "/v1/search", [][]string{
+		{pageJSON("1"), databaseJSON("2")},
+		{pageJSON("3")},
+	}))
+	defer mockHTTPServer.Close()
+
+	sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+	objects, err := sut.SearchAll(context.Background(), SearchParameters{}).Collect(0)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 3)
+	assert.IsType(t, &Database{}, objects[1])
+	assert.Equal(t, "3", objects[2].(*Page).ID)
+}
+
+func TestPagerStopsOnError(t *testing.T) {
+	mockHTTPServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Query().Get("start_cursor") == "" {
+			writer.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(writer, `{"object": "list", "results": [%s], "next_cursor": "cursor-1", "has_more": true}`, userJSON("1"))
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte(`{"object": "error", "code": "validation_error", "message": "invalid cursor"}`))
+	}))
+	defer mockHTTPServer.Close()
+
+	sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+	users, err := sut.Users().ListAll(context.Background(), UsersListParameters{}).Collect(0)
+	assert.Len(t, users, 1)
+	assert.ErrorIs(t, err, ErrValidationError)
+}
+
+func TestPagerStopsOnContextCancellation(t *testing.T) {
+	requests := 0
+
+	mockHTTPServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+
+		writer.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(writer, `{"object": "list", "results": [%s], "next_cursor": "next", "has_more": true}`, userJSON("1"))
+	}))
+	defer mockHTTPServer.Close()
+
+	sut := New("token", WithBaseURL(mockHTTPServer.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	iterator := sut.Users().ListAll(ctx, UsersListParameters{})
+
+	assert.True(t, iterator.Next())
+	assert.True(t, iterator.Next())
+
+	cancel()
+
+	assert.False(t, iterator.Next())
+	assert.ErrorIs(t, iterator.Err(), context.Canceled)
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,1036 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned when mapping property values to and from structs, e.g. errors.Is(err, ErrPropertyTypeMismatch).
+var (
+	ErrInvalidTarget         = errors.New("invalid target")
+	ErrInvalidPropertyTag    = errors.New("invalid property tag")
+	ErrPropertyTypeMismatch  = errors.New("property type mismatch")
+	ErrUnsupportedFieldType  = errors.New("unsupported field type")
+	ErrReadOnlyPropertyValue = errors.New("read only property value")
+	ErrSchemaMismatch        = errors.New("schema mismatch")
+)
+
+// dateLayout is the layout of the dates without time.
+const dateLayout = "2006-01-02"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	dateType          = reflect.TypeOf(Date{})
+	richTextsType     = reflect.TypeOf([]RichText{})
+	propertyValueType = reflect.TypeOf((*PropertyValue)(nil)).Elem()
+)
+
+// propertyField is a field of a struct mapped to a property.
+type propertyField struct {
+	// Name of the field in the struct.
+	field string
+	// Index of the field for reflect.Value.FieldByIndex.
+	index []int
+	// Name of the property.
+	name string
+	// Type of the property, from the tag or inferred from the type of the field.
+	propertyType PropertyValueType
+	// Whether the type of the property is set in the tag.
+	explicitType bool
+	// Whether the property is left out by MarshalProperties when the field has a zero value.
+	omitEmpty bool
+	// Type of the field.
+	typ reflect.Type
+}
+
+var propertyFieldsCache sync.Map // map[reflect.Type][]propertyField
+
+// UnmarshalPage stores the property values of the page in the struct pointed to by v, see UnmarshalProperties.
+func UnmarshalPage(page Page, v interface{}) error {
+	return UnmarshalProperties(page.Properties, v)
+}
+
+// UnmarshalProperties stores property values in the struct pointed to by v. The fields of the struct are mapped
+// to properties with tags such as `notion:"Price,number"`, where the type of the property is optional and
+// checked against the property values when given. Names containing commas are quoted with single quotes, e.g.
+// `notion:"'Size, in points',number"`. Only tagged fields are mapped, and a field whose property
+// is missing is left unchanged. Fields can be of the following types, or pointers to them:
+//
+//   - string for titles and rich texts (plain text), selects (option name), URLs, emails, phone numbers,
+//     dates (start), created by and last edited by (user ID), and formulas of strings;
+//   - []RichText for titles and rich texts;
+//   - integers and floats for numbers, formulas and rollups of numbers;
+//   - bool for checkboxes and formulas of booleans;
+//   - time.Time and Date for dates, created and last edited times, formulas and rollups of dates;
+//   - []string (or slices of any string type) for multi selects (option names), people (user IDs),
+//     relations (page IDs) and files (names);
+//   - PropertyValue, or the type of the property value itself, e.g. SelectPropertyValue.
+func UnmarshalProperties(properties map[string]PropertyValue, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected a non-nil pointer to a struct, got %T", ErrInvalidTarget, v)
+	}
+
+	fields, err := propertyFields(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		value, ok := properties[field.name]
+		if !ok || value == nil {
+			continue
+		}
+
+		value = derefPropertyValue(value)
+
+		if field.explicitType && field.propertyType != propertyValueTypeOf(value) {
+			return fmt.Errorf("%w: field %s expects a %s property but %q is a %s property",
+				ErrPropertyTypeMismatch, field.field, field.propertyType, field.name, propertyValueTypeOf(value))
+		}
+
+		if err := decodePropertyValue(value, rv.Elem().FieldByIndex(field.index)); err != nil {
+			return fmt.Errorf("failed to unmarshal property %q into field %s: %w", field.name, field.field, err)
+		}
+	}
+
+	return nil
+}
+
+// MarshalProperties returns the property values of a struct, or a pointer to a struct, tagged as described in
+// UnmarshalProperties, e.g. for PagesCreateParameters.Properties. Nil pointers, fields tagged with omitempty
+// (e.g. `notion:"Price,number,omitempty"`) holding a zero value, zero times and empty select names are left out,
+// and so are the properties which cannot be written: formulas, rollups, files, created and last edited times
+// and users.
+func MarshalProperties(v interface{}) (map[string]PropertyValue, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: expected a struct, got %T", ErrInvalidTarget, v)
+	}
+
+	fields, err := propertyFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]PropertyValue, len(fields))
+
+	for _, field := range fields {
+		fv := rv.FieldByIndex(field.index)
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		value, err := encodePropertyValue(field.propertyType, fv)
+
+		switch {
+		case errors.Is(err, ErrReadOnlyPropertyValue):
+			continue
+
+		case err != nil:
+			return nil, fmt.Errorf("failed to marshal field %s into property %q: %w", field.field, field.name, err)
+
+		case value != nil:
+			properties[field.name] = value
+		}
+	}
+
+	return properties, nil
+}
+
+// SchemaMismatchError lists every field of a struct which does not match the properties of a database.
+type SchemaMismatchError struct {
+	// Identifier of the database.
+	DatabaseID string
+	// Type of the struct.
+	Type reflect.Type
+	// Description of each mismatch, prefixed by the name of the field.
+	Mismatches []string
+}
+
+func (e *SchemaMismatchError) Error() string {
+	return fmt.Sprintf("%s does not match the properties of database %s: %s",
+		e.Type, e.DatabaseID, strings.Join(e.Mismatches, "; "))
+}
+
+// Is reports whether the target is ErrSchemaMismatch.
+func (e *SchemaMismatchError) Is(target error) bool {
+	return target == ErrSchemaMismatch // nolint: errorlint
+}
+
+// ValidateStruct checks that every field of the struct, or pointer to a struct, tagged as described in
+// UnmarshalProperties matches a property of the database: the property must exist, be of the type set in
+// the tag if any, and its values must fit in the field. All the mismatches are reported by a
+// *SchemaMismatchError.
+func ValidateStruct(database *Database, v interface{}) error {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected a struct, got %T", ErrInvalidTarget, v)
+	}
+
+	fields, err := propertyFields(t)
+	if err != nil {
+		return err
+	}
+
+	var mismatches []string
+
+	for _, field := range fields {
+		property, ok := database.Properties[field.name]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s: no property %q", field.field, field.name))
+
+			continue
+		}
+
+		propertyType := propertyValueTypeOfProperty(property)
+
+		switch {
+		case field.explicitType && field.propertyType != propertyType:
+			mismatches = append(mismatches, fmt.Sprintf("%s: property %q is a %s property, not a %s property",
+				field.field, field.name, propertyType, field.propertyType))
+
+		case !fieldAccepts(field.typ, propertyType):
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s cannot hold the values of the %s property %q",
+				field.field, field.typ, propertyType, field.name))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return &SchemaMismatchError{DatabaseID: database.ID, Type: t, Mismatches: mismatches}
+	}
+
+	return nil
+}
+
+// propertyValueTypeOfProperty returns the type of the values of a property.
+func propertyValueTypeOfProperty(property Property) PropertyValueType {
+	return PropertyValueType(property.propertyType())
+}
+
+// fieldAccepts reports whether a field of the given type can hold the values of a property, see
+// UnmarshalProperties.
+// nolint: cyclop
+func fieldAccepts(t reflect.Type, propertyType PropertyValueType) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Implements(propertyValueType) {
+		return t.Kind() == reflect.Interface ||
+			propertyValueTypeOf(reflect.Zero(t).Interface().(PropertyValue)) == propertyType // nolint: forcetypeassert
+	}
+
+	isTime := t == timeType || t == dateType
+	isString := t.Kind() == reflect.String
+	isStrings := t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String
+	isNumber := reflect.Int <= t.Kind() && t.Kind() <= reflect.Float64 && t.Kind() != reflect.Uintptr
+
+	switch propertyType {
+	case PropertyValueTypeTitle, PropertyValueTypeRichText:
+		return isString || t == richTextsType
+
+	case PropertyValueTypeNumber:
+		return isNumber
+
+	case PropertyValueTypeSelect, PropertyValueTypeURL, PropertyValueTypeEmail, PropertyValueTypePhoneNumber,
+		PropertyValueTypeCreatedBy, PropertyValueTypeLastEditedBy:
+		return isString
+
+	case PropertyValueTypeMultiSelect, PropertyValueTypePeople, PropertyValueTypeRelation, PropertyValueTypeFiles:
+		return isStrings
+
+	case PropertyValueTypeDate, PropertyValueTypeCreatedTime, PropertyValueTypeLastEditedTime:
+		return isTime || isString
+
+	case PropertyValueTypeCheckbox:
+		return t.Kind() == reflect.Bool
+
+	case PropertyValueTypeFormula:
+		return isString || isNumber || isTime || t.Kind() == reflect.Bool
+
+	case PropertyValueTypeRollup:
+		return isNumber || isTime
+	}
+
+	return false
+}
+
+// propertyFields returns the fields of the struct type mapped to properties.
+func propertyFields(t reflect.Type) ([]propertyField, error) {
+	if fields, ok := propertyFieldsCache.Load(t); ok {
+		return fields.([]propertyField), nil // nolint: forcetypeassert
+	}
+
+	fields, err := collectPropertyFields(t, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	propertyFieldsCache.Store(t, fields)
+
+	return fields, nil
+}
+
+func collectPropertyFields(t reflect.Type, index []int) ([]propertyField, error) {
+	var fields []propertyField
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("notion")
+
+		// Untagged embedded structs are flattened like with encoding/json.
+		if !tagged && sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			embedded, err := collectPropertyFields(sf.Type, append(append([]int{}, index...), i))
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, embedded...)
+
+			continue
+		}
+
+		if !tagged || tag == "-" || sf.PkgPath != "" {
+			continue
+		}
+
+		field, err := parsePropertyTag(sf, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		field.index = append(append([]int{}, index...), i)
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func parsePropertyTag(sf reflect.StructField, tag string) (propertyField, error) {
+	name, options, ok := splitPropertyTag(tag)
+	if !ok {
+		return propertyField{}, fmt.Errorf("%w: field %s has an unterminated quoted property name", ErrInvalidPropertyTag, sf.Name)
+	}
+
+	field := propertyField{field: sf.Name, name: name, typ: sf.Type}
+	if field.name == "" {
+		return field, fmt.Errorf("%w: field %s has no property name", ErrInvalidPropertyTag, sf.Name)
+	}
+
+	for _, option := range options {
+		switch option {
+		case "":
+		case "omitempty":
+			field.omitEmpty = true
+
+		default:
+			if field.propertyType != "" {
+				return field, fmt.Errorf("%w: field %s has several property types", ErrInvalidPropertyTag, sf.Name)
+			}
+
+			field.propertyType = PropertyValueType(option)
+			field.explicitType = true
+		}
+	}
+
+	if field.propertyType == "" {
+		field.propertyType = inferPropertyValueType(sf.Type)
+	}
+
+	return field, nil
+}
+
+// splitPropertyTag splits a tag into the name of the property and the options. A name quoted with single quotes,
+// e.g. 'Size, in points', can contain commas, and single quotes written twice.
+func splitPropertyTag(tag string) (string, []string, bool) {
+	if !strings.HasPrefix(tag, "'") {
+		parts := strings.Split(tag, ",")
+
+		return parts[0], parts[1:], true
+	}
+
+	var name strings.Builder
+
+	for i := 1; i < len(tag); i++ {
+		switch {
+		case tag[i] != '\'':
+			name.WriteByte(tag[i])
+
+		case i+1 < len(tag) && tag[i+1] == '\'':
+			name.WriteByte('\'')
+			i++
+
+		case i+1 == len(tag):
+			return name.String(), nil, true
+
+		case tag[i+1] == ',':
+			return name.String(), strings.Split(tag[i+2:], ","), true
+
+		default:
+			return "", nil, false
+		}
+	}
+
+	return "", nil, false
+}
+
+// inferPropertyValueType returns the type of the property of a field whose tag has no type, or an empty type
+// if the field can hold several types of properties.
+func inferPropertyValueType(t reflect.Type) PropertyValueType {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType || t == dateType:
+		return PropertyValueTypeDate
+
+	case t.Implements(propertyValueType) && t.Kind() != reflect.Interface:
+		return propertyValueTypeOf(reflect.Zero(t).Interface().(PropertyValue)) // nolint: forcetypeassert
+	}
+
+	switch t.Kind() { // nolint: exhaustive
+	case reflect.String:
+		return PropertyValueTypeRichText
+
+	case reflect.Bool:
+		return PropertyValueTypeCheckbox
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return PropertyValueTypeNumber
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return PropertyValueTypeMultiSelect
+		}
+	}
+
+	return ""
+}
+
+// propertyValueTypeOf returns the type of a property value from its Go type, so that it does not depend on
+// the Type field which is not set on property values built locally.
+// nolint: cyclop
+func propertyValueTypeOf(value PropertyValue) PropertyValueType {
+	switch derefPropertyValue(value).(type) {
+	case TitlePropertyValue:
+		return PropertyValueTypeTitle
+	case RichTextPropertyValue:
+		return PropertyValueTypeRichText
+	case NumberPropertyValue:
+		return PropertyValueTypeNumber
+	case SelectPropertyValue:
+		return PropertyValueTypeSelect
+	case MultiSelectPropertyValue:
+		return PropertyValueTypeMultiSelect
+	case DatePropertyValue:
+		return PropertyValueTypeDate
+	case FormulaPropertyValue:
+		return PropertyValueTypeFormula
+	case RelationPropertyValue:
+		return PropertyValueTypeRelation
+	case RollupPropertyValue:
+		return PropertyValueTypeRollup
+	case PeoplePropertyValue:
+		return PropertyValueTypePeople
+	case FilesPropertyValue:
+		return PropertyValueTypeFiles
+	case CheckboxPropertyValue:
+		return PropertyValueTypeCheckbox
+	case URLPropertyValue:
+		return PropertyValueTypeURL
+	case EmailPropertyValue:
+		return PropertyValueTypeEmail
+	case PhoneNumberPropertyValue:
+		return PropertyValueTypePhoneNumber
+	case CreatedTimePropertyValue:
+		return PropertyValueTypeCreatedTime
+	case CreatedByPropertyValue:
+		return PropertyValueTypeCreatedBy
+	case LastEditedTimePropertyValue:
+		return PropertyValueTypeLastEditedTime
+	case LastEditedByPropertyValue:
+		return PropertyValueTypeLastEditedBy
+	}
+
+	return ""
+}
+
+// derefPropertyValue returns the property value pointed by value if it is a pointer, so that property values
+// decoded from the API (pointers) and built locally (values) are handled the same way.
+func derefPropertyValue(value PropertyValue) PropertyValue {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return value
+	}
+
+	if v, ok := rv.Elem().Interface().(PropertyValue); ok {
+		return v
+	}
+
+	return value
+}
+
+// decodePropertyValue stores the property value in the field.
+func decodePropertyValue(value PropertyValue, field reflect.Value) error {
+	// The property value itself.
+	if reflect.TypeOf(value).AssignableTo(field.Type()) {
+		field.Set(reflect.ValueOf(value))
+
+		return nil
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if reflect.TypeOf(value) == field.Type().Elem() {
+			ptr := reflect.New(field.Type().Elem())
+			ptr.Elem().Set(reflect.ValueOf(value))
+			field.Set(ptr)
+
+			return nil
+		}
+
+		plain, err := plainPropertyValue(value, field.Type().Elem())
+		if err != nil {
+			return err
+		}
+
+		if plain == nil {
+			field.Set(reflect.Zero(field.Type()))
+
+			return nil
+		}
+
+		ptr := reflect.New(field.Type().Elem())
+		if err := assignPlainValue(plain, ptr.Elem()); err != nil {
+			return err
+		}
+
+		field.Set(ptr)
+
+		return nil
+	}
+
+	plain, err := plainPropertyValue(value, field.Type())
+	if err != nil {
+		return err
+	}
+
+	if plain == nil {
+		field.Set(reflect.Zero(field.Type()))
+
+		return nil
+	}
+
+	return assignPlainValue(plain, field)
+}
+
+// plainPropertyValue returns the value of a property value as a string, a float64, a bool, a Date, a []string,
+// a []RichText or, for array rollups, a []interface{}, or nil for an empty value. The type of the field decides between several representations.
+// nolint: cyclop, funlen
+func plainPropertyValue(value PropertyValue, t reflect.Type) (interface{}, error) {
+	switch v := value.(type) {
+	case TitlePropertyValue:
+		if t == richTextsType {
+			return v.Title, nil
+		}
+
+		return PlainText(v.Title), nil
+
+	case RichTextPropertyValue:
+		if t == richTextsType {
+			return v.RichText, nil
+		}
+
+		return PlainText(v.RichText), nil
+
+	case NumberPropertyValue:
+		return v.Number, nil
+
+	case SelectPropertyValue:
+		return v.Select.Name, nil
+
+	case MultiSelectPropertyValue:
+		names := make([]string, 0, len(v.MultiSelect))
+		for _, option := range v.MultiSelect {
+			names = append(names, option.Name)
+		}
+
+		return names, nil
+
+	case DatePropertyValue:
+		if v.Date.Start == "" {
+			return nil, nil
+		}
+
+		return v.Date, nil
+
+	case FormulaPropertyValue:
+		return plainFormulaValue(v.Formula)
+
+	case RelationPropertyValue:
+		ids := make([]string, 0, len(v.Relation))
+		for _, page := range v.Relation {
+			ids = append(ids, page.ID)
+		}
+
+		return ids, nil
+
+	case RollupPropertyValue:
+		return plainRollupValue(v.Rollup, t)
+
+	case PeoplePropertyValue:
+		ids := make([]string, 0, len(v.People))
+		for _, user := range v.People {
+			ids = append(ids, userID(user))
+		}
+
+		return ids, nil
+
+	case FilesPropertyValue:
+		names := make([]string, 0, len(v.Files))
+		for _, file := range v.Files {
+			names = append(names, file.Name)
+		}
+
+		return names, nil
+
+	case CheckboxPropertyValue:
+		return v.Checkbox, nil
+
+	case URLPropertyValue:
+		return v.URL, nil
+
+	case EmailPropertyValue:
+		return v.Email, nil
+
+	case PhoneNumberPropertyValue:
+		return v.PhoneNumber, nil
+
+	case CreatedTimePropertyValue:
+		return Date{Start: v.CreatedTime.Format(time.RFC3339Nano)}, nil
+
+	case CreatedByPropertyValue:
+		return userID(v.CreatedBy), nil
+
+	case LastEditedTimePropertyValue:
+		return Date{Start: v.LastEditedTime.Format(time.RFC3339Nano)}, nil
+
+	case LastEditedByPropertyValue:
+		return userID(v.LastEditedBy), nil
+	}
+
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedFieldType, value)
+}
+
+func plainFormulaValue(formula FormulaValue) (interface{}, error) {
+	if rv := reflect.ValueOf(formula); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		formula, _ = rv.Elem().Interface().(FormulaValue)
+	}
+
+	switch v := formula.(type) {
+	case StringFormulaValue:
+		if v.String == nil {
+			return nil, nil
+		}
+
+		return *v.String, nil
+
+	case NumberFormulaValue:
+		if v.Number == nil {
+			return nil, nil
+		}
+
+		return *v.Number, nil
+
+	case BooleanFormulaValue:
+		return v.Boolean, nil
+
+	case DateFormulaValue:
+		if v.Date.Date.Start == "" {
+			return nil, nil
+		}
+
+		return v.Date.Date, nil
+	}
+
+	return nil, fmt.Errorf("%w: formula of %T", ErrUnsupportedFieldType, formula)
+}
+
+// plainRollupValue returns the value of a rollup as plainPropertyValue does, an array rollup being a
+// []interface{} holding the plain value of each of its property values.
+func plainRollupValue(rollup RollupValueType, t reflect.Type) (interface{}, error) {
+	if rv := reflect.ValueOf(rollup); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rollup, _ = rv.Elem().Interface().(RollupValueType)
+	}
+
+	switch v := rollup.(type) {
+	case NumberRollupValue:
+		if v.Number == nil {
+			return nil, nil
+		}
+
+		return *v.Number, nil
+
+	case DateRollupValue:
+		if v.Date.Start == "" {
+			return nil, nil
+		}
+
+		return v.Date, nil
+
+	case ArrayRollupValue:
+		var elem reflect.Type
+		if t != nil && t.Kind() == reflect.Slice {
+			elem = t.Elem()
+		}
+
+		values := make([]interface{}, 0, len(v.Array))
+
+		for _, value := range v.Array {
+			plain, err := plainPropertyValue(derefPropertyValue(value), elem)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, plain)
+		}
+
+		return values, nil
+
+	case UnsupportedRollupValue:
+		// Unsupported and incomplete rollups have no value.
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("%w: rollup of %T", ErrUnsupportedFieldType, rollup)
+}
+
+// assignPlainValue stores a value returned by plainPropertyValue in the field, converting it to the type
+// of the field.
+// nolint: cyclop
+func assignPlainValue(plain interface{}, field reflect.Value) error {
+	switch v := plain.(type) {
+	case Date:
+		switch {
+		case field.Type() == dateType:
+			field.Set(reflect.ValueOf(v))
+
+			return nil
+
+		case field.Type() == timeType:
+			t, err := parseDate(v.Start)
+			if err != nil {
+				return err
+			}
+
+			field.Set(reflect.ValueOf(t))
+
+			return nil
+
+		case field.Kind() == reflect.String:
+			field.SetString(v.Start)
+
+			return nil
+		}
+
+	case float64:
+		switch field.Kind() { // nolint: exhaustive
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(v)
+
+			return nil
+
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			field.SetInt(int64(v))
+
+			return nil
+
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			field.SetUint(uint64(v))
+
+			return nil
+		}
+
+	case []string:
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String {
+			slice := reflect.MakeSlice(field.Type(), len(v), len(v))
+			for i, s := range v {
+				slice.Index(i).SetString(s)
+			}
+
+			field.Set(slice)
+
+			return nil
+		}
+
+	case []interface{}:
+		if field.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(field.Type(), len(v), len(v))
+
+			for i, value := range v {
+				switch {
+				case value == nil:
+				case reflect.TypeOf(value).AssignableTo(field.Type().Elem()):
+					slice.Index(i).Set(reflect.ValueOf(value))
+				default:
+					if err := assignPlainValue(value, slice.Index(i)); err != nil {
+						return err
+					}
+				}
+			}
+
+			field.Set(slice)
+
+			return nil
+		}
+
+	default:
+		if rv := reflect.ValueOf(plain); rv.Type().ConvertibleTo(field.Type()) && rv.Kind() == field.Kind() {
+			field.Set(rv.Convert(field.Type()))
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: cannot store %T in %s", ErrUnsupportedFieldType, plain, field.Type())
+}
+
+// encodePropertyValue returns the property value of the given type holding the value of the field.
+// nolint: cyclop, funlen
+func encodePropertyValue(propertyType PropertyValueType, field reflect.Value) (PropertyValue, error) {
+	switch propertyType { // nolint: exhaustive
+	case PropertyValueTypeFormula, PropertyValueTypeRollup, PropertyValueTypeFiles,
+		PropertyValueTypeCreatedTime, PropertyValueTypeCreatedBy,
+		PropertyValueTypeLastEditedTime, PropertyValueTypeLastEditedBy:
+		// Also left out when the field holds the property value, e.g. FormulaPropertyValue.
+		return nil, ErrReadOnlyPropertyValue
+	}
+
+	if field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return nil, nil
+		}
+
+		if value, ok := field.Interface().(PropertyValue); ok {
+			return value, nil
+		}
+
+		field = field.Elem()
+	}
+
+	if value, ok := field.Interface().(PropertyValue); ok {
+		return value, nil
+	}
+
+	base := basePropertyValue{Type: propertyType}
+
+	switch propertyType {
+	case PropertyValueTypeTitle:
+		text, err := encodeRichTexts(field)
+
+		return TitlePropertyValue{basePropertyValue: base, Title: text}, err
+
+	case PropertyValueTypeRichText:
+		text, err := encodeRichTexts(field)
+
+		return RichTextPropertyValue{basePropertyValue: base, RichText: text}, err
+
+	case PropertyValueTypeNumber:
+		number, err := encodeNumber(field)
+
+		return NumberPropertyValue{basePropertyValue: base, Number: number}, err
+
+	case PropertyValueTypeSelect:
+		if field.Kind() != reflect.String {
+			break
+		}
+
+		if field.String() == "" {
+			return nil, nil
+		}
+
+		return SelectPropertyValue{basePropertyValue: base, Select: SelectPropertyValueOption{Name: field.String()}}, nil
+
+	case PropertyValueTypeMultiSelect:
+		names, err := encodeStrings(field)
+		options := make([]MultiSelectPropertyValueOption, 0, len(names))
+
+		for _, name := range names {
+			options = append(options, MultiSelectPropertyValueOption{Name: name})
+		}
+
+		return MultiSelectPropertyValue{basePropertyValue: base, MultiSelect: options}, err
+
+	case PropertyValueTypeDate:
+		date, err := encodeDate(field)
+		if err != nil || date.Start == "" {
+			return nil, err
+		}
+
+		return DatePropertyValue{basePropertyValue: base, Date: date}, nil
+
+	case PropertyValueTypeRelation:
+		ids, err := encodeStrings(field)
+		pages := make([]PageReference, 0, len(ids))
+
+		for _, id := range ids {
+			pages = append(pages, PageReference{ID: id})
+		}
+
+		return RelationPropertyValue{basePropertyValue: base, Relation: pages}, err
+
+	case PropertyValueTypePeople:
+		ids, err := encodeStrings(field)
+		people := make([]User, 0, len(ids))
+
+		for _, id := range ids {
+			people = append(people, PartialUser{Object: ObjectTypeUser, ID: id})
+		}
+
+		return PeoplePropertyValue{basePropertyValue: base, People: people}, err
+
+	case PropertyValueTypeCheckbox:
+		if field.Kind() == reflect.Bool {
+			return CheckboxPropertyValue{basePropertyValue: base, Checkbox: field.Bool()}, nil
+		}
+
+	case PropertyValueTypeURL:
+		if field.Kind() == reflect.String {
+			return URLPropertyValue{basePropertyValue: base, URL: field.String()}, nil
+		}
+
+	case PropertyValueTypeEmail:
+		if field.Kind() == reflect.String {
+			return EmailPropertyValue{basePropertyValue: base, Email: field.String()}, nil
+		}
+
+	case PropertyValueTypePhoneNumber:
+		if field.Kind() == reflect.String {
+			return PhoneNumberPropertyValue{basePropertyValue: base, PhoneNumber: field.String()}, nil
+		}
+
+	case "":
+		return nil, fmt.Errorf("%w: the type of the property of %s must be set in its tag", ErrUnsupportedFieldType, field.Type())
+
+	default:
+		return nil, fmt.Errorf("%w: unknown property type %q", ErrInvalidPropertyTag, propertyType)
+	}
+
+	return nil, fmt.Errorf("%w: cannot store %s in a %s property", ErrUnsupportedFieldType, field.Type(), propertyType)
+}
+
+func encodeRichTexts(field reflect.Value) ([]RichText, error) {
+	switch {
+	case field.Type() == richTextsType:
+		return richTexts(field.Interface().([]RichText)), nil // nolint: forcetypeassert
+
+	case field.Kind() == reflect.String:
+		return []RichText{Text(field.String())}, nil
+	}
+
+	return nil, fmt.Errorf("%w: cannot store %s in a text property", ErrUnsupportedFieldType, field.Type())
+}
+
+func encodeNumber(field reflect.Value) (float64, error) {
+	switch field.Kind() { // nolint: exhaustive
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int()), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(field.Uint()), nil
+	}
+
+	return 0, fmt.Errorf("%w: cannot store %s in a number property", ErrUnsupportedFieldType, field.Type())
+}
+
+func encodeStrings(field reflect.Value) ([]string, error) {
+	if field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.String {
+		return nil, fmt.Errorf("%w: expected a slice of strings, got %s", ErrUnsupportedFieldType, field.Type())
+	}
+
+	values := make([]string, 0, field.Len())
+	for i := 0; i < field.Len(); i++ {
+		values = append(values, field.Index(i).String())
+	}
+
+	return values, nil
+}
+
+// encodeDate returns the date of a time.Time, a Date or a string. A time at midnight UTC is encoded as a date
+// without time.
+func encodeDate(field reflect.Value) (Date, error) {
+	switch {
+	case field.Type() == dateType:
+		return field.Interface().(Date), nil // nolint: forcetypeassert
+
+	case field.Type() == timeType:
+		t := field.Interface().(time.Time) // nolint: forcetypeassert
+		if t.IsZero() {
+			return Date{}, nil
+		}
+
+		if t.Location() == time.UTC && t.Equal(t.Truncate(24*time.Hour)) {
+			return Date{Start: t.Format(dateLayout)}, nil
+		}
+
+		return Date{Start: t.Format(time.RFC3339Nano)}, nil
+
+	case field.Kind() == reflect.String:
+		return Date{Start: field.String()}, nil
+	}
+
+	return Date{}, fmt.Errorf("%w: cannot store %s in a date property", ErrUnsupportedFieldType, field.Type())
+}
+
+// parseDate parses a date of the API, with or without time. A date without time is parsed as midnight UTC.
+func parseDate(value string) (time.Time, error) {
+	layout := time.RFC3339Nano
+	if len(value) == len(dateLayout) {
+		layout = dateLayout
+	}
+
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse date %q: %w", value, err)
+	}
+
+	return t, nil
+}
+
+// userID returns the ID of a user, whatever its type.
+func userID(user User) string {
+	switch u := user.(type) {
+	case *PersonUser:
+		return u.ID
+	case PersonUser:
+		return u.ID
+	case *BotUser:
+		return u.ID
+	case BotUser:
+		return u.ID
+	case *PartialUser:
+		return u.ID
+	case PartialUser:
+		return u.ID
+	}
+
+	return ""
+}
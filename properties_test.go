@@ -0,0 +1,357 @@
+package notion
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type taskStatus string
+
+type taskBase struct {
+	Name string `notion:"Name,title"`
+}
+
+type task struct {
+	taskBase
+	Price       float64       `notion:"Price,number"`
+	Quantity    *int          `notion:"Quantity,number"`
+	Status      taskStatus    `notion:"Status,select"`
+	Tags        []string      `notion:"Tags,multi_select"`
+	Due         time.Time     `notion:"Due,date"`
+	Done        bool          `notion:"Done"`
+	Website     string        `notion:"Website,url"`
+	Assignees   []string      `notion:"Assignees,people"`
+	Related     []string      `notion:"Related,relation"`
+	Description []RichText    `notion:"Description,rich_text"`
+	Total       float64       `notion:"Total,formula"`
+	CreatedBy   string        `notion:"Created by,created_by"`
+	Created     time.Time     `notion:"Created,created_time"`
+	Raw         PropertyValue `notion:"Status"`
+	Ignored     string
+	Skipped     string `notion:"-"`
+}
+
+const taskPageJSON = `{
+	"object": "page",
+	"id": "251d2b5f-268c-4de2-afe9-c71ff92ca95c",
+	"properties": {
+		"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "Tuscan "}, "plain_text": "Tuscan "}, {"type": "text", "text": {"content": "kale"}, "plain_text": "kale"}]},
+		"Price": {"id": "BJXS", "type": "number", "number": 2.5},
+		"Quantity": {"id": "qQty", "type": "number", "number": 3},
+		"Status": {"id": "bSta", "type": "select", "select": {"id": "a", "name": "Done", "color": "green"}},
+		"Tags": {"id": "cTag", "type": "multi_select", "multi_select": [{"id": "b", "name": "Vegetable", "color": "green"}, {"id": "c", "name": "Leafy", "color": "blue"}]},
+		"Due": {"id": "dDue", "type": "date", "date": {"start": "2021-05-11", "end": null}},
+		"Done": {"id": "eDon", "type": "checkbox", "checkbox": true},
+		"Website": {"id": "fWeb", "type": "url", "url": "https://example.com"},
+		"Assignees": {"id": "gAss", "type": "people", "people": [{"object": "user", "id": "6794760a-1f15-45cd-9c65-0dfe42f5135a"}]},
+		"Related": {"id": "hRel", "type": "relation", "relation": [{"id": "3c357473-a281-49a4-88c0-10d2b245a589"}]},
+		"Description": {"id": "iDes", "type": "rich_text", "rich_text": [{"type": "text", "text": {"content": "Leafy"}, "plain_text": "Leafy"}]},
+		"Total": {"id": "jTot", "type": "formula", "formula": {"type": "number", "number": 7.5}},
+		"Created by": {"id": "kCre", "type": "created_by", "created_by": {"object": "user", "id": "92a680bb-6970-4726-952b-4f4c03bff617", "type": "person", "name": "Aria", "person": {"email": "aria@example.com"}}},
+		"Created": {"id": "lCre", "type": "created_time", "created_time": "2021-05-10T02:25:00.000Z"}
+	}
+}`
+
+func TestUnmarshalPage(t *testing.T) {
+	var page Page
+
+	assert.NoError(t, json.Unmarshal([]byte(taskPageJSON), &page))
+
+	var got task
+
+	assert.NoError(t, UnmarshalPage(page, &got))
+
+	quantity := 3
+
+	assert.Equal(t, task{
+		taskBase:    taskBase{Name: "Tuscan kale"},
+		Price:       2.5,
+		Quantity:    &quantity,
+		Status:      "Done",
+		Tags:        []string{"Vegetable", "Leafy"},
+		Due:         time.Date(2021, 5, 11, 0, 0, 0, 0, time.UTC),
+		Done:        true,
+		Website:     "https://example.com",
+		Assignees:   []string{"6794760a-1f15-45cd-9c65-0dfe42f5135a"},
+		Related:     []string{"3c357473-a281-49a4-88c0-10d2b245a589"},
+		Description: page.Properties["Description"].(*RichTextPropertyValue).RichText,
+		Total:       7.5,
+		CreatedBy:   "92a680bb-6970-4726-952b-4f4c03bff617",
+		Created:     time.Date(2021, 5, 10, 2, 25, 0, 0, time.UTC),
+		Raw: SelectPropertyValue{
+			basePropertyValue: basePropertyValue{ID: "bSta", Type: PropertyValueTypeSelect},
+			Select:            SelectPropertyValueOption{ID: "a", Name: "Done", Color: ColorGreen},
+		},
+	}, got)
+}
+
+func TestUnmarshalProperties_Values(t *testing.T) {
+	kale, number, budget := "kale", 7.5, 1250.5
+
+	var got struct {
+		Label  string    `notion:"Label,formula"`
+		Total  float64   `notion:"Total,formula"`
+		Urgent bool      `notion:"Urgent,formula"`
+		Due    time.Time `notion:"Due,formula"`
+		Budget float64   `notion:"Budget,rollup"`
+		Next   time.Time `notion:"Next,rollup"`
+		Tasks  []string  `notion:"Tasks,rollup"`
+	}
+
+	// Values built locally rather than decoded from the API.
+	err := UnmarshalProperties(map[string]PropertyValue{
+		"Label":  FormulaPropertyValue{Formula: StringFormulaValue{String: &kale}},
+		"Total":  FormulaPropertyValue{Formula: NumberFormulaValue{Number: &number}},
+		"Urgent": FormulaPropertyValue{Formula: BooleanFormulaValue{Boolean: true}},
+		"Due":    FormulaPropertyValue{Formula: DateFormulaValue{Date: DatePropertyValue{Date: Date{Start: "2021-05-11"}}}},
+		"Budget": RollupPropertyValue{Rollup: NumberRollupValue{Number: &budget}},
+		"Next":   RollupPropertyValue{Rollup: DateRollupValue{Date: Date{Start: "2021-05-24"}}},
+		"Tasks": RollupPropertyValue{Rollup: ArrayRollupValue{Array: []PropertyValue{
+			TitlePropertyValue{Title: []RichText{Text("Buy kale")}},
+			TitlePropertyValue{Title: []RichText{Text("Cook kale")}},
+		}}},
+	}, &got)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "kale", got.Label)
+	assert.Equal(t, 7.5, got.Total)
+	assert.True(t, got.Urgent)
+	assert.Equal(t, time.Date(2021, 5, 11, 0, 0, 0, 0, time.UTC), got.Due)
+	assert.Equal(t, 1250.5, got.Budget)
+	assert.Equal(t, time.Date(2021, 5, 24, 0, 0, 0, 0, time.UTC), got.Next)
+	assert.Equal(t, []string{"Buy kale", "Cook kale"}, got.Tasks)
+}
+
+func TestUnmarshalPage_Rollups(t *testing.T) {
+	var got struct {
+		TotalBudget *float64      `notion:"Total budget,rollup"`
+		NextDue     string        `notion:"Next due,rollup"`
+		TaskNames   []interface{} `notion:"Task names,rollup"`
+		Owners      []string      `notion:"Owners,rollup"`
+	}
+
+	got.Owners = []string{"stale"}
+
+	assert.NoError(t, UnmarshalPage(readFixturePage(t), &got))
+
+	if assert.NotNil(t, got.TotalBudget) {
+		assert.Equal(t, 1250.5, *got.TotalBudget)
+	}
+
+	assert.Equal(t, "2021-05-24", got.NextDue)
+	assert.Equal(t, []interface{}{"Buy kale", 3.0}, got.TaskNames)
+	assert.Nil(t, got.Owners)
+
+	var null struct {
+		TotalBudget *float64 `notion:"Total budget,rollup"`
+	}
+
+	err := UnmarshalProperties(map[string]PropertyValue{
+		"Total budget": &RollupPropertyValue{Rollup: &NumberRollupValue{}},
+	}, &null)
+	assert.NoError(t, err)
+	assert.Nil(t, null.TotalBudget)
+}
+
+func TestUnmarshalProperties_Errors(t *testing.T) {
+	type args struct {
+		properties map[string]PropertyValue
+		v          interface{}
+	}
+
+	type wants struct {
+		err error
+	}
+
+	type test struct {
+		name  string
+		args  args
+		wants wants
+	}
+
+	tests := []test{
+		{
+			name:  "Not a pointer",
+			args:  args{v: task{}},
+			wants: wants{err: ErrInvalidTarget},
+		},
+		{
+			name: "Property type mismatch",
+			args: args{
+				properties: map[string]PropertyValue{"Price": &RichTextPropertyValue{}},
+				v:          &task{},
+			},
+			wants: wants{err: ErrPropertyTypeMismatch},
+		},
+		{
+			name: "Unsupported field type",
+			args: args{
+				properties: map[string]PropertyValue{"Price": &NumberPropertyValue{Number: 1}},
+				v: &struct {
+					Price []string `notion:"Price"`
+				}{},
+			},
+			wants: wants{err: ErrUnsupportedFieldType},
+		},
+		{
+			name: "Invalid tag",
+			args: args{
+				v: &struct {
+					Price float64 `notion:",number"`
+				}{},
+			},
+			wants: wants{err: ErrInvalidPropertyTag},
+		},
+		{
+			name: "Unterminated quoted name",
+			args: args{
+				v: &struct {
+					Size float64 `notion:"'Size, in points,number"`
+				}{},
+			},
+			wants: wants{err: ErrInvalidPropertyTag},
+		},
+		{
+			name: "Text after quoted name",
+			args: args{
+				v: &struct {
+					Size float64 `notion:"'Size, in' points,number"`
+				}{},
+			},
+			wants: wants{err: ErrInvalidPropertyTag},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, UnmarshalProperties(tt.args.properties, tt.args.v), tt.wants.err)
+		})
+	}
+}
+
+func TestProperties_QuotedNames(t *testing.T) {
+	type sizes struct {
+		Size  float64 `notion:"'Size, in points',number"`
+		Note  string  `notion:"'Kale''s note',rich_text,omitempty"`
+		Dash  bool    `notion:"'-'"`
+		Plain string  `notion:"Plain"`
+	}
+
+	properties, err := MarshalProperties(sizes{Size: 12, Note: "Curly", Dash: true, Plain: "Lacinato"})
+	assert.NoError(t, err)
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	assert.ElementsMatch(t, []string{"Size, in points", "Kale's note", "-", "Plain"}, names)
+
+	var got sizes
+
+	assert.NoError(t, UnmarshalProperties(properties, &got))
+	assert.Equal(t, sizes{Size: 12, Note: "Curly", Dash: true, Plain: "Lacinato"}, got)
+}
+
+func TestMarshalProperties(t *testing.T) {
+	quantity := 3
+
+	properties, err := MarshalProperties(task{
+		taskBase:  taskBase{Name: "Tuscan kale"},
+		Price:     2.5,
+		Quantity:  &quantity,
+		Status:    "Done",
+		Tags:      []string{"Vegetable"},
+		Due:       time.Date(2021, 5, 11, 0, 0, 0, 0, time.UTC),
+		Done:      true,
+		Website:   "https://example.com",
+		Assignees: []string{"6794760a-1f15-45cd-9c65-0dfe42f5135a"},
+		Related:   []string{"3c357473-a281-49a4-88c0-10d2b245a589"},
+		Total:     7.5,
+		CreatedBy: "92a680bb-6970-4726-952b-4f4c03bff617",
+		Created:   time.Now(),
+		Ignored:   "ignored",
+		Skipped:   "skipped",
+	})
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(properties)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Name": {"type": "title", "title": [{"type": "text", "text": {"content": "Tuscan kale"}}]},
+		"Price": {"type": "number", "number": 2.5},
+		"Quantity": {"type": "number", "number": 3},
+		"Status": {"type": "select", "select": {"name": "Done"}},
+		"Tags": {"type": "multi_select", "multi_select": [{"name": "Vegetable"}]},
+		"Due": {"type": "date", "date": {"start": "2021-05-11", "end": null}},
+		"Done": {"type": "checkbox", "checkbox": true},
+		"Website": {"type": "url", "url": "https://example.com"},
+		"Assignees": {"type": "people", "people": [{"object": "user", "id": "6794760a-1f15-45cd-9c65-0dfe42f5135a"}]},
+		"Related": {"type": "relation", "relation": [{"id": "3c357473-a281-49a4-88c0-10d2b245a589"}]},
+		"Description": {"type": "rich_text", "rich_text": []}
+	}`, string(data))
+}
+
+func TestMarshalProperties_OmitEmpty(t *testing.T) {
+	type update struct {
+		Name  string               `notion:"Name,title,omitempty"`
+		Price float64              `notion:"Price,number,omitempty"`
+		Due   *time.Time           `notion:"Due"`
+		Time  time.Time            `notion:"Time"`
+		Total FormulaPropertyValue `notion:"Total,formula"`
+	}
+
+	number := 7.5
+
+	properties, err := MarshalProperties(&update{
+		Price: 1.5,
+		Time:  time.Date(2021, 5, 11, 14, 30, 0, 0, time.FixedZone("", 2*60*60)),
+		Total: FormulaPropertyValue{Formula: &NumberFormulaValue{Number: &number}},
+	})
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(properties)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Price": {"type": "number", "number": 1.5},
+		"Time": {"type": "date", "date": {"start": "2021-05-11T14:30:00+02:00", "end": null}}
+	}`, string(data))
+}
+
+func TestMarshalProperties_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		err  error
+	}{
+		{
+			name: "Not a struct",
+			v:    "kale",
+			err:  ErrInvalidTarget,
+		},
+		{
+			name: "Unknown property type",
+			v: struct {
+				Name string `notion:"Name,heading"`
+			}{},
+			err: ErrInvalidPropertyTag,
+		},
+		{
+			name: "Unsupported field type",
+			v: struct {
+				Done string `notion:"Done,checkbox"`
+			}{},
+			err: ErrUnsupportedFieldType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MarshalProperties(tt.v)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,779 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/filter"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenQuotedName
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+// is reports whether the token is the given symbol, or the given word regardless of case.
+func (t token) is(text string) bool {
+	switch t.kind { // nolint: exhaustive
+	case tokenWord:
+		return strings.EqualFold(t.text, text)
+	case tokenSymbol:
+		return t.text == text
+	}
+
+	return false
+}
+
+func (t token) String() string {
+	switch t.kind { // nolint: exhaustive
+	case tokenEOF:
+		return "end of query"
+	case tokenQuotedName:
+		return "`" + t.text + "`"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+
+	return strconv.Quote(t.text)
+}
+
+// tokenize splits the query into tokens, ending with a tokenEOF.
+// nolint: cyclop, funlen
+func tokenize(text string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+
+		case strings.ContainsRune("(),:", r):
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(r), offset: i})
+			i++
+
+		case strings.HasPrefix(text[i:], "!=") || strings.HasPrefix(text[i:], "<=") || strings.HasPrefix(text[i:], ">="):
+			tokens = append(tokens, token{kind: tokenSymbol, text: text[i : i+2], offset: i})
+			i += 2
+
+		case r == '=' || r == '<' || r == '>':
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(r), offset: i})
+			i++
+
+		case r == '"':
+			end := i + 1
+			for end < len(text) && text[end] != '"' {
+				if text[end] == '\\' {
+					end++
+				}
+
+				end++
+			}
+
+			if end >= len(text) {
+				return nil, &Error{Offset: i, Message: "unterminated string"}
+			}
+
+			s, err := strconv.Unquote(text[i : end+1])
+			if err != nil {
+				return nil, &Error{Offset: i, Message: "invalid string " + text[i:end+1]}
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: s, offset: i})
+			i = end + 1
+
+		case r == '`':
+			var name strings.Builder
+
+			end := i + 1
+
+			for ; end < len(text); end++ {
+				if text[end] == '`' {
+					// A doubled backtick stands for a backtick.
+					if end+1 < len(text) && text[end+1] == '`' {
+						name.WriteByte('`')
+						end++
+
+						continue
+					}
+
+					break
+				}
+
+				name.WriteByte(text[end])
+			}
+
+			if end >= len(text) {
+				return nil, &Error{Offset: i, Message: "unterminated property name"}
+			}
+
+			tokens = append(tokens, token{kind: tokenQuotedName, text: name.String(), offset: i})
+			i = end + 1
+
+		case unicode.IsDigit(r) || (r == '-' || r == '.') && i+1 < len(text) && isNumberStart(text[i+1]):
+			end := i + 1
+			for end < len(text) && (isNumberByte(text[end]) ||
+				(text[end] == '-' || text[end] == '+') && (text[end-1] == 'e' || text[end-1] == 'E')) {
+				end++
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, text: text[i:end], offset: i})
+			i = end
+
+		case isWordRune(r):
+			end := i
+			for end < len(text) {
+				r, size := utf8.DecodeRuneInString(text[end:])
+				if !isWordRune(r) {
+					break
+				}
+
+				end += size
+			}
+
+			tokens = append(tokens, token{kind: tokenWord, text: text[i:end], offset: i})
+			i = end
+
+		default:
+			return nil, &Error{Offset: i, Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, offset: len(text)}), nil
+}
+
+func isNumberStart(b byte) bool {
+	return '0' <= b && b <= '9' || b == '.'
+}
+
+func isNumberByte(b byte) bool {
+	return '0' <= b && b <= '9' || b == '.' || b == 'e' || b == 'E'
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// Parse parses a query, see the package documentation. When the database is not nil, the types of the filters
+// are those of its properties, and the properties must exist.
+func Parse(text string, database *notion.Database) (*Query, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens, database: database}
+
+	var q Query
+
+	if next := p.peek(); next.kind != tokenEOF && !next.is("order") {
+		condition, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		q.Filter = condition.Build()
+	}
+
+	if p.peek().is("order") {
+		if q.Sorts, err = p.parseSorts(); err != nil {
+			return nil, err
+		}
+	}
+
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, p.unexpected(next, "and, or, order by or end of query")
+	}
+
+	return &q, nil
+}
+
+type parser struct {
+	tokens   []token
+	position int
+	database *notion.Database
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.position]
+	if t.kind != tokenEOF {
+		p.position++
+	}
+
+	return t
+}
+
+func (p *parser) unexpected(t token, expected string) error {
+	return &Error{Offset: t.offset, Message: fmt.Sprintf("expected %s, got %s", expected, t)}
+}
+
+func (p *parser) expect(text, expected string) error {
+	if t := p.next(); !t.is(text) {
+		return p.unexpected(t, expected)
+	}
+
+	return nil
+}
+
+// parseOr parses conditions combined with or, whose operands are combined with and.
+func (p *parser) parseOr() (filter.Condition, error) {
+	var operands []notion.Filter
+
+	for {
+		condition, err := p.parseAnd()
+		if err != nil {
+			return filter.Condition{}, err
+		}
+
+		operands = append(operands, condition.Build())
+
+		if !p.peek().is("or") {
+			break
+		}
+
+		p.next()
+	}
+
+	if len(operands) == 1 {
+		return filter.Of(operands[0]), nil
+	}
+
+	return filter.Of(notion.CompoundFilter{Or: operands}), nil
+}
+
+func (p *parser) parseAnd() (filter.Condition, error) {
+	var operands []notion.Filter
+
+	for {
+		condition, err := p.parseOperand()
+		if err != nil {
+			return filter.Condition{}, err
+		}
+
+		operands = append(operands, condition.Build())
+
+		if !p.peek().is("and") {
+			break
+		}
+
+		p.next()
+	}
+
+	if len(operands) == 1 {
+		return filter.Of(operands[0]), nil
+	}
+
+	return filter.Of(notion.CompoundFilter{And: operands}), nil
+}
+
+func (p *parser) parseOperand() (filter.Condition, error) {
+	if !p.peek().is("(") {
+		return p.parseCondition()
+	}
+
+	p.next()
+
+	condition, err := p.parseOr()
+	if err != nil {
+		return filter.Condition{}, err
+	}
+
+	return condition, p.expect(")", "and, or or )")
+}
+
+func (p *parser) parseName() (token, error) {
+	t := p.next()
+
+	if t.kind == tokenQuotedName || t.kind == tokenWord && !isKeyword(t.text) {
+		return t, nil
+	}
+
+	return t, p.unexpected(t, "property")
+}
+
+// parseCondition parses a property, with an optional type, an operator and a value.
+func (p *parser) parseCondition() (filter.Condition, error) {
+	name, err := p.parseName()
+	if err != nil {
+		return filter.Condition{}, err
+	}
+
+	var hint token
+
+	if p.peek().is(":") {
+		p.next()
+
+		if hint = p.next(); hint.kind != tokenWord || propertyTypes[strings.ToLower(hint.text)] == "" {
+			return filter.Condition{}, p.unexpected(hint, "property type")
+		}
+	}
+
+	opToken := p.peek()
+
+	op, err := p.parseOperator()
+	if err != nil {
+		return filter.Condition{}, err
+	}
+
+	var v value
+
+	if hasValue(op) {
+		if v, err = p.parseValue(); err != nil {
+			return filter.Condition{}, err
+		}
+	}
+
+	propertyType, err := p.propertyType(name, hint, op, v)
+	if err != nil {
+		return filter.Condition{}, err
+	}
+
+	condition, err := buildCondition(filter.Prop(name.text), propertyType, op, v)
+	if err != nil {
+		return filter.Condition{}, &Error{Offset: opToken.offset, Message: fmt.Sprintf("property %q: %s", name.text, err)}
+	}
+
+	return condition, nil
+}
+
+func (p *parser) parseOperator() (string, error) {
+	t := p.peek()
+
+	if t.kind == tokenSymbol && t.text != "(" && t.text != ")" && t.text != "," && t.text != ":" {
+		p.next()
+
+		return t.text, nil
+	}
+
+	for _, op := range wordOperators {
+		words := strings.Fields(op)
+		if p.position+len(words) >= len(p.tokens) {
+			continue
+		}
+
+		matches := true
+
+		for i, word := range words {
+			if !p.tokens[p.position+i].is(word) {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			p.position += len(words)
+
+			return op, nil
+		}
+	}
+
+	return "", p.unexpected(t, "operator")
+}
+
+func (p *parser) parseValue() (value, error) {
+	t := p.next()
+
+	switch {
+	case t.kind == tokenString:
+		return value{kind: stringValue, s: t.text}, nil
+
+	case t.kind == tokenNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return value{}, &Error{Offset: t.offset, Message: "invalid number " + t.text}
+		}
+
+		return value{kind: numberValue, n: n}, nil
+
+	case t.is("true"), t.is("false"):
+		return value{kind: boolValue, b: t.is("true")}, nil
+	}
+
+	return value{}, p.unexpected(t, "string, number, true or false")
+}
+
+// propertyType returns the type of the property, from the database, the type given after its name or inferred
+// from the condition.
+func (p *parser) propertyType(name, hint token, op string, v value) (notion.PropertyType, error) {
+	hinted := propertyTypes[strings.ToLower(hint.text)]
+
+	if p.database == nil {
+		if hinted != "" {
+			return hinted, nil
+		}
+
+		return inferPropertyType(op, v), nil
+	}
+
+	property, ok := p.database.Properties[name.text]
+	if !ok || property == nil {
+		return "", &Error{Offset: name.offset, Message: fmt.Sprintf("no property %q", name.text)}
+	}
+
+	propertyType := notion.PropertyTypeOf(property)
+
+	// Titles are filtered with text conditions.
+	if hinted != "" && hinted != propertyType && !(hinted == notion.PropertyTypeTitle && propertyType == notion.PropertyTypeRichText) {
+		return "", &Error{Offset: hint.offset, Message: fmt.Sprintf("property %q is a %s property, not a %s property",
+			name.text, propertyType, hint.text)}
+	}
+
+	return propertyType, nil
+}
+
+func (p *parser) parseSorts() ([]notion.Sort, error) {
+	p.next()
+
+	if err := p.expect("by", "by"); err != nil {
+		return nil, err
+	}
+
+	var sorts []notion.Sort
+
+	for {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+
+		sort := notion.Sort{Property: name.text, Direction: notion.SortDirectionAscending}
+
+		if name.kind == tokenWord {
+			switch timestamp := notion.SortTimestamp(strings.ToLower(name.text)); timestamp {
+			case notion.SortTimestampByCreatedTime, notion.SortTimestampByLastEditedTime:
+				sort = notion.Sort{Timestamp: timestamp, Direction: notion.SortDirectionAscending}
+			}
+		}
+
+		if sort.Property != "" && p.database != nil {
+			if _, ok := p.database.Properties[sort.Property]; !ok {
+				return nil, &Error{Offset: name.offset, Message: fmt.Sprintf("no property %q", sort.Property)}
+			}
+		}
+
+		switch next := p.peek(); {
+		case next.is("asc"), next.is("ascending"):
+			p.next()
+
+		case next.is("desc"), next.is("descending"):
+			p.next()
+
+			sort.Direction = notion.SortDirectionDescending
+		}
+
+		sorts = append(sorts, sort)
+
+		if !p.peek().is(",") {
+			return sorts, nil
+		}
+
+		p.next()
+	}
+}
+
+// keywords cannot be used as property names without backticks.
+var keywords = []string{"and", "or", "order", "by", "true", "false", "asc", "desc", "ascending", "descending"}
+
+func isKeyword(word string) bool {
+	for _, keyword := range keywords {
+		if strings.EqualFold(word, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildCondition builds the condition on a property of the given type.
+// nolint: cyclop
+func buildCondition(property filter.Property, propertyType notion.PropertyType, op string, v value) (filter.Condition, error) {
+	switch propertyType {
+	case notion.PropertyTypeTitle:
+		return textCondition(property.Title(), op, v)
+	case notion.PropertyTypeRichText:
+		return textCondition(property.RichText(), op, v)
+	case notion.PropertyTypeURL:
+		return textCondition(property.URL(), op, v)
+	case notion.PropertyTypeEmail:
+		return textCondition(property.Email(), op, v)
+	case notion.PropertyTypePhoneNumber:
+		return textCondition(property.PhoneNumber(), op, v)
+	case notion.PropertyTypeNumber:
+		return numberCondition(property.Number(), op, v)
+	case notion.PropertyTypeCheckbox:
+		return checkboxCondition(property.Checkbox(), op, v)
+	case notion.PropertyTypeSelect:
+		return selectCondition(property.Select(), op, v)
+	case notion.PropertyTypeMultiSelect:
+		return multiSelectCondition(property.MultiSelect(), op, v)
+	case notion.PropertyTypeDate:
+		return dateCondition(property.Date(), op, v)
+	case notion.PropertyTypeCreatedTime:
+		return dateCondition(property.CreatedTime(), op, v)
+	case notion.PropertyTypeLastEditedTime:
+		return dateCondition(property.LastEditedTime(), op, v)
+	case notion.PropertyTypePeople:
+		return peopleCondition(property.People(), op, v)
+	case notion.PropertyTypeCreatedBy:
+		return peopleCondition(property.CreatedBy(), op, v)
+	case notion.PropertyTypeLastEditedBy:
+		return peopleCondition(property.LastEditedBy(), op, v)
+	case notion.PropertyTypeFile:
+		return filesCondition(property.Files(), op, v)
+	case notion.PropertyTypeRelation:
+		return relationCondition(property.Relation(), op, v)
+	case notion.PropertyTypeFormula:
+		return formulaCondition(property.Formula(), op, v)
+	}
+
+	return filter.Condition{}, fmt.Errorf("%s properties cannot be filtered", propertyType) // nolint: goerr113
+}
+
+// unsupported returns the error of an operator, or a value, which does not apply to a type of property.
+func unsupported(kind, op string, v value) (filter.Condition, error) {
+	switch {
+	case !hasValue(op):
+		return filter.Condition{}, fmt.Errorf("%s does not apply to %s", op, kind) // nolint: goerr113
+	case v.kind == stringValue:
+		return filter.Condition{}, fmt.Errorf("%s a string does not apply to %s", op, kind) // nolint: goerr113
+	case v.kind == numberValue:
+		return filter.Condition{}, fmt.Errorf("%s a number does not apply to %s", op, kind) // nolint: goerr113
+	}
+
+	return filter.Condition{}, fmt.Errorf("%s a boolean does not apply to %s", op, kind) // nolint: goerr113
+}
+
+// nolint: cyclop
+func textCondition(t filter.Text, op string, v value) (filter.Condition, error) {
+	switch {
+	case op == opIsEmpty:
+		return t.IsEmpty(), nil
+	case op == opIsNotEmpty:
+		return t.IsNotEmpty(), nil
+	case v.kind != stringValue:
+	case op == opEquals:
+		return t.Equals(v.s), nil
+	case op == opDoesNotEqual:
+		return t.DoesNotEqual(v.s), nil
+	case op == opContains:
+		return t.Contains(v.s), nil
+	case op == opDoesNotContain:
+		return t.DoesNotContain(v.s), nil
+	case op == opStartsWith:
+		return t.StartsWith(v.s), nil
+	case op == opEndsWith:
+		return t.EndsWith(v.s), nil
+	}
+
+	return unsupported("text", op, v)
+}
+
+// nolint: cyclop
+func numberCondition(n filter.Number, op string, v value) (filter.Condition, error) {
+	switch {
+	case op == opIsEmpty:
+		return n.IsEmpty(), nil
+	case op == opIsNotEmpty:
+		return n.IsNotEmpty(), nil
+	case v.kind != numberValue:
+	case op == opEquals:
+		return n.Equals(v.n), nil
+	case op == opDoesNotEqual:
+		return n.DoesNotEqual(v.n), nil
+	case op == opLessThan:
+		return n.LessThan(v.n), nil
+	case op == opLessOrEqual:
+		return n.LessThanOrEqualTo(v.n), nil
+	case op == opGreaterThan:
+		return n.GreaterThan(v.n), nil
+	case op == opGreaterOrEqual:
+		return n.GreaterThanOrEqualTo(v.n), nil
+	}
+
+	return unsupported("numbers", op, v)
+}
+
+func checkboxCondition(c filter.Checkbox, op string, v value) (filter.Condition, error) {
+	switch {
+	case v.kind != boolValue:
+	case op == opEquals:
+		return c.Equals(v.b), nil
+	case op == opDoesNotEqual:
+		return c.DoesNotEqual(v.b), nil
+	}
+
+	return unsupported("checkboxes", op, v)
+}
+
+func selectCondition(s filter.Select, op string, v value) (filter.Condition, error) {
+	switch {
+	case op == opIsEmpty:
+		return s.IsEmpty(), nil
+	case op == opIsNotEmpty:
+		return s.IsNotEmpty(), nil
+	case v.kind != stringValue:
+	case op == opEquals:
+		return s.Equals(v.s), nil
+	case op == opDoesNotEqual:
+		return s.DoesNotEqual(v.s), nil
+	}
+
+	return unsupported("selects", op, v)
+}
+
+func multiSelectCondition(m filter.MultiSelect, op string, v value) (filter.Condition, error) {
+	switch {
+	case op == opIsEmpty:
+		return m.IsEmpty(), nil
+	case op == opIsNotEmpty:
+		return m.IsNotEmpty(), nil
+	case v.kind != stringValue:
+	case op == opContains:
+		return m.Contains(v.s), nil
+	case op == opDoesNotContain:
+		return m.DoesNotContain(v.s), nil
+	}
+
+	return unsupported("multi selects", op, v)
+}
+
+// nolint: cyclop
+func dateCondition(d filter.Date, op string, v value) (filter.Condition, error) {
+	switch op {
+	case opIsEmpty:
+		return d.IsEmpty(), nil
+	case opIsNotEmpty:
+		return d.IsNotEmpty(), nil
+	case opPastWeek:
+		return d.PastWeek(), nil
+	case opPastMonth:
+		return d.PastMonth(), nil
+	case opPastYear:
+		return d.PastYear(), nil
+	case opNextWeek:
+		return d.NextWeek(), nil
+	case opNextMonth:
+		return d.NextMonth(), nil
+	case opNextYear:
+		return d.NextYear(), nil
+	}
+
+	if v.kind != stringValue {
+		return unsupported("dates", op, v)
+	}
+
+	date, err := parseDate(v.s)
+	if err != nil {
+		return filter.Condition{}, err
+	}
+
+	switch op {
+	case opEquals:
+		return d.Equals(date), nil
+	case opLessThan, opBefore:
+		return d.Before(date), nil
+	case opGreaterThan, opAfter:
+		return d.After(date), nil
+	case opLessOrEqual, opOnOrBefore:
+		return d.OnOrBefore(date), nil
+	case opGreaterOrEqual, opOnOrAfter:
+		return d.OnOrAfter(date), nil
+	}
+
+	return unsupported("dates", op, v)
+}
+
+// parseDate parses an ISO 8601 date, with or without time. A date without time is parsed as midnight UTC, which
+// the filter package formats as a date without time.
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected an ISO 8601 date such as 2021-05-10 or 2021-05-10T14:30:00Z", s) // nolint: goerr113
+	}
+
+	return t, nil
+}
+
+func peopleCondition(p filter.People, op string, v value) (filter.Condition, error) {
+	switch {
+	case op == opIsEmpty:
+		return p.IsEmpty(), nil
+	case op == opIsNotEmpty:
+		return p.IsNotEmpty(), nil
+	case v.kind != stringValue:
+	case op == opContains:
+		return p.Contains(v.s), nil
+	case op == opDoesNotContain:
+		return p.DoesNotContain(v.s), nil
+	}
+
+	return unsupported("people", op, v)
+}
+
+func filesCondition(f filter.Files, op string, v value) (filter.Condition, error) {
+	switch op {
+	case opIsEmpty:
+		return f.IsEmpty(), nil
+	case opIsNotEmpty:
+		return f.IsNotEmpty(), nil
+	}
+
+	return unsupported("files", op, v)
+}
+
+func relationCondition(r filter.Relation, op string, v value) (filter.Condition, error) {
+	switch {
+	case op == opIsEmpty:
+		return r.IsEmpty(), nil
+	case op == opIsNotEmpty:
+		return r.IsNotEmpty(), nil
+	case v.kind != stringValue:
+	case op == opContains:
+		return r.Contains(v.s), nil
+	case op == opDoesNotContain:
+		return r.DoesNotContain(v.s), nil
+	}
+
+	return unsupported("relations", op, v)
+}
+
+// formulaCondition builds a condition on the result of a formula, whose type is inferred from the condition.
+func formulaCondition(f filter.Formula, op string, v value) (filter.Condition, error) {
+	switch inferPropertyType(op, v) { // nolint: exhaustive
+	case notion.PropertyTypeDate:
+		return dateCondition(f.Date(), op, v)
+	case notion.PropertyTypeNumber:
+		return numberCondition(f.Number(), op, v)
+	case notion.PropertyTypeCheckbox:
+		return checkboxCondition(f.Checkbox(), op, v)
+	}
+
+	return textCondition(f.Text(), op, v)
+}
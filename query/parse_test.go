@@ -0,0 +1,240 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tasksDatabaseJSON = `{
+	"object": "database",
+	"id": "668d797c-76fa-4934-9b05-ad288df2d136",
+	"properties": {
+		"Name": {"id": "title", "type": "title", "title": {}},
+		"Notes": {"id": "E@Qk", "type": "rich_text", "rich_text": {}},
+		"Status": {"id": "%3AQ%5Bq", "type": "select", "select": {"options": []}},
+		"Tags": {"id": "flsb", "type": "multi_select", "multi_select": {"options": []}},
+		"Priority": {"id": "I%7D%3DS", "type": "number", "number": {"format": "number"}},
+		"Done": {"id": "Ax%3Da", "type": "checkbox", "checkbox": {}},
+		"Due date": {"id": "Zc%5Dh", "type": "date", "date": {}},
+		"Created": {"id": "s~%5Bf", "type": "created_time", "created_time": {}},
+		"Owner": {"id": "%5EOE%40", "type": "people", "people": {}},
+		"Total": {"id": "%3Fy%5E%3F", "type": "formula", "formula": {"expression": "prop(\"Priority\") * 2"}}
+	}
+}`
+
+func tasksDatabase(t *testing.T) *notion.Database {
+	var database notion.Database
+
+	require.NoError(t, json.Unmarshal([]byte(tasksDatabaseJSON), &database))
+
+	return &database
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		database bool
+		want     string
+	}{
+		{
+			name: "Example without database",
+			text: `Status = "Done" and (Priority >= 3 or Tags contains "urgent") order by Created desc`,
+			want: `{
+				"filter": {"and": [
+					{"property": "Status", "text": {"equals": "Done"}},
+					{"or": [
+						{"property": "Priority", "number": {"greater_than_or_equal_to": 3}},
+						{"property": "Tags", "text": {"contains": "urgent"}}
+					]}
+				]},
+				"sorts": [{"property": "Created", "direction": "descending"}]
+			}`,
+		},
+		{
+			name:     "Example with database",
+			text:     `Status = "Done" and (Priority >= 3 or Tags contains "urgent") order by Created desc`,
+			database: true,
+			want: `{
+				"filter": {"and": [
+					{"property": "Status", "select": {"equals": "Done"}},
+					{"or": [
+						{"property": "Priority", "number": {"greater_than_or_equal_to": 3}},
+						{"property": "Tags", "multi_select": {"contains": "urgent"}}
+					]}
+				]},
+				"sorts": [{"property": "Created", "direction": "descending"}]
+			}`,
+		},
+		{
+			name: "And takes precedence over or",
+			text: `a = 1 or b = 2 and c = 3`,
+			want: `{"filter": {"or": [
+				{"property": "a", "number": {"equals": 1}},
+				{"and": [{"property": "b", "number": {"equals": 2}}, {"property": "c", "number": {"equals": 3}}]}
+			]}}`,
+		},
+		{
+			name: "Keywords in any case",
+			text: `A IS NOT EMPTY AND B Does Not Contain "x" ORDER BY created_time`,
+			want: `{
+				"filter": {"and": [
+					{"property": "A", "text": {"is_not_empty": true}},
+					{"property": "B", "text": {"does_not_contain": "x"}}
+				]},
+				"sorts": [{"timestamp": "created_time", "direction": "ascending"}]
+			}`,
+		},
+		{
+			name: "Quoted names and escaped strings",
+			text: "`Due date` on or after \"2021-05-10\" and `It``s` = \"say \\\"hi\\\"\" order by `created_time` asc, last_edited_time descending",
+			want: `{
+				"filter": {"and": [
+					{"property": "Due date", "date": {"on_or_after": "2021-05-10"}},
+					{"property": "It` + "`" + `s", "text": {"equals": "say \"hi\""}}
+				]},
+				"sorts": [
+					{"property": "created_time", "direction": "ascending"},
+					{"timestamp": "last_edited_time", "direction": "descending"}
+				]
+			}`,
+		},
+		{
+			name: "Inferred types",
+			text: `Done = false and Due > "2021-05-10T14:30:00+02:00" and Due in next week and Score < -1.5`,
+			want: `{"filter": {"and": [
+				{"property": "Done", "checkbox": {"does_not_equal": true}},
+				{"property": "Due", "date": {"after": "2021-05-10T14:30:00+02:00"}},
+				{"property": "Due", "date": {"next_week": {}}},
+				{"property": "Score", "number": {"less_than": -1.5}}
+			]}}`,
+		},
+		{
+			name: "Types after the names",
+			text: `Status:select is empty and Notes:rich_text starts with "a" and Due:date = "2021-05-10" and ` +
+				`Created:created_time in past month and Owner:people contains "u1" and Files:files is not empty and ` +
+				`Total:formula > 2 and Site:url ends with ".com"`,
+			want: `{"filter": {"and": [
+				{"property": "Status", "select": {"is_empty": true}},
+				{"property": "Notes", "rich_text": {"starts_with": "a"}},
+				{"property": "Due", "date": {"equals": "2021-05-10"}},
+				{"property": "Created", "created_time": {"past_month": {}}},
+				{"property": "Owner", "people": {"contains": "u1"}},
+				{"property": "Files", "files": {"is_not_empty": true}},
+				{"property": "Total", "formula": {"number": {"greater_than": 2}}},
+				{"property": "Site", "url": {"ends_with": ".com"}}
+			]}}`,
+		},
+		{
+			name:     "Types from the database",
+			text:     `Name contains "kale" and Notes = "x" and Created before "2021-05-10" and Owner is empty and Total = "high"`,
+			database: true,
+			want: `{"filter": {"and": [
+				{"property": "Name", "text": {"contains": "kale"}},
+				{"property": "Notes", "rich_text": {"equals": "x"}},
+				{"property": "Created", "created_time": {"before": "2021-05-10"}},
+				{"property": "Owner", "people": {"is_empty": true}},
+				{"property": "Total", "formula": {"text": {"equals": "high"}}}
+			]}}`,
+		},
+		{
+			name: "Sorts only",
+			text: `order by Priority desc, Name`,
+			want: `{"sorts": [
+				{"property": "Priority", "direction": "descending"},
+				{"property": "Name", "direction": "ascending"}
+			]}`,
+		},
+		{
+			name: "Empty",
+			text: ` `,
+			want: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var database *notion.Database
+			if tt.database {
+				database = tasksDatabase(t)
+			}
+
+			got, err := Parse(tt.text, database)
+			require.NoError(t, err)
+
+			params := got.Parameters("668d797c-76fa-4934-9b05-ad288df2d136")
+			assert.Equal(t, "668d797c-76fa-4934-9b05-ad288df2d136", params.DatabaseID)
+
+			b, err := json.Marshal(params)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(b))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		database bool
+		want     string
+	}{
+		{name: "Unterminated string", text: `Name = "kale`, want: `query: offset 7: unterminated string`},
+		{name: "Unterminated name", text: "`Name = 1", want: `query: offset 0: unterminated property name`},
+		{name: "Unexpected character", text: `Name = 1 & b = 2`, want: `query: offset 9: unexpected character '&'`},
+		{name: "Missing operator", text: `Name "kale"`, want: `query: offset 5: expected operator, got "kale"`},
+		{name: "Missing value", text: `Name =`, want: `query: offset 6: expected string, number, true or false, got end of query`},
+		{name: "Keyword as name", text: `and = 1`, want: `query: offset 0: expected property, got "and"`},
+		{name: "Unknown type", text: `Name:text2 = 1`, want: `query: offset 5: expected property type, got "text2"`},
+		{name: "Missing parenthesis", text: `(a = 1 or b = 2`, want: `query: offset 15: expected and, or or ), got end of query`},
+		{name: "Trailing tokens", text: `a = 1 b = 2`, want: `query: offset 6: expected and, or, order by or end of query, got "b"`},
+		{name: "Missing by", text: `order Name`, want: `query: offset 6: expected by, got "Name"`},
+		{
+			name: "Operator not applying to the type",
+			text: `a contains 1`,
+			want: `query: offset 2: property "a": contains a number does not apply to numbers`,
+		},
+		{
+			name: "Value not applying to the type",
+			text: `a:checkbox = "yes"`,
+			want: `query: offset 11: property "a": = a string does not apply to checkboxes`,
+		},
+		{name: "Invalid date", text: `a before "soon"`, want: `query: offset 2: property "a": invalid date "soon", ` +
+			`expected an ISO 8601 date such as 2021-05-10 or 2021-05-10T14:30:00Z`},
+		{name: "Files", text: `a:files contains "x"`, want: `query: offset 8: property "a": contains a string does not apply to files`},
+		{name: "Unknown property", text: `Nmae = "kale"`, database: true, want: `query: offset 0: no property "Nmae"`},
+		{name: "Unknown sort", text: `order by Nmae`, database: true, want: `query: offset 9: no property "Nmae"`},
+		{
+			name:     "Type not matching the database",
+			text:     `Status:number = 1`,
+			database: true,
+			want:     `query: offset 7: property "Status" is a select property, not a number property`,
+		},
+		{
+			name:     "Condition not applying to the property",
+			text:     `Status contains "a"`,
+			database: true,
+			want:     `query: offset 7: property "Status": contains a string does not apply to selects`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var database *notion.Database
+			if tt.database {
+				database = tasksDatabase(t)
+			}
+
+			_, err := Parse(tt.text, database)
+
+			var queryErr *Error
+
+			require.ErrorAs(t, err, &queryErr)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,546 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// ErrUnsupportedFilter is returned when a filter cannot be written as text, e.g. a filter without condition.
+var ErrUnsupportedFilter = errors.New("query: unsupported filter")
+
+var (
+	errNoCondition       = errors.New("no condition")
+	errSeveralConditions = errors.New("several conditions")
+)
+
+// Format formats the query as text, which Parse parses back into the same query.
+func Format(q Query) (string, error) {
+	var parts []string
+
+	if q.Filter != nil {
+		text, err := FormatFilter(q.Filter)
+		if err != nil {
+			return "", err
+		}
+
+		parts = append(parts, text)
+	}
+
+	if len(q.Sorts) > 0 {
+		sorts := make([]string, 0, len(q.Sorts))
+
+		for _, s := range q.Sorts {
+			text, err := formatSort(s)
+			if err != nil {
+				return "", err
+			}
+
+			sorts = append(sorts, text)
+		}
+
+		parts = append(parts, "order by "+strings.Join(sorts, ", "))
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// FormatFilter formats the filter as text. The types of the properties are written when they cannot be inferred
+// from the conditions, so that Parse parses the text back into the same filter without the database.
+func FormatFilter(f notion.Filter) (string, error) {
+	return formatFilter(f, false)
+}
+
+func formatSort(s notion.Sort) (string, error) {
+	var text string
+
+	switch {
+	case s.Property != "" && s.Timestamp != "":
+		return "", fmt.Errorf("%w: sort by both property %q and timestamp %q", ErrUnsupportedFilter, s.Property, s.Timestamp)
+	case s.Property != "":
+		text = formatName(s.Property)
+	case s.Timestamp != "":
+		text = string(s.Timestamp)
+	default:
+		return "", fmt.Errorf("%w: sort by neither a property nor a timestamp", ErrUnsupportedFilter)
+	}
+
+	if s.Direction == notion.SortDirectionDescending {
+		text += " desc"
+	}
+
+	return text, nil
+}
+
+// formatFilter formats the filter, within parentheses if it is a compound filter nested in another one.
+func formatFilter(f notion.Filter, nested bool) (string, error) {
+	if rv := reflect.ValueOf(f); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		if deref, ok := rv.Elem().Interface().(notion.Filter); ok {
+			f = deref
+		}
+	}
+
+	if compound, ok := f.(notion.CompoundFilter); ok {
+		return formatCompoundFilter(compound, nested)
+	}
+
+	c, err := conditionOf(f)
+	if err != nil {
+		return "", err
+	}
+
+	return c.String(), nil
+}
+
+func formatCompoundFilter(f notion.CompoundFilter, nested bool) (string, error) {
+	operator, operands := " and ", f.And
+
+	switch {
+	case len(f.And) > 0 && len(f.Or) > 0:
+		return "", fmt.Errorf("%w: compound filter with both and and or", ErrUnsupportedFilter)
+	case len(f.Or) > 0:
+		operator, operands = " or ", f.Or
+	case len(f.And) == 0:
+		return "", fmt.Errorf("%w: compound filter without filters", ErrUnsupportedFilter)
+	}
+
+	if len(operands) == 1 {
+		return formatFilter(operands[0], nested)
+	}
+
+	texts := make([]string, 0, len(operands))
+
+	for _, operand := range operands {
+		text, err := formatFilter(operand, true)
+		if err != nil {
+			return "", err
+		}
+
+		texts = append(texts, text)
+	}
+
+	if nested {
+		return "(" + strings.Join(texts, operator) + ")", nil
+	}
+
+	return strings.Join(texts, operator), nil
+}
+
+// condition is a single filter as text.
+type condition struct {
+	property string
+	hint     string
+	op       string
+	value    value
+}
+
+func (c condition) String() string {
+	text := formatName(c.property)
+
+	if c.hint != "" {
+		text += ":" + c.hint
+	}
+
+	text += " " + c.op
+
+	switch c.value.kind {
+	case stringValue:
+		text += " " + strconv.Quote(c.value.s)
+	case numberValue:
+		text += " " + strconv.FormatFloat(c.value.n, 'g', -1, 64)
+	case boolValue:
+		text += " " + strconv.FormatBool(c.value.b)
+	case noValue:
+	}
+
+	return text
+}
+
+// conditionOf returns the condition of a single filter.
+// nolint: cyclop
+func conditionOf(f notion.Filter) (condition, error) {
+	var (
+		c   condition
+		err error
+	)
+
+	switch f := f.(type) {
+	case nil:
+		return c, fmt.Errorf("%w: nil filter", ErrUnsupportedFilter)
+	case notion.SingleTextFilter:
+		c, err = textFilterCondition(f)
+	case notion.SingleNumberFilter:
+		c, err = numberFilterCondition(f.Number)
+		c.hint = string(notion.PropertyTypeNumber)
+	case notion.SingleCheckboxFilter:
+		c, err = checkboxFilterCondition(f.Checkbox)
+		c.hint = string(notion.PropertyTypeCheckbox)
+	case notion.SingleSelectFilter:
+		c, err = selectFilterCondition(f.Select)
+		c.hint = string(notion.PropertyTypeSelect)
+	case notion.SingleMultiSelectFilter:
+		c, err = listFilterCondition(f.MultiSelect.Contains, f.MultiSelect.DoesNotContain, f.MultiSelect.IsEmpty, f.MultiSelect.IsNotEmpty)
+		c.hint = string(notion.PropertyTypeMultiSelect)
+	case notion.SingleDateFilter:
+		c, err = dateFilterCondition(f)
+	case notion.SinglePeopleFilter:
+		c, err = peopleFilterCondition(f)
+	case notion.SingleFilesFilter:
+		c, err = listFilterCondition(nil, nil, f.Files.IsEmpty, f.Files.IsNotEmpty)
+		c.hint = "files"
+	case notion.SingleRelationFilter:
+		c, err = listFilterCondition(f.Relation.Contains, f.Relation.DoesNotContain, f.Relation.IsEmpty, f.Relation.IsNotEmpty)
+		c.hint = string(notion.PropertyTypeRelation)
+	case notion.SingleFormulaFilter:
+		return formulaFilterCondition(f)
+	default:
+		return c, fmt.Errorf("%w: %T", ErrUnsupportedFilter, f)
+	}
+
+	if err == nil && c.op == "" {
+		err = errNoCondition
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("%w: property %q: %s", ErrUnsupportedFilter, propertyOf(f), err) // nolint: errorlint
+	}
+
+	c.property = propertyOf(f)
+
+	// The type is left out when it is inferred from the condition.
+	if propertyTypes[c.hint] == inferPropertyType(c.op, c.value) {
+		c.hint = ""
+	}
+
+	return c, nil
+}
+
+func propertyOf(f notion.Filter) string {
+	rv := reflect.ValueOf(f).FieldByName("Property")
+	if rv.Kind() != reflect.String {
+		return ""
+	}
+
+	return rv.String()
+}
+
+// set returns the condition of the filter, or an error if several conditions are set.
+func set(conditions ...condition) (condition, error) {
+	var (
+		result condition
+		ops    []string
+	)
+
+	for _, c := range conditions {
+		if c.op != "" {
+			result = c
+			ops = append(ops, c.op)
+		}
+	}
+
+	if len(ops) > 1 {
+		return result, fmt.Errorf("several conditions: %s", strings.Join(ops, ", ")) // nolint: goerr113
+	}
+
+	return result, nil
+}
+
+// when returns a condition without value if ok, or no condition.
+func when(ok bool, op string) condition {
+	if !ok {
+		return condition{}
+	}
+
+	return condition{op: op}
+}
+
+func withString(s *string, op string) condition {
+	if s == nil {
+		return condition{}
+	}
+
+	return condition{op: op, value: value{kind: stringValue, s: *s}}
+}
+
+func withNumber(n *float64, op string) condition {
+	if n == nil {
+		return condition{}
+	}
+
+	return condition{op: op, value: value{kind: numberValue, n: *n}}
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+func textFilterCondition(f notion.SingleTextFilter) (condition, error) {
+	filters := []struct {
+		hint   string
+		filter *notion.TextFilter
+	}{
+		{"title", f.Text}, {"rich_text", f.RichText}, {"url", f.URL}, {"email", f.Email}, {"phone_number", f.Phone},
+	}
+
+	var (
+		result condition
+		count  int
+	)
+
+	for _, t := range filters {
+		if t.filter == nil {
+			continue
+		}
+
+		c, err := textFilterConditions(t.filter)
+		if err != nil {
+			return c, err
+		}
+
+		c.hint = t.hint
+		result = c
+		count++
+	}
+
+	if count > 1 {
+		return result, errSeveralConditions
+	}
+
+	return result, nil
+}
+
+func textFilterConditions(f *notion.TextFilter) (condition, error) {
+	return set(
+		withString(f.Equals, opEquals),
+		withString(f.DoesNotEqual, opDoesNotEqual),
+		withString(f.Contains, opContains),
+		withString(f.DoesNotContain, opDoesNotContain),
+		withString(f.StartsWith, opStartsWith),
+		withString(f.EndsWith, opEndsWith),
+		when(isTrue(f.IsEmpty), opIsEmpty),
+		when(isTrue(f.IsNotEmpty), opIsNotEmpty),
+	)
+}
+
+func numberFilterCondition(f notion.NumberFilter) (condition, error) {
+	return set(
+		withNumber(f.Equals, opEquals),
+		withNumber(f.DoesNotEqual, opDoesNotEqual),
+		withNumber(f.LessThan, opLessThan),
+		withNumber(f.LessThanOrEqualTo, opLessOrEqual),
+		withNumber(f.GreaterThan, opGreaterThan),
+		withNumber(f.GreaterThanOrEqualTo, opGreaterOrEqual),
+		when(f.IsEmpty, opIsEmpty),
+		when(f.IsNotEmpty, opIsNotEmpty),
+	)
+}
+
+func checkboxFilterCondition(f notion.CheckboxFilter) (condition, error) {
+	var equals, doesNotEqual condition
+
+	if f.Equals {
+		equals = condition{op: opEquals, value: value{kind: boolValue, b: true}}
+	}
+
+	if f.DoesNotEqual {
+		doesNotEqual = condition{op: opDoesNotEqual, value: value{kind: boolValue, b: true}}
+	}
+
+	return set(equals, doesNotEqual)
+}
+
+func selectFilterCondition(f notion.SelectFilter) (condition, error) {
+	return set(
+		withString(f.Equals, opEquals),
+		withString(f.DoesNotEqual, opDoesNotEqual),
+		when(f.IsEmpty, opIsEmpty),
+		when(f.IsNotEmpty, opIsNotEmpty),
+	)
+}
+
+func listFilterCondition(contains, doesNotContain *string, isEmpty, isNotEmpty bool) (condition, error) {
+	return set(
+		withString(contains, opContains),
+		withString(doesNotContain, opDoesNotContain),
+		when(isEmpty, opIsEmpty),
+		when(isNotEmpty, opIsNotEmpty),
+	)
+}
+
+func dateFilterCondition(f notion.SingleDateFilter) (condition, error) {
+	filters := []struct {
+		hint   string
+		filter *notion.DateFilter
+	}{
+		{"date", f.Date}, {"created_time", f.CreatedTime}, {"last_edited_time", f.LastEditedTime},
+	}
+
+	var (
+		result condition
+		count  int
+	)
+
+	for _, d := range filters {
+		if d.filter == nil {
+			continue
+		}
+
+		c, err := dateFilterConditions(d.filter)
+		if err != nil {
+			return c, err
+		}
+
+		c.hint = d.hint
+		result = c
+		count++
+	}
+
+	if count > 1 {
+		return result, errSeveralConditions
+	}
+
+	return result, nil
+}
+
+func dateFilterConditions(f *notion.DateFilter) (condition, error) {
+	return set(
+		withString(f.Equals, opEquals),
+		withString(f.Before, opBefore),
+		withString(f.After, opAfter),
+		withString(f.OnOrBefore, opOnOrBefore),
+		withString(f.OnOrAfter, opOnOrAfter),
+		when(f.IsEmpty, opIsEmpty),
+		when(f.IsNotEmpty, opIsNotEmpty),
+		when(f.PastWeek != nil, opPastWeek),
+		when(f.PastMonth != nil, opPastMonth),
+		when(f.PastYear != nil, opPastYear),
+		when(f.NextWeek != nil, opNextWeek),
+		when(f.NextMonth != nil, opNextMonth),
+		when(f.NextYear != nil, opNextYear),
+	)
+}
+
+func peopleFilterCondition(f notion.SinglePeopleFilter) (condition, error) {
+	filters := []struct {
+		hint   string
+		filter *notion.PeopleFilter
+	}{
+		{"people", f.People}, {"created_by", f.CreatedBy}, {"last_edited_by", f.LastEditedBy},
+	}
+
+	var (
+		result condition
+		count  int
+	)
+
+	for _, p := range filters {
+		if p.filter == nil {
+			continue
+		}
+
+		c, err := listFilterCondition(p.filter.Contains, p.filter.DoesNotContain, p.filter.IsEmpty, p.filter.IsNotEmpty)
+		if err != nil {
+			return c, err
+		}
+
+		c.hint = p.hint
+		result = c
+		count++
+	}
+
+	if count > 1 {
+		return result, errSeveralConditions
+	}
+
+	return result, nil
+}
+
+// formulaFilterCondition returns the condition of a formula filter, whose type of result must be inferred from
+// the condition, as Parse does.
+func formulaFilterCondition(f notion.SingleFormulaFilter) (condition, error) {
+	var (
+		c          condition
+		resultType notion.PropertyType
+		err        error
+		count      int
+	)
+
+	if f.Formula.Text != nil {
+		c, err = textFilterConditions(f.Formula.Text)
+		resultType = notion.PropertyTypeTitle
+		count++
+	}
+
+	if f.Formula.Checkbox != nil {
+		c, err = checkboxFilterCondition(*f.Formula.Checkbox)
+		resultType = notion.PropertyTypeCheckbox
+		count++
+	}
+
+	if f.Formula.Number != nil {
+		c, err = numberFilterCondition(*f.Formula.Number)
+		resultType = notion.PropertyTypeNumber
+		count++
+	}
+
+	if f.Formula.Date != nil {
+		c, err = dateFilterConditions(f.Formula.Date)
+		resultType = notion.PropertyTypeDate
+		count++
+	}
+
+	switch {
+	case err != nil:
+	case count > 1:
+		err = errSeveralConditions
+	case c.op == "":
+		err = errNoCondition
+	case inferPropertyType(c.op, c.value) != resultType:
+		err = fmt.Errorf("the %s condition on the %s result cannot be told apart from conditions on other results", // nolint: goerr113
+			c.op, resultType)
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("%w: property %q: %s", ErrUnsupportedFilter, f.Property, err) // nolint: errorlint
+	}
+
+	c.property = f.Property
+	c.hint = string(notion.PropertyTypeFormula)
+
+	return c, nil
+}
+
+// formatName returns the name of the property, within backticks unless it is a word which is not a keyword.
+func formatName(name string) string {
+	if isBareName(name) {
+		return name
+	}
+
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func isBareName(name string) bool {
+	first, _ := utf8.DecodeRuneInString(name)
+	if name == "" || !unicode.IsLetter(first) && first != '_' {
+		return false
+	}
+
+	for _, r := range name {
+		if !isWordRune(r) {
+			return false
+		}
+	}
+
+	switch notion.SortTimestamp(strings.ToLower(name)) {
+	case notion.SortTimestampByCreatedTime, notion.SortTimestampByLastEditedTime:
+		return false
+	}
+
+	return !isKeyword(name)
+}
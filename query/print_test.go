@@ -0,0 +1,197 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	due := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query Query
+		want  string
+	}{
+		{
+			name: "Example",
+			query: Query{
+				Filter: filter.Prop("Status").Select().Equals("Done").
+					And(filter.Prop("Priority").Number().GreaterThanOrEqualTo(3).Or(filter.Prop("Tags").MultiSelect().Contains("urgent"))).
+					Build(),
+				Sorts: []notion.Sort{{Property: "Created", Direction: notion.SortDirectionDescending}},
+			},
+			want: `Status:select = "Done" and (Priority >= 3 or Tags:multi_select contains "urgent") order by Created desc`,
+		},
+		{
+			name: "Text conditions",
+			query: Query{Filter: filter.And(
+				filter.Prop("Name").Title().Contains("kale"),
+				filter.Prop("Notes").RichText().IsEmpty(),
+				filter.Prop("Site").URL().EndsWith(".com"),
+				filter.Prop("Mail").Email().DoesNotEqual("a@b.c"),
+				filter.Prop("Phone").PhoneNumber().StartsWith("+33"),
+			).Build()},
+			want: `Name contains "kale" and Notes:rich_text is empty and Site:url ends with ".com" and ` +
+				`Mail:email != "a@b.c" and Phone:phone_number starts with "+33"`,
+		},
+		{
+			name: "Other conditions",
+			query: Query{Filter: filter.Or(
+				filter.Prop("Done").Checkbox().Equals(false),
+				filter.Prop("Score").Number().IsNotEmpty(),
+				filter.Prop("Due").Date().Equals(due),
+				filter.Prop("Due").Date().Before(due.Add(90*time.Minute)),
+				filter.Prop("Created").CreatedTime().PastYear(),
+				filter.Prop("Owner").People().Contains("u1"),
+				filter.Prop("Files").Files().IsEmpty(),
+				filter.Prop("Parent").Relation().DoesNotContain("p1"),
+				filter.Prop("Total").Formula().Number().LessThan(-1.5),
+				filter.Prop("Late").Formula().Checkbox().Equals(true),
+			).Build()},
+			want: `Done != true or Score:number is not empty or Due:date = "2021-05-10" or Due before "2021-05-10T01:30:00Z" or ` +
+				`Created:created_time in past year or Owner:people contains "u1" or Files:files is empty or ` +
+				`Parent:relation does not contain "p1" or Total:formula < -1.5 or Late:formula = true`,
+		},
+		{
+			name: "Quoted names and strings",
+			query: Query{
+				Filter: filter.Prop("It`s").Title().Equals(`say "hi"`).And(filter.Prop("or").Number().Equals(1)).Build(),
+				Sorts: []notion.Sort{
+					{Property: "created_time", Direction: notion.SortDirectionAscending},
+					{Timestamp: notion.SortTimestampByLastEditedTime, Direction: notion.SortDirectionDescending},
+				},
+			},
+			want: "`It``s` = \"say \\\"hi\\\"\" and `or` = 1 order by `created_time`, last_edited_time desc",
+		},
+		{
+			name: "Nested compound filters",
+			query: Query{Filter: &notion.CompoundFilter{Or: []notion.Filter{
+				notion.CompoundFilter{And: []notion.Filter{
+					filter.Prop("a").Number().Equals(1).Build(),
+					notion.CompoundFilter{Or: []notion.Filter{
+						filter.Prop("b").Number().Equals(2).Build(),
+						filter.Prop("d").Number().Equals(4).Build(),
+					}},
+				}},
+				filter.Prop("c").Number().Equals(3).Build(),
+			}}},
+			want: `(a = 1 and (b = 2 or d = 4)) or c = 3`,
+		},
+		{
+			name:  "Sorts only",
+			query: Query{Sorts: []notion.Sort{{Property: "Due date", Direction: notion.SortDirectionAscending}}},
+			want:  "order by `Due date`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// The text is parsed back into the same query.
+			parsed, err := Parse(got, nil)
+			require.NoError(t, err)
+			assert.JSONEq(t, mustMarshal(t, tt.query), mustMarshal(t, parsed))
+		})
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	texts := []string{
+		`Status:select = "Done" and (Priority >= 3 or Tags:multi_select contains "urgent") order by Created desc`,
+		`a = 1 or (b = 2 and (c = 3 or d = 4))`,
+		`Due on or after "2021-05-10T14:30:00+02:00" and Due:last_edited_time in next month`,
+		`Total:formula is empty or Total:formula after "2021-05-10"`,
+	}
+
+	for _, text := range texts {
+		t.Run(text, func(t *testing.T) {
+			q, err := Parse(text, nil)
+			require.NoError(t, err)
+
+			got, err := Format(*q)
+			require.NoError(t, err)
+			assert.Equal(t, text, got)
+		})
+	}
+}
+
+func TestFormat_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  string
+	}{
+		{
+			name:  "Nil filter",
+			query: Query{Filter: notion.CompoundFilter{And: []notion.Filter{nil, nil}}},
+			want:  "query: unsupported filter: nil filter",
+		},
+		{
+			name:  "Compound filter without filters",
+			query: Query{Filter: notion.CompoundFilter{}},
+			want:  "query: unsupported filter: compound filter without filters",
+		},
+		{
+			name: "Compound filter with and and or",
+			query: Query{Filter: notion.CompoundFilter{
+				And: []notion.Filter{filter.Prop("a").Number().Equals(1).Build()},
+				Or:  []notion.Filter{filter.Prop("b").Number().Equals(1).Build()},
+			}},
+			want: "query: unsupported filter: compound filter with both and and or",
+		},
+		{
+			name:  "Filter without condition",
+			query: Query{Filter: notion.SingleSelectFilter{SinglePropertyFilter: notion.SinglePropertyFilter{Property: "Status"}}},
+			want:  `query: unsupported filter: property "Status": no condition`,
+		},
+		{
+			name: "Filter with several conditions",
+			query: Query{Filter: notion.SingleNumberFilter{
+				SinglePropertyFilter: notion.SinglePropertyFilter{Property: "Score"},
+				Number:               notion.NumberFilter{IsEmpty: true, IsNotEmpty: true},
+			}},
+			want: `query: unsupported filter: property "Score": several conditions: is empty, is not empty`,
+		},
+		{
+			name:  "Property filter",
+			query: Query{Filter: notion.SinglePropertyFilter{Property: "Status"}},
+			want:  "query: unsupported filter: notion.SinglePropertyFilter",
+		},
+		{
+			name:  "Formula result which cannot be inferred",
+			query: Query{Filter: filter.Prop("Total").Formula().Number().IsEmpty().Build()},
+			want: `query: unsupported filter: property "Total": the is empty condition on the number result ` +
+				`cannot be told apart from conditions on other results`,
+		},
+		{
+			name:  "Sort without property",
+			query: Query{Sorts: []notion.Sort{{Direction: notion.SortDirectionAscending}}},
+			want:  "query: unsupported filter: sort by neither a property nor a timestamp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Format(tt.query)
+			assert.ErrorIs(t, err, ErrUnsupportedFilter)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return string(b)
+}
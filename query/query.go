@@ -0,0 +1,162 @@
+// Package query parses database queries written as text, such as
+//
+//	Status = "Done" and (Priority >= 3 or Tags contains "urgent") order by Created desc
+//
+// into the filter and the sorts of notion.DatabasesQueryParameters, and formats them back into text.
+//
+// A condition is a property, an operator and a value. Property names which are not made of letters, digits and
+// underscores are quoted with backticks, e.g. `Due date`. Values are double-quoted strings, numbers, true or false.
+// The operators are =, !=, <, <=, >, >=, contains, does not contain, starts with, ends with, is empty,
+// is not empty, before, after, on or before, on or after, and in past week, in past month, in past year,
+// in next week, in next month, in next year. Conditions are combined with and, which takes precedence, or,
+// and parentheses.
+//
+// The type of filter depends on the type of the property, which is taken from the database when one is given to
+// Parse. Otherwise it is set after the name of the property, e.g. Status:select = "Done", or inferred from the
+// condition: date conditions for the date operators and for strings compared with <, <=, > and >=, number
+// conditions for numbers, checkbox conditions for booleans and text conditions otherwise.
+//
+// The sorts follow order by, and are properties or the created_time and last_edited_time timestamps, optionally
+// followed by asc or desc.
+package query
+
+import (
+	"fmt"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// Query is the filter and the sorts of a database query.
+type Query struct {
+	Filter notion.Filter
+	Sorts  []notion.Sort
+}
+
+// Parameters returns the parameters of the query of the database.
+func (q Query) Parameters(databaseID string) notion.DatabasesQueryParameters {
+	return notion.DatabasesQueryParameters{DatabaseID: databaseID, Filter: q.Filter, Sorts: q.Sorts}
+}
+
+// Error is an error in a query, at the given byte offset.
+type Error struct {
+	Offset  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("query: offset %d: %s", e.Offset, e.Message)
+}
+
+// Operators of the conditions.
+const (
+	opEquals         = "="
+	opDoesNotEqual   = "!="
+	opLessThan       = "<"
+	opLessOrEqual    = "<="
+	opGreaterThan    = ">"
+	opGreaterOrEqual = ">="
+	opContains       = "contains"
+	opDoesNotContain = "does not contain"
+	opStartsWith     = "starts with"
+	opEndsWith       = "ends with"
+	opIsEmpty        = "is empty"
+	opIsNotEmpty     = "is not empty"
+	opBefore         = "before"
+	opAfter          = "after"
+	opOnOrBefore     = "on or before"
+	opOnOrAfter      = "on or after"
+	opPastWeek       = "in past week"
+	opPastMonth      = "in past month"
+	opPastYear       = "in past year"
+	opNextWeek       = "in next week"
+	opNextMonth      = "in next month"
+	opNextYear       = "in next year"
+)
+
+// wordOperators are the operators made of words, the longest first where they share a prefix.
+var wordOperators = []string{
+	opContains, opDoesNotContain, opStartsWith, opEndsWith, opIsNotEmpty, opIsEmpty,
+	opBefore, opAfter, opOnOrBefore, opOnOrAfter,
+	opPastWeek, opPastMonth, opPastYear, opNextWeek, opNextMonth, opNextYear,
+}
+
+// isDateOperator reports whether the operator only applies to dates.
+func isDateOperator(op string) bool {
+	switch op {
+	case opBefore, opAfter, opOnOrBefore, opOnOrAfter, opPastWeek, opPastMonth, opPastYear, opNextWeek, opNextMonth, opNextYear:
+		return true
+	}
+
+	return false
+}
+
+// hasValue reports whether the operator is followed by a value.
+func hasValue(op string) bool {
+	switch op {
+	case opIsEmpty, opIsNotEmpty, opPastWeek, opPastMonth, opPastYear, opNextWeek, opNextMonth, opNextYear:
+		return false
+	}
+
+	return true
+}
+
+// valueKind is the kind of the value of a condition.
+type valueKind int
+
+const (
+	noValue valueKind = iota
+	stringValue
+	numberValue
+	boolValue
+)
+
+type value struct {
+	kind valueKind
+	s    string
+	n    float64
+	b    bool
+}
+
+// inferPropertyType returns the type of the property of a condition whose type is not given, see the package
+// documentation. Text conditions are built as title conditions, whose filter applies to titles and rich texts.
+func inferPropertyType(op string, v value) notion.PropertyType {
+	switch {
+	case isDateOperator(op):
+		return notion.PropertyTypeDate
+
+	case v.kind == numberValue:
+		return notion.PropertyTypeNumber
+
+	case v.kind == boolValue:
+		return notion.PropertyTypeCheckbox
+
+	case v.kind == stringValue && (op == opLessThan || op == opLessOrEqual || op == opGreaterThan || op == opGreaterOrEqual):
+		return notion.PropertyTypeDate
+	}
+
+	return notion.PropertyTypeTitle
+}
+
+// propertyTypes are the types which can be given after the names of the properties.
+var propertyTypes = map[string]notion.PropertyType{
+	"title":            notion.PropertyTypeTitle,
+	"text":             notion.PropertyTypeTitle,
+	"rich_text":        notion.PropertyTypeRichText,
+	"url":              notion.PropertyTypeURL,
+	"email":            notion.PropertyTypeEmail,
+	"phone_number":     notion.PropertyTypePhoneNumber,
+	"number":           notion.PropertyTypeNumber,
+	"checkbox":         notion.PropertyTypeCheckbox,
+	"select":           notion.PropertyTypeSelect,
+	"multi_select":     notion.PropertyTypeMultiSelect,
+	"date":             notion.PropertyTypeDate,
+	"created_time":     notion.PropertyTypeCreatedTime,
+	"last_edited_time": notion.PropertyTypeLastEditedTime,
+	"people":           notion.PropertyTypePeople,
+	"created_by":       notion.PropertyTypeCreatedBy,
+	"last_edited_by":   notion.PropertyTypeLastEditedBy,
+	"file":             notion.PropertyTypeFile,
+	"files":            notion.PropertyTypeFile,
+	"relation":         notion.PropertyTypeRelation,
+	"formula":          notion.PropertyTypeFormula,
+}
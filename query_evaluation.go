@@ -0,0 +1,763 @@
+package notion
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MatchFilter reports whether the page matches the filter, as Databases().Query would, e.g. to filter pages
+// fetched earlier. Text conditions ignore case, and dates without time are compared day by day. Relative dates,
+// such as past week, are relative to the day of now. Properties missing from the page, which Notion leaves out
+// when they are empty, are empty: they only match the is empty, does not equal and does not contain conditions.
+// Invalid filters, e.g. filters without condition, are reported by an error matching ErrInvalidQuery.
+func MatchFilter(page Page, filter Filter, now time.Time) (bool, error) {
+	e := filterEvaluator{page: page, now: now}
+
+	return e.match("filter", filter)
+}
+
+// FilterPages returns the pages matching the filter in their order, see MatchFilter.
+func FilterPages(pages []Page, filter Filter, now time.Time) ([]Page, error) {
+	matches := make([]Page, 0, len(pages))
+
+	for _, page := range pages {
+		ok, err := MatchFilter(page, filter, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			matches = append(matches, page)
+		}
+	}
+
+	return matches, nil
+}
+
+// SortPages sorts the pages in place as Databases().Query would: by the first sort, then by the following ones
+// for equal values. The sort is stable, and empty values come last in both directions. Texts are sorted
+// regardless of case, selects and multi selects by the names of their (first) options, people by the IDs of
+// their (first) users, formulas and rollups by their results.
+func SortPages(pages []Page, sorts []Sort) error {
+	for i, s := range sorts {
+		switch {
+		case s.Property != "" && s.Timestamp != "":
+			return fmt.Errorf("%w: sorts[%d]: sort by both property %q and timestamp %q", ErrInvalidQuery, i, s.Property, s.Timestamp)
+		case s.Property == "" && s.Timestamp == "":
+			return fmt.Errorf("%w: sorts[%d]: sort by neither a property nor a timestamp", ErrInvalidQuery, i)
+		}
+	}
+
+	keys := make([][]sortKey, len(pages))
+	for i, page := range pages {
+		keys[i] = make([]sortKey, len(sorts))
+		for j, s := range sorts {
+			keys[i][j] = sortKeyOf(page, s)
+		}
+	}
+
+	indexes := make([]int, len(pages))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(a, b int) bool {
+		for j, s := range sorts {
+			c := compareSortKeys(keys[indexes[a]][j], keys[indexes[b]][j], s.Direction == SortDirectionDescending)
+			if c != 0 {
+				return c < 0
+			}
+		}
+
+		return false
+	})
+
+	sorted := make([]Page, len(pages))
+	for i, index := range indexes {
+		sorted[i] = pages[index]
+	}
+
+	copy(pages, sorted)
+
+	return nil
+}
+
+type filterEvaluator struct {
+	page Page
+	now  time.Time
+}
+
+func (e filterEvaluator) invalid(path, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s: %s", ErrInvalidQuery, path, fmt.Sprintf(format, args...))
+}
+
+// nolint: cyclop
+func (e filterEvaluator) match(path string, filter Filter) (bool, error) {
+	switch f := derefFilter(filter).(type) {
+	case nil:
+		return false, e.invalid(path, "nil filter")
+
+	case CompoundFilter:
+		return e.matchCompound(path, f)
+
+	case SingleTextFilter:
+		return e.matchText(path, f)
+
+	case SingleNumberFilter:
+		n, ok := numberOf(e.property(f.Property))
+
+		return e.condition(path, f.Property)(matchNumber(f.Number, n, ok))
+
+	case SingleCheckboxFilter:
+		value, _ := derefPropertyValue(e.property(f.Property)).(CheckboxPropertyValue)
+
+		return e.condition(path, f.Property)(matchCheckbox(f.Checkbox, value.Checkbox))
+
+	case SingleSelectFilter:
+		value, ok := derefPropertyValue(e.property(f.Property)).(SelectPropertyValue)
+
+		return e.condition(path, f.Property)(matchSelect(f.Select, value.Select.Name, ok && value.Select.Name != ""))
+
+	case SingleMultiSelectFilter:
+		value, _ := derefPropertyValue(e.property(f.Property)).(MultiSelectPropertyValue)
+
+		names := make([]string, 0, len(value.MultiSelect))
+		for _, option := range value.MultiSelect {
+			names = append(names, option.Name)
+		}
+
+		return e.condition(path, f.Property)(matchList(f.MultiSelect.Contains, f.MultiSelect.DoesNotContain,
+			f.MultiSelect.IsEmpty, f.MultiSelect.IsNotEmpty, names))
+
+	case SingleDateFilter:
+		return e.matchDate(path, f)
+
+	case SinglePeopleFilter:
+		return e.matchPeople(path, f)
+
+	case SingleFilesFilter:
+		value, _ := derefPropertyValue(e.property(f.Property)).(FilesPropertyValue)
+
+		return e.condition(path, f.Property)(matchList(nil, nil, f.Files.IsEmpty, f.Files.IsNotEmpty, make([]string, len(value.Files))))
+
+	case SingleRelationFilter:
+		value, _ := derefPropertyValue(e.property(f.Property)).(RelationPropertyValue)
+
+		ids := make([]string, 0, len(value.Relation))
+		for _, page := range value.Relation {
+			ids = append(ids, page.ID)
+		}
+
+		return e.condition(path, f.Property)(matchList(f.Relation.Contains, f.Relation.DoesNotContain,
+			f.Relation.IsEmpty, f.Relation.IsNotEmpty, ids))
+
+	case SingleFormulaFilter:
+		return e.matchFormula(path, f)
+	}
+
+	return false, e.invalid(path, "unsupported filter %T", filter)
+}
+
+func (e filterEvaluator) matchCompound(path string, filter CompoundFilter) (bool, error) {
+	switch {
+	case len(filter.And) > 0 && len(filter.Or) > 0:
+		return false, e.invalid(path, "compound filter with both and and or, nest one in the other")
+	case len(filter.And) == 0 && len(filter.Or) == 0:
+		return false, e.invalid(path, "compound filter without filters")
+	}
+
+	for i, f := range filter.And {
+		ok, err := e.match(fmt.Sprintf("%s.and[%d]", path, i), f)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	if len(filter.And) > 0 {
+		return true, nil
+	}
+
+	// Every filter is checked, so that invalid filters are reported whatever the page.
+	var matches bool
+
+	for i, f := range filter.Or {
+		ok, err := e.match(fmt.Sprintf("%s.or[%d]", path, i), f)
+		if err != nil {
+			return false, err
+		}
+
+		matches = matches || ok
+	}
+
+	return matches, nil
+}
+
+// condition returns a function returning the result of a condition of the filter on the property, or an error
+// if the filter has no condition, e.g. e.condition(path, name)(matchNumber(...)).
+func (e filterEvaluator) condition(path, name string) func(matches, set bool) (bool, error) {
+	return func(matches, set bool) (bool, error) {
+		if !set {
+			return false, e.invalid(path, "filter on property %q without condition", name)
+		}
+
+		return matches, nil
+	}
+}
+
+// property returns the value of the property with the given name or identifier, or nil if the page does not
+// have it.
+func (e filterEvaluator) property(name string) PropertyValue {
+	if value, ok := e.page.Properties[name]; ok {
+		return value
+	}
+
+	for _, value := range e.page.Properties {
+		if rv := reflect.Indirect(reflect.ValueOf(value)); rv.IsValid() && rv.Kind() == reflect.Struct &&
+			rv.FieldByName("ID").String() == name {
+			return value
+		}
+	}
+
+	return nil
+}
+
+func (e filterEvaluator) matchText(path string, filter SingleTextFilter) (bool, error) {
+	var (
+		conditions []*TextFilter
+		text       string
+	)
+
+	for _, condition := range []*TextFilter{filter.Text, filter.RichText, filter.URL, filter.Email, filter.Phone} {
+		if condition != nil {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	if len(conditions) != 1 {
+		return false, e.invalid(path, "filter on property %q with %d text conditions", filter.Property, len(conditions))
+	}
+
+	switch v := derefPropertyValue(e.property(filter.Property)).(type) {
+	case TitlePropertyValue:
+		text = PlainText(v.Title)
+	case RichTextPropertyValue:
+		text = PlainText(v.RichText)
+	case URLPropertyValue:
+		text = v.URL
+	case EmailPropertyValue:
+		text = v.Email
+	case PhoneNumberPropertyValue:
+		text = v.PhoneNumber
+	}
+
+	return e.condition(path, filter.Property)(matchText(conditions[0], text))
+}
+
+// matchText reports whether the text matches the conditions, and whether a condition is set.
+func matchText(f *TextFilter, text string) (bool, bool) {
+	matches, set := true, false
+	text = strings.ToLower(text)
+
+	check := func(value *string, match func(text, value string) bool) {
+		if value != nil {
+			set = true
+			matches = matches && match(text, strings.ToLower(*value))
+		}
+	}
+
+	check(f.Equals, func(text, value string) bool { return text == value })
+	check(f.DoesNotEqual, func(text, value string) bool { return text != value })
+	check(f.Contains, func(text, value string) bool { return text != "" && strings.Contains(text, value) })
+	check(f.DoesNotContain, func(text, value string) bool { return !strings.Contains(text, value) })
+	check(f.StartsWith, func(text, value string) bool { return text != "" && strings.HasPrefix(text, value) })
+	check(f.EndsWith, func(text, value string) bool { return text != "" && strings.HasSuffix(text, value) })
+
+	if f.IsEmpty != nil && *f.IsEmpty {
+		set = true
+		matches = matches && text == ""
+	}
+
+	if f.IsNotEmpty != nil && *f.IsNotEmpty {
+		set = true
+		matches = matches && text != ""
+	}
+
+	return matches, set
+}
+
+// numberOf returns the number of a number property value, and false if it is empty.
+func numberOf(value PropertyValue) (float64, bool) {
+	v, ok := derefPropertyValue(value).(NumberPropertyValue)
+
+	return v.Number, ok
+}
+
+// matchNumber reports whether the number, which is empty unless ok, matches the conditions, and whether
+// a condition is set.
+func matchNumber(f NumberFilter, n float64, ok bool) (bool, bool) {
+	matches, set := true, false
+
+	check := func(value *float64, match func(n, value float64) bool) {
+		if value != nil {
+			set = true
+			matches = matches && match(n, *value)
+		}
+	}
+
+	check(f.Equals, func(n, value float64) bool { return ok && n == value })
+	check(f.DoesNotEqual, func(n, value float64) bool { return !ok || n != value })
+	check(f.GreaterThan, func(n, value float64) bool { return ok && n > value })
+	check(f.LessThan, func(n, value float64) bool { return ok && n < value })
+	check(f.GreaterThanOrEqualTo, func(n, value float64) bool { return ok && n >= value })
+	check(f.LessThanOrEqualTo, func(n, value float64) bool { return ok && n <= value })
+
+	if f.IsEmpty {
+		set = true
+		matches = matches && !ok
+	}
+
+	if f.IsNotEmpty {
+		set = true
+		matches = matches && ok
+	}
+
+	return matches, set
+}
+
+func matchCheckbox(f CheckboxFilter, checked bool) (bool, bool) {
+	switch {
+	case f.Equals:
+		return checked, true
+	case f.DoesNotEqual:
+		return !checked, true
+	}
+
+	return false, false
+}
+
+func matchSelect(f SelectFilter, name string, ok bool) (bool, bool) {
+	switch {
+	case f.Equals != nil:
+		return ok && name == *f.Equals, true
+	case f.DoesNotEqual != nil:
+		return !ok || name != *f.DoesNotEqual, true
+	case f.IsEmpty:
+		return !ok, true
+	case f.IsNotEmpty:
+		return ok, true
+	}
+
+	return false, false
+}
+
+// matchList matches the options of multi selects, the users of people, the pages of relations and the files.
+func matchList(contains, doesNotContain *string, isEmpty, isNotEmpty bool, values []string) (bool, bool) {
+	has := func(s string) bool {
+		for _, value := range values {
+			if value == s {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	switch {
+	case contains != nil:
+		return has(*contains), true
+	case doesNotContain != nil:
+		return !has(*doesNotContain), true
+	case isEmpty:
+		return len(values) == 0, true
+	case isNotEmpty:
+		return len(values) > 0, true
+	}
+
+	return false, false
+}
+
+func (e filterEvaluator) matchDate(path string, filter SingleDateFilter) (bool, error) {
+	var (
+		conditions []*DateFilter
+		date       string
+	)
+
+	for _, condition := range []*DateFilter{filter.Date, filter.CreatedTime, filter.LastEditedTime} {
+		if condition != nil {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	if len(conditions) != 1 {
+		return false, e.invalid(path, "filter on property %q with %d date conditions", filter.Property, len(conditions))
+	}
+
+	switch v := derefPropertyValue(e.property(filter.Property)).(type) {
+	case DatePropertyValue:
+		date = v.Date.Start
+	case CreatedTimePropertyValue:
+		date = v.CreatedTime.Format(time.RFC3339Nano)
+	case LastEditedTimePropertyValue:
+		date = v.LastEditedTime.Format(time.RFC3339Nano)
+	case nil:
+		// The timestamps of the page are those of the created and last edited time properties.
+		switch {
+		case filter.CreatedTime != nil:
+			date = e.page.CreatedTime.Format(time.RFC3339Nano)
+		case filter.LastEditedTime != nil:
+			date = e.page.LastEditedTime.Format(time.RFC3339Nano)
+		}
+	}
+
+	matches, set, err := matchDate(conditions[0], date, e.now)
+	if err != nil {
+		return false, e.invalid(path, "%s", err)
+	}
+
+	return e.condition(path, filter.Property)(matches, set)
+}
+
+// matchDate reports whether the date, which is empty if "", matches the conditions, and whether a condition
+// is set. Dates are compared day by day when either of them has no time.
+// nolint: cyclop, funlen
+func matchDate(f *DateFilter, date string, now time.Time) (bool, bool, error) {
+	var (
+		t       time.Time
+		invalid error
+	)
+
+	if date != "" {
+		var err error
+		if t, err = parseDate(date); err != nil {
+			return false, true, err
+		}
+	}
+
+	matches, set := true, false
+
+	check := func(value *string, match func(c int) bool) {
+		if value == nil {
+			return
+		}
+
+		set = true
+
+		other, err := parseDate(*value)
+		if err != nil {
+			invalid = err
+
+			return
+		}
+
+		matches = matches && date != "" && match(compareDates(t, len(date) == len(dateLayout), other, len(*value) == len(dateLayout)))
+	}
+
+	check(f.Equals, func(c int) bool { return c == 0 })
+	check(f.Before, func(c int) bool { return c < 0 })
+	check(f.After, func(c int) bool { return c > 0 })
+	check(f.OnOrBefore, func(c int) bool { return c <= 0 })
+	check(f.OnOrAfter, func(c int) bool { return c >= 0 })
+
+	if invalid != nil {
+		return false, true, invalid
+	}
+
+	if f.IsEmpty {
+		set = true
+		matches = matches && date == ""
+	}
+
+	if f.IsNotEmpty {
+		set = true
+		matches = matches && date != ""
+	}
+
+	day := t.Format(dateLayout)
+
+	between := func(relative map[string]interface{}, from, to time.Time) {
+		if relative != nil {
+			set = true
+			matches = matches && date != "" && from.Format(dateLayout) <= day && day <= to.Format(dateLayout)
+		}
+	}
+
+	between(f.PastWeek, now.AddDate(0, 0, -7), now)
+	between(f.PastMonth, now.AddDate(0, -1, 0), now)
+	between(f.PastYear, now.AddDate(-1, 0, 0), now)
+	between(f.NextWeek, now, now.AddDate(0, 0, 7))
+	between(f.NextMonth, now, now.AddDate(0, 1, 0))
+	between(f.NextYear, now, now.AddDate(1, 0, 0))
+
+	return matches, set, nil
+}
+
+// compareDates compares two dates, day by day when either of them is a date without time.
+func compareDates(a time.Time, aDateOnly bool, b time.Time, bDateOnly bool) int {
+	if aDateOnly || bDateOnly {
+		return strings.Compare(a.Format(dateLayout), b.Format(dateLayout))
+	}
+
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+
+	return 0
+}
+
+func (e filterEvaluator) matchPeople(path string, filter SinglePeopleFilter) (bool, error) {
+	var (
+		conditions []*PeopleFilter
+		ids        []string
+	)
+
+	for _, condition := range []*PeopleFilter{filter.People, filter.CreatedBy, filter.LastEditedBy} {
+		if condition != nil {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	if len(conditions) != 1 {
+		return false, e.invalid(path, "filter on property %q with %d people conditions", filter.Property, len(conditions))
+	}
+
+	switch v := derefPropertyValue(e.property(filter.Property)).(type) {
+	case PeoplePropertyValue:
+		for _, user := range v.People {
+			ids = append(ids, userID(user))
+		}
+	case CreatedByPropertyValue:
+		ids = append(ids, userID(v.CreatedBy))
+	case LastEditedByPropertyValue:
+		ids = append(ids, userID(v.LastEditedBy))
+	}
+
+	f := conditions[0]
+
+	return e.condition(path, filter.Property)(matchList(f.Contains, f.DoesNotContain, f.IsEmpty, f.IsNotEmpty, ids))
+}
+
+// nolint: cyclop
+func (e filterEvaluator) matchFormula(path string, filter SingleFormulaFilter) (bool, error) {
+	value, _ := derefPropertyValue(e.property(filter.Property)).(FormulaPropertyValue)
+	result := formulaResult(value.Formula)
+	f := filter.Formula
+
+	switch {
+	case f.Text != nil:
+		text, _ := result.(string)
+
+		return e.condition(path, filter.Property)(matchText(f.Text, text))
+
+	case f.Checkbox != nil:
+		checked, _ := result.(bool)
+
+		return e.condition(path, filter.Property)(matchCheckbox(*f.Checkbox, checked))
+
+	case f.Number != nil:
+		n, ok := result.(float64)
+
+		return e.condition(path, filter.Property)(matchNumber(*f.Number, n, ok))
+
+	case f.Date != nil:
+		date, _ := result.(Date)
+
+		matches, set, err := matchDate(f.Date, date.Start, e.now)
+		if err != nil {
+			return false, e.invalid(path, "%s", err)
+		}
+
+		return e.condition(path, filter.Property)(matches, set)
+	}
+
+	return false, e.invalid(path, "filter on property %q without condition", filter.Property)
+}
+
+// formulaResult returns the result of a formula as a string, a float64, a bool or a Date, or nil if it is empty.
+func formulaResult(formula FormulaValue) interface{} {
+	if rv := reflect.ValueOf(formula); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		formula, _ = rv.Elem().Interface().(FormulaValue)
+	}
+
+	switch v := formula.(type) {
+	case StringFormulaValue:
+		if v.String != nil && *v.String != "" {
+			return *v.String
+		}
+	case NumberFormulaValue:
+		if v.Number != nil {
+			return *v.Number
+		}
+	case BooleanFormulaValue:
+		return v.Boolean
+	case DateFormulaValue:
+		if v.Date.Date.Start != "" {
+			return v.Date.Date
+		}
+	}
+
+	return nil
+}
+
+// sortKey is a value sorted by SortPages, nil for empty values.
+type sortKey interface{}
+
+// sortKeyOf returns the value of the page sorted by s.
+// nolint: cyclop
+func sortKeyOf(page Page, s Sort) sortKey {
+	switch s.Timestamp {
+	case SortTimestampByCreatedTime:
+		return page.CreatedTime
+	case SortTimestampByLastEditedTime:
+		return page.LastEditedTime
+	}
+
+	switch v := derefPropertyValue(filterEvaluator{page: page}.property(s.Property)).(type) {
+	case TitlePropertyValue:
+		return textKey(PlainText(v.Title))
+	case RichTextPropertyValue:
+		return textKey(PlainText(v.RichText))
+	case URLPropertyValue:
+		return textKey(v.URL)
+	case EmailPropertyValue:
+		return textKey(v.Email)
+	case PhoneNumberPropertyValue:
+		return textKey(v.PhoneNumber)
+	case NumberPropertyValue:
+		return v.Number
+	case CheckboxPropertyValue:
+		return v.Checkbox
+	case SelectPropertyValue:
+		return textKey(v.Select.Name)
+	case MultiSelectPropertyValue:
+		if len(v.MultiSelect) > 0 {
+			return textKey(v.MultiSelect[0].Name)
+		}
+	case DatePropertyValue:
+		return dateKey(v.Date)
+	case CreatedTimePropertyValue:
+		return v.CreatedTime
+	case LastEditedTimePropertyValue:
+		return v.LastEditedTime
+	case PeoplePropertyValue:
+		if len(v.People) > 0 {
+			return userID(v.People[0])
+		}
+	case CreatedByPropertyValue:
+		return userID(v.CreatedBy)
+	case LastEditedByPropertyValue:
+		return userID(v.LastEditedBy)
+	case FormulaPropertyValue:
+		switch result := formulaResult(v.Formula).(type) {
+		case string:
+			return textKey(result)
+		case Date:
+			return dateKey(result)
+		default:
+			return result
+		}
+	case RollupPropertyValue:
+		return rollupKey(v.Rollup)
+	}
+
+	return nil
+}
+
+// textKey returns the key of a text, nil if it is empty.
+func textKey(text string) sortKey {
+	if text == "" {
+		return nil
+	}
+
+	return text
+}
+
+// dateKey returns the key of a date, its start, nil if it is empty or invalid.
+func dateKey(date Date) sortKey {
+	t, err := parseDate(date.Start)
+	if err != nil {
+		return nil
+	}
+
+	return t
+}
+
+func rollupKey(rollup RollupValueType) sortKey {
+	if rv := reflect.ValueOf(rollup); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rollup, _ = rv.Elem().Interface().(RollupValueType)
+	}
+
+	switch v := rollup.(type) {
+	case NumberRollupValue:
+		if v.Number != nil {
+			return *v.Number
+		}
+	case DateRollupValue:
+		return dateKey(v.Date)
+	}
+
+	return nil
+}
+
+// compareSortKeys returns -1, 0 or 1 when the key k comes before, with or after the other, empty keys last.
+// nolint: cyclop
+func compareSortKeys(k, other sortKey, descending bool) int {
+	switch {
+	case k == nil && other == nil:
+		return 0
+	case k == nil:
+		return 1
+	case other == nil:
+		return -1
+	}
+
+	c := 0
+
+	switch a := k.(type) {
+	case string:
+		b, _ := other.(string)
+		if c = strings.Compare(strings.ToLower(a), strings.ToLower(b)); c == 0 {
+			c = strings.Compare(a, b)
+		}
+	case float64:
+		b, _ := other.(float64)
+		c = compareFloats(a, b)
+	case bool:
+		b, _ := other.(bool)
+		c = compareFloats(boolToFloat(a), boolToFloat(b))
+	case time.Time:
+		b, _ := other.(time.Time)
+		c = compareDates(a, false, b, false)
+	}
+
+	if descending {
+		return -c
+	}
+
+	return c
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b, math.IsNaN(a) && !math.IsNaN(b):
+		return -1
+	case a > b, math.IsNaN(b) && !math.IsNaN(a):
+		return 1
+	}
+
+	return 0
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
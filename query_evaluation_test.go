@@ -0,0 +1,399 @@
+package notion
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedQueries are the pages of a database and the results of queries of the database recorded from the API.
+type recordedQueries struct {
+	RecordedAt time.Time              `json:"recorded_at"`
+	Pages      DatabasesQueryResponse `json:"pages"`
+	Queries    map[string]struct {
+		Request json.RawMessage `json:"request"`
+		Results []string        `json:"results"`
+	} `json:"queries"`
+}
+
+func newString(s string) *string {
+	return &s
+}
+
+func TestFilterPages_Recorded(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/query_evaluation.json")
+	require.NoError(t, err)
+
+	var recorded recordedQueries
+
+	require.NoError(t, json.Unmarshal(data, &recorded))
+
+	property := func(name string) SinglePropertyFilter {
+		return SinglePropertyFilter{Property: name}
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		sorts  []Sort
+	}{
+		{
+			name:   "select equals",
+			filter: SingleSelectFilter{SinglePropertyFilter: property("Status"), Select: SelectFilter{Equals: newString("Done")}},
+			sorts:  []Sort{{Property: "Name", Direction: SortDirectionAscending}},
+		},
+		{
+			name: "compound",
+			filter: &CompoundFilter{And: []Filter{
+				SingleSelectFilter{SinglePropertyFilter: property("Status"), Select: SelectFilter{Equals: newString("Done")}},
+				CompoundFilter{Or: []Filter{
+					SingleNumberFilter{SinglePropertyFilter: property("Priority"), Number: NumberFilter{GreaterThanOrEqualTo: newFloat64(3)}},
+					SingleMultiSelectFilter{SinglePropertyFilter: property("Tags"), MultiSelect: MultiSelectFilter{Contains: newString("urgent")}},
+				}},
+			}},
+		},
+		{
+			name:   "text contains ignoring case",
+			filter: SingleTextFilter{SinglePropertyFilter: property("Name"), Text: &TextFilter{Contains: newString("Kale")}},
+			sorts:  []Sort{{Property: "Name", Direction: SortDirectionAscending}},
+		},
+		{
+			name:   "number is empty",
+			filter: SingleNumberFilter{SinglePropertyFilter: property("Priority"), Number: NumberFilter{IsEmpty: true}},
+		},
+		{
+			name:   "number does not equal",
+			filter: SingleNumberFilter{SinglePropertyFilter: property("Priority"), Number: NumberFilter{DoesNotEqual: newFloat64(3)}},
+			sorts:  []Sort{{Property: "Priority", Direction: SortDirectionDescending}},
+		},
+		{
+			name:   "checkbox does not equal",
+			filter: SingleCheckboxFilter{SinglePropertyFilter: property("Done"), Checkbox: CheckboxFilter{DoesNotEqual: true}},
+			sorts:  []Sort{{Timestamp: SortTimestampByCreatedTime, Direction: SortDirectionAscending}},
+		},
+		{
+			name:   "date past week",
+			filter: SingleDateFilter{SinglePropertyFilter: property("Due"), Date: &DateFilter{PastWeek: map[string]interface{}{}}},
+			sorts:  []Sort{{Property: "Due", Direction: SortDirectionAscending}},
+		},
+		{
+			name:   "date next month",
+			filter: SingleDateFilter{SinglePropertyFilter: property("Due"), Date: &DateFilter{NextMonth: map[string]interface{}{}}},
+		},
+		{
+			name:   "date on or after",
+			filter: SingleDateFilter{SinglePropertyFilter: property("Due"), Date: &DateFilter{OnOrAfter: newString("2021-05-25")}},
+			sorts:  []Sort{{Property: "Due", Direction: SortDirectionDescending}},
+		},
+		{
+			name:   "date is empty",
+			filter: SingleDateFilter{SinglePropertyFilter: property("Due"), Date: &DateFilter{IsEmpty: true}},
+		},
+		{
+			name: "people contains",
+			filter: SinglePeopleFilter{
+				SinglePropertyFilter: property("Owner"),
+				People:               &PeopleFilter{Contains: newString("92e1c7a4-5b8d-4f3e-a2c1-0d9e8f7a6b52")},
+			},
+			sorts: []Sort{{Property: "Name", Direction: SortDirectionAscending}},
+		},
+		{
+			name: "formula number",
+			filter: SingleFormulaFilter{
+				SinglePropertyFilter: property("Total"),
+				Formula:              FormulaFilter{Number: &NumberFilter{GreaterThan: newFloat64(5)}},
+			},
+			sorts: []Sort{
+				{Property: "Total", Direction: SortDirectionDescending},
+				{Property: "Name", Direction: SortDirectionAscending},
+			},
+		},
+		{
+			name: "formula text",
+			filter: CompoundFilter{Or: []Filter{
+				SingleFormulaFilter{SinglePropertyFilter: property("Label"), Formula: FormulaFilter{Text: &TextFilter{IsEmpty: newBool(true)}}},
+				SingleFormulaFilter{SinglePropertyFilter: property("Label"), Formula: FormulaFilter{Text: &TextFilter{Equals: newString("Low")}}},
+			}},
+			sorts: []Sort{{Timestamp: SortTimestampByLastEditedTime, Direction: SortDirectionDescending}},
+		},
+		{
+			name: "multi select does not contain",
+			filter: SingleMultiSelectFilter{
+				SinglePropertyFilter: property("Tags"),
+				MultiSelect:          MultiSelectFilter{DoesNotContain: newString("urgent")},
+			},
+			sorts: []Sort{{Property: "Priority", Direction: SortDirectionAscending}},
+		},
+		{
+			name: "relation contains",
+			filter: SingleRelationFilter{
+				SinglePropertyFilter: property("Related"),
+				Relation:             RelationFilter{Contains: newString("1f3ab0c2-7d1e-4b55-9c8a-0e2f3d4c5b61")},
+			},
+		},
+		{
+			name: "created time on or before",
+			filter: SingleDateFilter{
+				SinglePropertyFilter: property("Created"),
+				CreatedTime:          &DateFilter{OnOrBefore: newString("2021-05-01")},
+			},
+			sorts: []Sort{{Property: "Created", Direction: SortDirectionDescending}},
+		},
+		{
+			name: "last edited time past week",
+			filter: SingleDateFilter{
+				SinglePropertyFilter: property("Edited"),
+				LastEditedTime:       &DateFilter{PastWeek: map[string]interface{}{}},
+			},
+			sorts: []Sort{{Property: "Edited", Direction: SortDirectionAscending}},
+		},
+		{
+			name:   "url is not empty",
+			filter: SingleTextFilter{SinglePropertyFilter: property("Website"), URL: &TextFilter{IsNotEmpty: newBool(true)}},
+		},
+		{
+			name: "sorts only",
+			sorts: []Sort{
+				{Property: "Priority", Direction: SortDirectionDescending},
+				{Timestamp: SortTimestampByLastEditedTime, Direction: SortDirectionDescending},
+			},
+		},
+	}
+
+	assert.Len(t, tests, len(recorded.Queries))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, ok := recorded.Queries[tt.name]
+			require.True(t, ok)
+
+			// The filter and the sorts are those of the recorded request.
+			request, err := json.Marshal(DatabasesQueryParameters{Filter: tt.filter, Sorts: tt.sorts})
+			require.NoError(t, err)
+			assert.JSONEq(t, string(query.Request), string(request))
+
+			pages := recorded.Pages.Results
+
+			if tt.filter != nil {
+				pages, err = FilterPages(pages, tt.filter, recorded.RecordedAt)
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, SortPages(pages, tt.sorts))
+
+			ids := make([]string, 0, len(pages))
+			for _, page := range pages {
+				ids = append(ids, page.ID)
+			}
+
+			// The order of the pages is unspecified without sorts.
+			if len(tt.sorts) == 0 {
+				assert.ElementsMatch(t, query.Results, ids)
+			} else {
+				assert.Equal(t, query.Results, ids)
+			}
+		})
+	}
+}
+
+func TestMatchFilter(t *testing.T) {
+	now := time.Date(2021, 5, 20, 9, 0, 0, 0, time.UTC)
+
+	// Property values built locally, rather than decoded, and properties referred to by identifier.
+	page := Page{
+		CreatedTime: time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC),
+		Properties: map[string]PropertyValue{
+			"Name":  TitlePropertyValue{Title: []RichText{Text("Lacinato kale")}},
+			"Price": NumberPropertyValue{basePropertyValue: basePropertyValue{ID: "p%40q"}, Number: 2.5},
+			"At":    DatePropertyValue{Date: Date{Start: "2021-05-20T23:30:00.000-02:00"}},
+			"Late":  FormulaPropertyValue{Formula: BooleanFormulaValue{Boolean: true}},
+			"Due":   FormulaPropertyValue{Formula: &DateFormulaValue{Date: DatePropertyValue{Date: Date{Start: "2021-05-21"}}}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{
+			name:   "Text equals ignoring case",
+			filter: SingleTextFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "Name"}, Text: &TextFilter{Equals: newString("lacinato KALE")}},
+			want:   true,
+		},
+		{
+			name:   "Text starts with",
+			filter: SingleTextFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "Name"}, Text: &TextFilter{StartsWith: newString("kale")}},
+			want:   false,
+		},
+		{
+			name:   "Property by identifier",
+			filter: SingleNumberFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "p%40q"}, Number: NumberFilter{LessThan: newFloat64(3)}},
+			want:   true,
+		},
+		{
+			name:   "Missing property does not contain",
+			filter: SingleTextFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "Notes"}, RichText: &TextFilter{DoesNotContain: newString("a")}},
+			want:   true,
+		},
+		{
+			name:   "Missing property equals",
+			filter: SingleSelectFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "Status"}, Select: SelectFilter{Equals: newString("Done")}},
+			want:   false,
+		},
+		{
+			name: "Date with time compared with a date in its time zone",
+			filter: SingleDateFilter{
+				SinglePropertyFilter: SinglePropertyFilter{Property: "At"},
+				Date:                 &DateFilter{Equals: newString("2021-05-20")},
+			},
+			want: true,
+		},
+		{
+			name: "Dates with time compared as instants",
+			filter: SingleDateFilter{
+				SinglePropertyFilter: SinglePropertyFilter{Property: "At"},
+				Date:                 &DateFilter{After: newString("2021-05-21T01:00:00Z")},
+			},
+			want: true,
+		},
+		{
+			name: "Created time of the page",
+			filter: SingleDateFilter{
+				SinglePropertyFilter: SinglePropertyFilter{Property: "Created"},
+				CreatedTime:          &DateFilter{PastMonth: map[string]interface{}{}},
+			},
+			want: true,
+		},
+		{
+			name: "Formula of boolean",
+			filter: SingleFormulaFilter{
+				SinglePropertyFilter: SinglePropertyFilter{Property: "Late"},
+				Formula:              FormulaFilter{Checkbox: &CheckboxFilter{Equals: true}},
+			},
+			want: true,
+		},
+		{
+			name: "Formula of date",
+			filter: SingleFormulaFilter{
+				SinglePropertyFilter: SinglePropertyFilter{Property: "Due"},
+				Formula:              FormulaFilter{Date: &DateFilter{NextWeek: map[string]interface{}{}}},
+			},
+			want: true,
+		},
+		{
+			name: "Formula of date past year",
+			filter: SingleFormulaFilter{
+				SinglePropertyFilter: SinglePropertyFilter{Property: "Due"},
+				Formula:              FormulaFilter{Date: &DateFilter{PastYear: map[string]interface{}{}}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchFilter(page, tt.filter, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		want   string
+	}{
+		{
+			name:   "Nil filter",
+			filter: CompoundFilter{Or: []Filter{nil}},
+			want:   "invalid query: filter.or[0]: nil filter",
+		},
+		{
+			name:   "Compound filter without filters",
+			filter: CompoundFilter{},
+			want:   "invalid query: filter: compound filter without filters",
+		},
+		{
+			name:   "Filter without condition",
+			filter: SingleCheckboxFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "Done"}},
+			want:   `invalid query: filter: filter on property "Done" without condition`,
+		},
+		{
+			name:   "Text filter with several conditions",
+			filter: SingleTextFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "Name"}, Text: &TextFilter{}, URL: &TextFilter{}},
+			want:   `invalid query: filter: filter on property "Name" with 2 text conditions`,
+		},
+		{
+			name: "Invalid date",
+			filter: SingleDateFilter{
+				SinglePropertyFilter: SinglePropertyFilter{Property: "Due"},
+				Date:                 &DateFilter{Before: newString("tomorrow")},
+			},
+			want: `invalid query: filter: failed to parse date "tomorrow": parsing time "tomorrow" as "2006-01-02T15:04:05.999999999Z07:00": ` +
+				`cannot parse "tomorrow" as "2006"`,
+		},
+		{
+			name:   "Property filter",
+			filter: SinglePropertyFilter{Property: "Done"},
+			want:   "invalid query: filter: unsupported filter notion.SinglePropertyFilter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MatchFilter(Page{}, tt.filter, time.Now())
+			assert.ErrorIs(t, err, ErrInvalidQuery)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestSortPages(t *testing.T) {
+	page := func(id string, done bool, price *float64) Page {
+		properties := map[string]PropertyValue{"Done": &CheckboxPropertyValue{Checkbox: done}}
+		if price != nil {
+			properties["Price"] = &NumberPropertyValue{Number: *price}
+		}
+
+		return Page{ID: id, Properties: properties}
+	}
+
+	pages := []Page{
+		page("a", true, nil),
+		page("b", false, newFloat64(2)),
+		page("c", true, newFloat64(1)),
+		page("d", false, nil),
+		page("e", true, newFloat64(2)),
+	}
+
+	ids := func() []string {
+		var ids []string
+		for _, page := range pages {
+			ids = append(ids, page.ID)
+		}
+
+		return ids
+	}
+
+	// Equal values keep their order.
+	require.NoError(t, SortPages(pages, []Sort{{Property: "Done", Direction: SortDirectionDescending}}))
+	assert.Equal(t, []string{"a", "c", "e", "b", "d"}, ids())
+
+	// Empty values come last in both directions.
+	require.NoError(t, SortPages(pages, []Sort{{Property: "Price", Direction: SortDirectionDescending}}))
+	assert.Equal(t, []string{"e", "b", "c", "a", "d"}, ids())
+
+	require.NoError(t, SortPages(pages, []Sort{{Property: "Price"}}))
+	assert.Equal(t, []string{"c", "e", "b", "a", "d"}, ids())
+
+	err := SortPages(pages, []Sort{{Property: "Price"}, {Direction: SortDirectionAscending}})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	assert.EqualError(t, err, "invalid query: sorts[1]: sort by neither a property nor a timestamp")
+}
@@ -0,0 +1,332 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidQuery is matched by a *QueryValidationError, e.g. errors.Is(err, ErrInvalidQuery).
+var ErrInvalidQuery = errors.New("invalid query")
+
+// QueryProblem is a problem found in the filter or the sorts of a database query.
+type QueryProblem struct {
+	// Path to the faulty filter or sort, e.g. "filter.or[1].and[0]" or "sorts[2]".
+	Path string
+	// Description of the problem.
+	Message string
+}
+
+func (p QueryProblem) String() string {
+	return p.Path + ": " + p.Message
+}
+
+// QueryValidationError lists every problem found in a database query by ValidateQuery.
+type QueryValidationError struct {
+	// Identifier of the database.
+	DatabaseID string
+	// Problems in the order of the filter tree, then of the sorts.
+	Problems []QueryProblem
+}
+
+func (e *QueryValidationError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		problems = append(problems, problem.String())
+	}
+
+	return fmt.Sprintf("invalid query of database %s: %s", e.DatabaseID, strings.Join(problems, "; "))
+}
+
+// Is reports whether the target is ErrInvalidQuery.
+func (e *QueryValidationError) Is(target error) bool {
+	return target == ErrInvalidQuery // nolint: errorlint
+}
+
+// filterConditions lists the types of properties to which each condition of the filters applies, by the name
+// of the condition in the JSON filter.
+var filterConditions = map[string][]PropertyValueType{
+	"text":             {PropertyValueTypeTitle, PropertyValueTypeRichText},
+	"rich_text":        {PropertyValueTypeTitle, PropertyValueTypeRichText},
+	"url":              {PropertyValueTypeURL},
+	"email":            {PropertyValueTypeEmail},
+	"phone":            {PropertyValueTypePhoneNumber},
+	"number":           {PropertyValueTypeNumber},
+	"checkbox":         {PropertyValueTypeCheckbox},
+	"select":           {PropertyValueTypeSelect},
+	"multi_select":     {PropertyValueTypeMultiSelect},
+	"date":             {PropertyValueTypeDate},
+	"created_time":     {PropertyValueTypeCreatedTime},
+	"last_edited_time": {PropertyValueTypeLastEditedTime},
+	"people":           {PropertyValueTypePeople},
+	"created_by":       {PropertyValueTypeCreatedBy},
+	"last_edited_by":   {PropertyValueTypeLastEditedBy},
+	"files":            {PropertyValueTypeFiles},
+	"relation":         {PropertyValueTypeRelation},
+	"formula":          {PropertyValueTypeFormula},
+}
+
+// ValidateQuery checks the filter and the sorts of a query against the properties of the database, before
+// Notion rejects them with an opaque validation error: every property must exist, by name or identifier,
+// every filter condition must apply to the type of its property, e.g. a number condition to a number property,
+// every compound filter must combine filters with either and or or, and every sort must sort by either a
+// property or a timestamp. All the problems are reported by a *QueryValidationError.
+func ValidateQuery(db *Database, params DatabasesQueryParameters) error {
+	v := queryValidator{database: db}
+
+	if params.Filter != nil {
+		v.validateFilter("filter", params.Filter)
+	}
+
+	for i, s := range params.Sorts {
+		v.validateSort(fmt.Sprintf("sorts[%d]", i), s)
+	}
+
+	if len(v.problems) > 0 {
+		return &QueryValidationError{DatabaseID: db.ID, Problems: v.problems}
+	}
+
+	return nil
+}
+
+type queryValidator struct {
+	database *Database
+	problems []QueryProblem
+}
+
+func (v *queryValidator) report(path, format string, args ...interface{}) {
+	v.problems = append(v.problems, QueryProblem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// nolint: cyclop
+func (v *queryValidator) validateFilter(path string, filter Filter) {
+	switch f := derefFilter(filter).(type) {
+	case nil:
+		v.report(path, "nil filter")
+
+	case CompoundFilter:
+		v.validateCompoundFilter(path, f)
+
+	case SinglePropertyFilter:
+		v.validateCondition(path, f.Property, nil)
+
+	case SingleTextFilter:
+		v.validateCondition(path, f.Property, map[string]bool{
+			"text": f.Text != nil, "rich_text": f.RichText != nil, "url": f.URL != nil, "email": f.Email != nil, "phone": f.Phone != nil,
+		})
+
+	case SingleNumberFilter:
+		v.validateCondition(path, f.Property, map[string]bool{"number": true})
+
+	case SingleCheckboxFilter:
+		v.validateCondition(path, f.Property, map[string]bool{"checkbox": true})
+
+	case SingleSelectFilter:
+		v.validateCondition(path, f.Property, map[string]bool{"select": true})
+
+	case SingleMultiSelectFilter:
+		v.validateCondition(path, f.Property, map[string]bool{"multi_select": true})
+
+	case SingleDateFilter:
+		v.validateCondition(path, f.Property, map[string]bool{
+			"date": f.Date != nil, "created_time": f.CreatedTime != nil, "last_edited_time": f.LastEditedTime != nil,
+		})
+
+	case SinglePeopleFilter:
+		v.validateCondition(path, f.Property, map[string]bool{
+			"people": f.People != nil, "created_by": f.CreatedBy != nil, "last_edited_by": f.LastEditedBy != nil,
+		})
+
+	case SingleFilesFilter:
+		v.validateCondition(path, f.Property, map[string]bool{"files": true})
+
+	case SingleRelationFilter:
+		v.validateCondition(path, f.Property, map[string]bool{"relation": true})
+
+	case SingleFormulaFilter:
+		v.validateCondition(path, f.Property, map[string]bool{"formula": true})
+
+	default:
+		v.report(path, "unsupported filter %T", filter)
+	}
+}
+
+func (v *queryValidator) validateCompoundFilter(path string, filter CompoundFilter) {
+	switch {
+	case len(filter.And) > 0 && len(filter.Or) > 0:
+		v.report(path, "compound filter with both and and or, nest one in the other")
+
+	case len(filter.And) == 0 && len(filter.Or) == 0:
+		v.report(path, "compound filter without filters")
+	}
+
+	for i, f := range filter.And {
+		v.validateFilter(fmt.Sprintf("%s.and[%d]", path, i), f)
+	}
+
+	for i, f := range filter.Or {
+		v.validateFilter(fmt.Sprintf("%s.or[%d]", path, i), f)
+	}
+}
+
+// validateCondition checks that the property exists and that the condition which is set, among the conditions
+// of the filter, applies to the type of the property.
+func (v *queryValidator) validateCondition(path, name string, conditions map[string]bool) {
+	var set []string
+
+	for condition, ok := range conditions {
+		if ok {
+			set = append(set, condition)
+		}
+	}
+
+	switch {
+	case len(set) == 0:
+		v.report(path, "filter on property %q without condition", name)
+
+		return
+
+	case len(set) > 1:
+		sort.Strings(set)
+		v.report(path, "filter on property %q with several conditions: %s", name, strings.Join(set, ", "))
+
+		return
+	}
+
+	property, ok := v.property(path, name)
+	if !ok {
+		return
+	}
+
+	propertyType := propertyValueTypeOfProperty(property)
+
+	for _, t := range filterConditions[set[0]] {
+		if t == propertyType {
+			return
+		}
+	}
+
+	v.report(path, "a %s filter cannot apply to the %s property %q", set[0], propertyType, name)
+}
+
+func (v *queryValidator) validateSort(path string, s Sort) {
+	switch {
+	case s.Property != "" && s.Timestamp != "":
+		v.report(path, "sort by both property %q and timestamp %q", s.Property, s.Timestamp)
+
+	case s.Property == "" && s.Timestamp == "":
+		v.report(path, "sort by neither a property nor a timestamp")
+
+	case s.Property != "":
+		v.property(path, s.Property)
+	}
+}
+
+// property returns the property with the given name or identifier, or reports that it does not exist.
+func (v *queryValidator) property(path, name string) (Property, bool) {
+	if name == "" {
+		v.report(path, "no property set")
+
+		return nil, false
+	}
+
+	if property, ok := v.database.Properties[name]; ok && property != nil {
+		return property, true
+	}
+
+	for _, property := range v.database.Properties {
+		if property != nil && property.propertyID() == name {
+			return property, true
+		}
+	}
+
+	v.report(path, "no property %q", name)
+
+	return nil, false
+}
+
+// derefFilter returns the filter pointed by filter if it is a pointer, so that pointers to filters, e.g.
+// &CompoundFilter{...}, are handled as the filters themselves.
+func derefFilter(filter Filter) Filter {
+	rv := reflect.ValueOf(filter)
+	if rv.Kind() != reflect.Ptr {
+		return filter
+	}
+
+	if rv.IsNil() {
+		return nil
+	}
+
+	if f, ok := rv.Elem().Interface().(Filter); ok {
+		return f
+	}
+
+	return filter
+}
+
+// schemaCache keeps the databases retrieved to validate queries, see WithQueryValidation.
+type schemaCache struct {
+	mu        sync.Mutex
+	databases map[string]*Database
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{databases: make(map[string]*Database)}
+}
+
+// get returns the database with the given ID, retrieving it the first time, and whether it was just retrieved.
+func (c *schemaCache) get(ctx context.Context, databaseID string,
+	retrieve func(context.Context, DatabasesRetrieveParameters) (*DatabasesRetrieveResponse, error)) (*Database, bool, error) {
+	c.mu.Lock()
+	database, ok := c.databases[databaseID]
+	c.mu.Unlock()
+
+	if ok {
+		return database, false, nil
+	}
+
+	resp, err := retrieve(ctx, DatabasesRetrieveParameters{DatabaseID: databaseID})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve database %s to validate the query: %w", databaseID, err)
+	}
+
+	c.mu.Lock()
+	c.databases[databaseID] = &resp.Database
+	c.mu.Unlock()
+
+	return &resp.Database, true, nil
+}
+
+// validate validates a query against the database, retrieving the database again when the query is invalid
+// against a copy kept from an earlier query, as the properties may have changed since.
+func (c *schemaCache) validate(ctx context.Context, params DatabasesQueryParameters,
+	retrieve func(context.Context, DatabasesRetrieveParameters) (*DatabasesRetrieveResponse, error)) error {
+	database, fetched, err := c.get(ctx, params.DatabaseID, retrieve)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateQuery(database, params)
+	if err == nil || fetched {
+		return err
+	}
+
+	c.forget(params.DatabaseID)
+
+	if database, _, err = c.get(ctx, params.DatabaseID, retrieve); err != nil {
+		return err
+	}
+
+	return ValidateQuery(database, params)
+}
+
+// forget drops the database, e.g. when Notion rejects a query which was valid against it.
+func (c *schemaCache) forget(databaseID string) {
+	c.mu.Lock()
+	delete(c.databases, databaseID)
+	c.mu.Unlock()
+}
@@ -0,0 +1,256 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const queryDatabaseJSON = `{
+	"object": "database",
+	"id": "db",
+	"title": [],
+	"properties": {
+		"Name": {"id": "title", "type": "title", "title": {}},
+		"Price": {"id": "BJXS", "type": "number", "number": {"format": "dollar"}},
+		"Status": {"id": "bSta", "type": "select", "select": {"options": []}},
+		"Tags": {"id": "cTag", "type": "multi_select", "multi_select": {"options": []}},
+		"Due": {"id": "dDue", "type": "date", "date": {}},
+		"Website": {"id": "fWeb", "type": "url", "url": {}},
+		"Attachments": {"id": "gAtt", "type": "files", "files": {}},
+		"Created": {"id": "hCre", "type": "created_time", "created_time": {}}
+	}
+}`
+
+func TestValidateQuery(t *testing.T) {
+	var database Database
+
+	require.NoError(t, json.Unmarshal([]byte(queryDatabaseJSON), &database))
+
+	keyword, price := "kale", 2.5
+	property := func(name string) SinglePropertyFilter {
+		return SinglePropertyFilter{Property: name}
+	}
+
+	tests := []struct {
+		name     string
+		params   DatabasesQueryParameters
+		problems []QueryProblem
+	}{
+		{
+			name: "valid",
+			params: DatabasesQueryParameters{
+				Filter: CompoundFilter{And: []Filter{
+					SingleTextFilter{SinglePropertyFilter: property("Name"), Text: &TextFilter{Contains: &keyword}},
+					&SingleNumberFilter{SinglePropertyFilter: property("BJXS"), Number: NumberFilter{GreaterThan: &price}},
+					CompoundFilter{Or: []Filter{
+						SingleSelectFilter{SinglePropertyFilter: property("Status"), Select: SelectFilter{IsEmpty: true}},
+						SingleMultiSelectFilter{SinglePropertyFilter: property("Tags"), MultiSelect: MultiSelectFilter{IsEmpty: true}},
+					}},
+					SingleDateFilter{SinglePropertyFilter: property("Created"), CreatedTime: &DateFilter{PastWeek: map[string]interface{}{}}},
+					SingleTextFilter{SinglePropertyFilter: property("Website"), URL: &TextFilter{Contains: &keyword}},
+					SingleFilesFilter{SinglePropertyFilter: property("Attachments"), Files: FilesFilter{IsEmpty: true}},
+				}},
+				Sorts: []Sort{
+					{Property: "Price", Direction: SortDirectionAscending},
+					{Timestamp: SortTimestampByCreatedTime, Direction: SortDirectionDescending},
+				},
+			},
+		},
+		{
+			name: "no filter",
+		},
+		{
+			name: "problems",
+			params: DatabasesQueryParameters{
+				Filter: CompoundFilter{Or: []Filter{
+					SingleNumberFilter{SinglePropertyFilter: property("Status"), Number: NumberFilter{Equals: &price}},
+					SingleTextFilter{SinglePropertyFilter: property("Nmae"), Text: &TextFilter{Equals: &keyword}},
+					CompoundFilter{And: []Filter{
+						SingleTextFilter{SinglePropertyFilter: property("Name")},
+						SingleDateFilter{SinglePropertyFilter: property("Due"), Date: &DateFilter{}, CreatedTime: &DateFilter{}},
+						SingleDateFilter{SinglePropertyFilter: property("Due"), CreatedTime: &DateFilter{}},
+						nil,
+					}},
+					CompoundFilter{},
+					CompoundFilter{
+						And: []Filter{SingleCheckboxFilter{SinglePropertyFilter: property("Price")}},
+						Or:  []Filter{SingleFormulaFilter{SinglePropertyFilter: property("Website")}},
+					},
+				}},
+				Sorts: []Sort{
+					{Property: "Prise"},
+					{},
+					{Property: "Price", Timestamp: SortTimestampByLastEditedTime},
+				},
+			},
+			problems: []QueryProblem{
+				{"filter.or[0]", `a number filter cannot apply to the select property "Status"`},
+				{"filter.or[1]", `no property "Nmae"`},
+				{"filter.or[2].and[0]", `filter on property "Name" without condition`},
+				{"filter.or[2].and[1]", `filter on property "Due" with several conditions: created_time, date`},
+				{"filter.or[2].and[2]", `a created_time filter cannot apply to the date property "Due"`},
+				{"filter.or[2].and[3]", "nil filter"},
+				{"filter.or[3]", "compound filter without filters"},
+				{"filter.or[4]", "compound filter with both and and or, nest one in the other"},
+				{"filter.or[4].and[0]", `a checkbox filter cannot apply to the number property "Price"`},
+				{"filter.or[4].or[0]", `a formula filter cannot apply to the url property "Website"`},
+				{"sorts[0]", `no property "Prise"`},
+				{"sorts[1]", "sort by neither a property nor a timestamp"},
+				{"sorts[2]", `sort by both property "Price" and timestamp "last_edited_time"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQuery(&database, tt.params)
+			if tt.problems == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var validationError *QueryValidationError
+
+			require.ErrorAs(t, err, &validationError)
+			assert.ErrorIs(t, err, ErrInvalidQuery)
+			assert.Equal(t, "db", validationError.DatabaseID)
+			assert.Equal(t, tt.problems, validationError.Problems)
+		})
+	}
+}
+
+func TestQueryValidationError_Error(t *testing.T) {
+	err := &QueryValidationError{DatabaseID: "db", Problems: []QueryProblem{
+		{Path: "filter.or[1]", Message: `no property "Nmae"`},
+		{Path: "sorts[0]", Message: `no property "Prise"`},
+	}}
+
+	assert.Equal(t, `invalid query of database db: filter.or[1]: no property "Nmae"; sorts[0]: no property "Prise"`, err.Error())
+}
+
+func TestWithQueryValidation_Query(t *testing.T) {
+	var retrievals, queries int
+
+	validationError := false
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/databases/db", func(writer http.ResponseWriter, request *http.Request) {
+		retrievals++
+
+		_, err := writer.Write([]byte(queryDatabaseJSON))
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc("/v1/databases/db/query", func(writer http.ResponseWriter, request *http.Request) {
+		queries++
+
+		if validationError {
+			writer.WriteHeader(http.StatusBadRequest)
+			_, err := writer.Write([]byte(`{"object": "error", "status": 400, "code": "validation_error", "message": "invalid"}`))
+			assert.NoError(t, err)
+
+			return
+		}
+
+		_, err := writer.Write([]byte(`{"object": "list", "results": [], "has_more": false, "next_cursor": null}`))
+		assert.NoError(t, err)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := New("token", WithBaseURL(server.URL), WithQueryValidation())
+	ctx := context.Background()
+	price := 2.5
+
+	valid := DatabasesQueryParameters{DatabaseID: "db", Sorts: []Sort{{Property: "Price"}}}
+	invalid := DatabasesQueryParameters{
+		DatabaseID: "db",
+		Filter:     SingleNumberFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "Status"}, Number: NumberFilter{Equals: &price}},
+	}
+
+	_, err := api.Databases().Query(ctx, valid)
+	assert.NoError(t, err)
+
+	_, err = api.Databases().Query(ctx, invalid)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	_, err = api.Databases().QueryAll(ctx, valid).Collect(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, retrievals, "the database is retrieved again to check the invalid query")
+	assert.Equal(t, 2, queries)
+
+	// The database is retrieved again after Notion rejects a query.
+	validationError = true
+	_, err = api.Databases().Query(ctx, valid)
+	assert.ErrorIs(t, err, ErrValidationError)
+
+	validationError = false
+	_, err = api.Databases().Query(ctx, valid)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, retrievals)
+	assert.Equal(t, 4, queries)
+}
+
+func TestWithQueryValidation_PropertyAdded(t *testing.T) {
+	var retrievals, queries int
+
+	database := `{"object": "database", "id": "db", "title": [], "properties": {
+		"Name": {"id": "title", "type": "title", "title": {}}
+	}}`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/databases/db", func(writer http.ResponseWriter, request *http.Request) {
+		retrievals++
+
+		_, err := writer.Write([]byte(database))
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc("/v1/databases/db/query", func(writer http.ResponseWriter, request *http.Request) {
+		queries++
+
+		_, err := writer.Write([]byte(`{"object": "list", "results": [], "has_more": false, "next_cursor": null}`))
+		assert.NoError(t, err)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := New("token", WithBaseURL(server.URL), WithQueryValidation())
+	ctx := context.Background()
+	price := 1.0
+
+	_, err := api.Databases().Query(ctx, DatabasesQueryParameters{DatabaseID: "db"})
+	require.NoError(t, err)
+
+	// Price is added from another client after the database was kept.
+	database = queryDatabaseJSON
+
+	query := DatabasesQueryParameters{
+		DatabaseID: "db",
+		Filter:     SingleNumberFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "Price"}, Number: NumberFilter{GreaterThan: &price}},
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err = api.Databases().Query(ctx, query)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, retrievals)
+	assert.Equal(t, 4, queries)
+
+	// A query still invalid against the database retrieved again is rejected without being sent.
+	query.Filter = SingleNumberFilter{SinglePropertyFilter: SinglePropertyFilter{Property: "Cost"}, Number: NumberFilter{GreaterThan: &price}}
+
+	_, err = api.Databases().Query(ctx, query)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	assert.Equal(t, 3, retrievals)
+	assert.Equal(t, 4, queries)
+}
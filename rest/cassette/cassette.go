@@ -0,0 +1,113 @@
+// Package cassette records the HTTP traffic of a client to a file, a cassette, and replays it deterministically,
+// e.g. to run tests in CI against traffic captured once from the Notion API:
+//
+//	recorder, err := cassette.New("testdata/list_users.json", cassette.ModeRecord)
+//	c := notion.New(token, notion.WithHTTPClient(recorder.Client()))
+//	...
+//	err = recorder.Save()
+//
+// The bearer token of the Authorization header is never written to a cassette, and other headers and fields of
+// the JSON bodies can be redacted with RedactHeaders and RedactFields.
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Cassette is the recorded traffic, in the order of the requests.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request. Its URL is only kept as a path and a query, so that it is replayed whatever
+// the base URL of the client is.
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is the body of a request or a response. JSON bodies are embedded as they are in the cassette, so that they
+// can be read and edited, other bodies are written as strings.
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if len(b) == 0 {
+		return []byte("null"), nil
+	}
+
+	if json.Valid(b) {
+		return b, nil
+	}
+
+	return json.Marshal(string(b))
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+
+	switch {
+	case string(data) == "null":
+		*b = nil
+	case json.Unmarshal(data, &s) == nil && !json.Valid([]byte(s)):
+		*b = Body(s)
+	default:
+		*b = append((*b)[:0], data...)
+	}
+
+	return nil
+}
+
+// Load reads a cassette from a file.
+func Load(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var c Cassette
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cassette %s: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+// Save writes a cassette to a file, creating its directory when it does not exist.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { // nolint:gomnd
+		return fmt.Errorf("failed to create the directory of cassette: %w", err)
+	}
+
+	if err := ioutil.WriteFile(path, append(data, '\n'), 0o644); err != nil { // nolint:gomnd,gosec
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
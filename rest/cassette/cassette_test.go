@@ -0,0 +1,34 @@
+package cassette
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBody_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body Body
+		want string
+	}{
+		{name: "Embed a JSON body", body: Body(`{"object":"list"}`), want: `{"status_code":200,"body":{"object":"list"}}`},
+		{name: "Write other bodies as strings", body: Body("Bad gateway"), want: `{"status_code":200,"body":"Bad gateway"}`},
+		{name: "Omit an empty body", body: nil, want: `{"status_code":200}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(Response{StatusCode: 200, Body: tt.body})
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+
+			var resp Response
+
+			require.NoError(t, json.Unmarshal(data, &resp))
+			assert.Equal(t, tt.body, resp.Body)
+		})
+	}
+}
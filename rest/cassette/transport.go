@@ -0,0 +1,354 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrNoMatch is returned when a request is replayed but no recorded interaction matches it.
+var ErrNoMatch = errors.New("no recorded interaction matches the request")
+
+// Redacted replaces the values which are redacted.
+const Redacted = "REDACTED"
+
+// Mode is whether a Transport records or replays the traffic.
+type Mode int
+
+const (
+	// ModeReplay replays the interactions of a cassette, and fails the requests which were not recorded.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests and records the interactions, which are written by Save.
+	ModeRecord
+)
+
+type Option func(t *Transport)
+
+// WithTransport sets the transport sending the requests which are recorded. Defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(t *Transport) {
+		t.transport = transport
+	}
+}
+
+// RedactHeaders redacts the values of headers of the requests and the responses, in addition to the bearer token
+// of the Authorization header.
+func RedactHeaders(names ...string) Option {
+	return func(t *Transport) {
+		for _, name := range names {
+			t.redactedHeaders = append(t.redactedHeaders, http.CanonicalHeaderKey(name))
+		}
+	}
+}
+
+// RedactFields redacts the values of the fields with the given names, at any depth, in the JSON bodies of the requests
+// and the responses, e.g. "email" for the emails of the users.
+func RedactFields(names ...string) Option {
+	return func(t *Transport) {
+		for _, name := range names {
+			t.redactedFields[name] = true
+		}
+	}
+}
+
+// Transport is an http.RoundTripper which records or replays the interactions of a cassette.
+// It is safe for concurrent use.
+type Transport struct {
+	path            string
+	mode            Mode
+	transport       http.RoundTripper
+	redactedHeaders []string
+	redactedFields  map[string]bool
+
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed []bool
+}
+
+// New returns a Transport recording to or replaying the cassette at path. The cassette is loaded when it is replayed.
+func New(path string, mode Mode, opts ...Option) (*Transport, error) {
+	t := &Transport{
+		path:           path,
+		mode:           mode,
+		transport:      http.DefaultTransport,
+		redactedFields: make(map[string]bool),
+		cassette:       &Cassette{},
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if mode == ModeReplay {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+
+		t.cassette = c
+		t.replayed = make([]bool, len(c.Interactions))
+	}
+
+	return t, nil
+}
+
+// Client returns an HTTP client using the Transport, to be passed to notion.WithHTTPClient.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// Save writes the recorded interactions to the cassette. It does nothing when the traffic is replayed.
+func (t *Transport) Save() error {
+	if t.mode != ModeRecord {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.cassette.Save(t.path)
+}
+
+// Unreplayed returns the recorded interactions which have not been replayed, e.g. to check that a test sent every
+// request it was recorded with.
+func (t *Transport) Unreplayed() []Interaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var interactions []Interaction
+
+	for i, replayed := range t.replayed {
+		if !replayed {
+			interactions = append(interactions, t.cassette.Interactions[i])
+		}
+	}
+
+	return interactions
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	request := t.recordRequest(req, body)
+
+	if t.mode == ModeReplay {
+		return t.replay(req, request)
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data from response body: %w", err)
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Request: request,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     t.redactHeader(resp.Header),
+			Body:       t.redactBody(respBody),
+		},
+	})
+
+	return resp, nil
+}
+
+// replay returns the response of the first interaction matching the request which has not been replayed yet, so
+// that the same request can be replayed with different responses, e.g. a page retrieved before and after an update.
+func (t *Transport) replay(req *http.Request, request Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.cassette.Interactions {
+		if t.replayed[i] || !matches(interaction.Request, request) {
+			continue
+		}
+
+		t.replayed[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w in %s: %s %s", ErrNoMatch, t.path, request.Method, describe(request))
+}
+
+// recordRequest returns a request as it is recorded, redacted.
+func (t *Transport) recordRequest(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Header: t.redactHeader(req.Header),
+		Body:   t.redactBody(body),
+	}
+}
+
+func (t *Transport) redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+
+	if authorization := header.Get("Authorization"); authorization != "" {
+		if strings.HasPrefix(authorization, "Bearer ") {
+			header.Set("Authorization", "Bearer "+Redacted)
+		} else {
+			header.Set("Authorization", Redacted)
+		}
+	}
+
+	for _, name := range t.redactedHeaders {
+		if _, ok := header[name]; ok {
+			header.Set(name, Redacted)
+		}
+	}
+
+	return header
+}
+
+// redactBody redacts the fields of a JSON body. Other bodies are recorded as they are.
+func (t *Transport) redactBody(body []byte) Body {
+	if len(t.redactedFields) == 0 || !json.Valid(body) {
+		return body
+	}
+
+	var v interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&v); err != nil {
+		return body
+	}
+
+	redacted, err := json.Marshal(t.redactValue(v))
+	if err != nil {
+		return body
+	}
+
+	return redacted
+}
+
+func (t *Transport) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if t.redactedFields[key] && value != nil {
+				v[key] = Redacted
+			} else {
+				v[key] = t.redactValue(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = t.redactValue(value)
+		}
+	}
+
+	return v
+}
+
+// readBody reads the body of a request, and returns a copy of the request with the body restored, so that the request
+// can still be sent.
+func readBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+
+	defer req.Body.Close()
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read data from request body: %w", err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return req, body, nil
+}
+
+// matches reports whether a request matches a recorded request, by method, path, query and JSON body, whatever the
+// order of the fields of the body and its formatting are.
+func matches(recorded, request Request) bool {
+	return recorded.Method == request.Method &&
+		recorded.Path == request.Path &&
+		canonicalQuery(recorded.Query) == canonicalQuery(request.Query) &&
+		canonicalBody(recorded.Body) == canonicalBody(request.Body)
+}
+
+// describe returns the URL and the body of a request, to be reported when it does not match.
+func describe(request Request) string {
+	s := request.Path
+
+	if request.Query != "" {
+		s += "?" + request.Query
+	}
+
+	if body := canonicalBody(request.Body); body != "" {
+		s += " " + body
+	}
+
+	return s
+}
+
+func canonicalQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+
+	return values.Encode()
+}
+
+// canonicalBody returns a JSON body with its fields sorted and without spaces, or an empty string when there is no
+// body, as the body of the requests without a body is "null".
+func canonicalBody(body Body) string {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || string(trimmed) == "null" {
+		return ""
+	}
+
+	var v interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&v); err != nil {
+		return string(body)
+	}
+
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+
+	return string(canonical)
+}
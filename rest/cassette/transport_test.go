@@ -0,0 +1,200 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/mkfsn/notion-go/notiontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "users.json")
+
+	server := notiontest.NewServer(notiontest.WithAuthToken("secret_token"))
+	defer server.Close()
+
+	user := notion.PersonUser{Person: notion.Person{Email: "ada@example.com"}}
+	user.Name, user.Type = "Ada", notion.UserTypePerson
+	userID := server.AddUser(user)
+
+	recorder, err := New(path, ModeRecord, RedactFields("email"), RedactHeaders("notion-version"))
+	require.NoError(t, err)
+
+	c := notion.New("secret_token", notion.WithBaseURL(server.URL), notion.WithHTTPClient(recorder.Client()))
+
+	recorded, err := c.Users().List(context.Background(), notion.UsersListParameters{})
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", recorded.Results[0].(*notion.PersonUser).Person.Email, "responses are not redacted")
+
+	_, err = c.Users().Retrieve(context.Background(), notion.UsersRetrieveParameters{UserID: "unknown"})
+	assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+
+	require.NoError(t, recorder.Save())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret_token")
+	assert.NotContains(t, string(data), "ada@example.com")
+	assert.Contains(t, string(data), `"Bearer REDACTED"`)
+
+	cassette, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 2)
+	assert.Equal(t, []string{Redacted}, cassette.Interactions[0].Request.Header["Notion-Version"])
+	assert.Equal(t, http.StatusNotFound, cassette.Interactions[1].Response.StatusCode)
+
+	server.Close()
+
+	replayer, err := New(path, ModeReplay)
+	require.NoError(t, err)
+
+	c = notion.New("another_token", notion.WithBaseURL("https://notion.invalid"), notion.WithHTTPClient(replayer.Client()))
+
+	replayed, err := c.Users().List(context.Background(), notion.UsersListParameters{})
+	require.NoError(t, err)
+	require.Len(t, replayed.Results, 1)
+	assert.Equal(t, userID, replayed.Results[0].(*notion.PersonUser).ID)
+	assert.Equal(t, Redacted, replayed.Results[0].(*notion.PersonUser).Person.Email)
+	assert.Len(t, replayer.Unreplayed(), 1)
+
+	_, err = c.Users().Retrieve(context.Background(), notion.UsersRetrieveParameters{UserID: "unknown"})
+	assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+	assert.Empty(t, replayer.Unreplayed())
+
+	_, err = c.Users().List(context.Background(), notion.UsersListParameters{})
+	assert.ErrorIs(t, err, ErrNoMatch, "each interaction is replayed once")
+}
+
+func TestTransport_Replay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	cassette := Cassette{Interactions: []Interaction{
+		{
+			Request:  Request{Method: http.MethodPost, Path: "/v1/databases/d1/query", Query: "page_size=10&start_cursor=c1", Body: Body(`{"sorts": [], "filter": {"property": "Done", "checkbox": {"equals": true}}}`)},
+			Response: Response{StatusCode: http.StatusOK, Body: Body(`{"results": 1}`)},
+		},
+		{
+			Request:  Request{Method: http.MethodGet, Path: "/v1/pages/p1"},
+			Response: Response{StatusCode: http.StatusOK, Body: Body(`{"version": 1}`)},
+		},
+		{
+			Request:  Request{Method: http.MethodGet, Path: "/v1/pages/p1"},
+			Response: Response{StatusCode: http.StatusOK, Body: Body(`{"version": 2}`)},
+		},
+		{
+			Request:  Request{Method: http.MethodGet, Path: "/v1/blocks/b1"},
+			Response: Response{StatusCode: http.StatusBadGateway, Body: Body("<html>Bad gateway</html>")},
+		},
+	}}
+	require.NoError(t, cassette.Save(path))
+
+	replayer, err := New(path, ModeReplay)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("a replayed request was sent: %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		wantStatus int
+		wantBody   string
+		wantErr    error
+	}{
+		{
+			name:       "Match the query in any order and the canonical JSON body",
+			method:     http.MethodPost,
+			url:        "/v1/databases/d1/query?start_cursor=c1&page_size=10",
+			body:       `{"filter":{"checkbox":{"equals":true},"property":"Done"},"sorts":[]}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"results": 1}`,
+		},
+		{
+			name:    "Fail on a different body",
+			method:  http.MethodPost,
+			url:     "/v1/databases/d1/query?start_cursor=c1&page_size=10",
+			body:    `{"filter":{"checkbox":{"equals":false},"property":"Done"},"sorts":[]}`,
+			wantErr: ErrNoMatch,
+		},
+		{
+			name:    "Fail on a different query",
+			method:  http.MethodPost,
+			url:     "/v1/databases/d1/query?start_cursor=c2&page_size=10",
+			body:    `{"filter":{"checkbox":{"equals":true},"property":"Done"},"sorts":[]}`,
+			wantErr: ErrNoMatch,
+		},
+		{
+			name:       "Replay the first matching interaction",
+			method:     http.MethodGet,
+			url:        "/v1/pages/p1",
+			body:       "null",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"version": 1}`,
+		},
+		{
+			name:       "Replay the next matching interaction",
+			method:     http.MethodGet,
+			url:        "/v1/pages/p1",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"version": 2}`,
+		},
+		{
+			name:       "Replay a body which is not JSON",
+			method:     http.MethodGet,
+			url:        "/v1/blocks/b1",
+			wantStatus: http.StatusBadGateway,
+			wantBody:   "<html>Bad gateway</html>",
+		},
+		{
+			name:    "Fail on a different method",
+			method:  http.MethodDelete,
+			url:     "/v1/blocks/b1",
+			wantErr: ErrNoMatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.url, strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			resp, err := replayer.Client().Do(req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			if json.Valid([]byte(tt.wantBody)) {
+				assert.JSONEq(t, tt.wantBody, string(body))
+			} else {
+				assert.Equal(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestNew_MissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	assert.Error(t, err)
+}
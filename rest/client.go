@@ -12,11 +12,14 @@ import (
 )
 
 type restClient struct {
-	baseURL    string
-	header     http.Header
-	httpClient *http.Client
+	baseURL     string
+	header      http.Header
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
 
 	method      string
+	idempotent  bool
 	endpoint    string
 	queryStruct interface{}
 	bodyJSON    interface{}
@@ -31,9 +34,11 @@ func New() Interface {
 
 func (r *restClient) New() Interface {
 	newRestClient := &restClient{
-		baseURL:    r.baseURL,
-		header:     r.header.Clone(),
-		httpClient: r.httpClient, // TODO: deep copy
+		baseURL:     r.baseURL,
+		header:      r.header.Clone(),
+		httpClient:  r.httpClient, // TODO: deep copy
+		retryPolicy: r.retryPolicy,
+		rateLimiter: r.rateLimiter,
 	}
 
 	return newRestClient
@@ -57,6 +62,18 @@ func (r *restClient) Client(httpClient *http.Client) Interface {
 	return r
 }
 
+func (r *restClient) RetryPolicy(retryPolicy RetryPolicy) Interface {
+	r.retryPolicy = retryPolicy
+
+	return r
+}
+
+func (r *restClient) RateLimiter(rateLimiter *RateLimiter) Interface {
+	r.rateLimiter = rateLimiter
+
+	return r
+}
+
 func (r *restClient) UserAgent(userAgent string) Interface {
 	r.header.Set("User-Agent", userAgent)
 
@@ -87,6 +104,20 @@ func (r *restClient) Patch() Interface {
 	return r
 }
 
+func (r *restClient) Delete() Interface {
+	r.method = http.MethodDelete
+
+	return r
+}
+
+// Idempotent marks the request as safe to replay even though its method is not idempotent,
+// e.g. a POST request which only reads data.
+func (r *restClient) Idempotent() Interface {
+	r.idempotent = true
+
+	return r
+}
+
 func (r *restClient) Endpoint(endpoint string) Interface {
 	r.endpoint = endpoint
 
@@ -133,27 +164,59 @@ func (r *restClient) Request(ctx context.Context) (*http.Request, error) {
 }
 
 func (r *restClient) Receive(ctx context.Context, success, failure interface{}) error {
-	req, err := r.Request(ctx)
-	if err != nil {
-		return err
+	for retries := 0; ; retries++ {
+		req, err := r.Request(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := r.rateLimiter.Wait(ctx); err != nil {
+			return fmt.Errorf("failed to wait for the rate limiter: %w", err)
+		}
+
+		resp, b, err := r.send(req)
+		if ctx.Err() != nil || !r.retryPolicy.shouldRetry(retries, r.isIdempotent(), resp) {
+			if err != nil {
+				return err
+			}
+
+			return r.decodeResponseData(resp, b, success, failure)
+		}
+
+		if err := sleep(ctx, r.retryPolicy.backoff(retries, resp)); err != nil {
+			return fmt.Errorf("failed to wait before retrying an HTTP request: %w", err)
+		}
 	}
+}
 
+// send processes the HTTP request and reads the whole response body.
+// The returned response is nil if no response was received.
+func (r *restClient) send(req *http.Request) (*http.Response, []byte, error) {
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to process an HTTP request: %w", err)
+		return nil, nil, fmt.Errorf("failed to process an HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	b, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read data from response body: %w", err)
+		return nil, nil, fmt.Errorf("failed to read data from response body: %w", err)
+	}
+
+	return resp, b, nil
+}
+
+func (r *restClient) isIdempotent() bool {
+	switch r.method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
 	}
 
-	return r.decodeResponseData(resp.StatusCode, b, success, failure)
+	return r.idempotent
 }
 
-func (r *restClient) decodeResponseData(statusCode int, data []byte, success, failure interface{}) error {
-	if statusCode == http.StatusOK {
+func (r *restClient) decodeResponseData(resp *http.Response, data []byte, success, failure interface{}) error {
+	if resp.StatusCode == http.StatusOK {
 		if success != nil {
 			return json.Unmarshal(data, success)
 		}
@@ -165,9 +228,15 @@ func (r *restClient) decodeResponseData(statusCode int, data []byte, success, fa
 		return nil
 	}
 
-	if err := json.Unmarshal(data, failure); err != nil {
+	recorder, isRecorder := failure.(ResponseRecorder)
+
+	if err := json.Unmarshal(data, failure); err != nil && !isRecorder {
 		return fmt.Errorf("failed to unmarshal error message from HTTP response body: %w", err)
 	}
 
+	if isRecorder {
+		recorder.RecordResponse(resp, data)
+	}
+
 	return failure.(error)
 }
@@ -10,14 +10,24 @@ type Interface interface {
 	BearerToken(token string) Interface
 	BaseURL(baseURL string) Interface
 	Client(httpClient *http.Client) Interface
+	RetryPolicy(retryPolicy RetryPolicy) Interface
+	RateLimiter(rateLimiter *RateLimiter) Interface
 	UserAgent(userAgent string) Interface
 	Header(key, value string) Interface
 	Get() Interface
 	Post() Interface
 	Patch() Interface
+	Delete() Interface
+	Idempotent() Interface
 	Endpoint(endpoint string) Interface
 	QueryStruct(queryStruct interface{}) Interface
 	BodyJSON(bodyJSON interface{}) Interface
 	Request(ctx context.Context) (*http.Request, error)
 	Receive(ctx context.Context, success, failure interface{}) error
 }
+
+// ResponseRecorder is implemented by failures which keep details about the HTTP response they were decoded from.
+// The response of a ResponseRecorder failure is recorded even when its body is not valid JSON.
+type ResponseRecorder interface {
+	RecordResponse(resp *http.Response, body []byte)
+}
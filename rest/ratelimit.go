@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimitExceeded is returned by a fail fast RateLimiter when no request is currently allowed.
+var ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
+// RateLimiter is a token bucket which limits the rate of requests.
+// It is safe for concurrent use, so the same RateLimiter can be shared by
+// several clients using the same integration token to share one budget.
+type RateLimiter struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	failFast bool
+	now      func() time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rate requests per second on average,
+// and at most burst requests at once. By default a request waits until it is allowed.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// FailFast makes the RateLimiter return ErrRateLimitExceeded instead of waiting.
+func (l *RateLimiter) FailFast() *RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.failFast = true
+
+	return l
+}
+
+// Wait blocks until a request is allowed or the context is done.
+// A nil RateLimiter allows every request.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	d, err := l.reserve(ctx)
+	if err != nil || d == 0 {
+		return err
+	}
+
+	if err := sleep(ctx, d); err != nil {
+		l.cancel()
+
+		return err
+	}
+
+	return nil
+}
+
+// reserve takes a token from the bucket, and returns how long to wait until the token is available.
+func (l *RateLimiter) reserve(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+
+		return 0, nil
+	}
+
+	if l.failFast || l.rate <= 0 {
+		return 0, ErrRateLimitExceeded
+	}
+
+	d := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(now) < d {
+		return 0, fmt.Errorf("%w: the context deadline is earlier than the next allowed request", ErrRateLimitExceeded)
+	}
+
+	l.tokens--
+
+	return d, nil
+}
+
+// cancel gives back a reserved token which was not used.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func TestRateLimiter_reserve(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2021, 5, 19, 12, 0, 0, 0, time.UTC)}
+
+	sut := NewRateLimiter(2, 2)
+	sut.now = clock.Now
+
+	for i := 0; i < 2; i++ {
+		d, err := sut.reserve(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), d)
+	}
+
+	d, err := sut.reserve(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, d)
+
+	d, err = sut.reserve(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, d)
+
+	clock.now = clock.now.Add(10 * time.Second)
+
+	for i := 0; i < 2; i++ {
+		d, err := sut.reserve(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), d)
+	}
+}
+
+func TestRateLimiter_reserveFailFast(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2021, 5, 19, 12, 0, 0, 0, time.UTC)}
+
+	sut := NewRateLimiter(1, 1).FailFast()
+	sut.now = clock.Now
+
+	_, err := sut.reserve(context.Background())
+	assert.NoError(t, err)
+
+	_, err = sut.reserve(context.Background())
+	assert.True(t, errors.Is(err, ErrRateLimitExceeded))
+
+	clock.now = clock.now.Add(time.Second)
+
+	_, err = sut.reserve(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRateLimiter_reserveDeadline(t *testing.T) {
+	sut := NewRateLimiter(1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, sut.Wait(ctx))
+	assert.True(t, errors.Is(sut.Wait(ctx), ErrRateLimitExceeded))
+}
+
+func TestRestClient_Receive_RateLimiter(t *testing.T) {
+	var attempts int32
+
+	mockHTTPServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer mockHTTPServer.Close()
+
+	rateLimiter := NewRateLimiter(1, 2).FailFast()
+
+	// Two clients sharing the same rate limiter share the same budget.
+	first := New().BaseURL(mockHTTPServer.URL).RateLimiter(rateLimiter)
+	second := New().BaseURL(mockHTTPServer.URL).RateLimiter(rateLimiter)
+
+	assert.NoError(t, first.New().Get().Endpoint("/").Receive(context.Background(), nil, nil))
+	assert.NoError(t, second.New().Get().Endpoint("/").Receive(context.Background(), nil, nil))
+
+	err := first.New().Get().Endpoint("/").Receive(context.Background(), nil, nil)
+	assert.True(t, errors.Is(err, ErrRateLimitExceeded))
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how a request is replayed after a retryable failure.
+type RetryPolicy struct {
+	// The maximum number of retries after the first attempt. Zero disables retrying.
+	MaxRetries int
+	// The backoff before the first retry, doubled on each following retry.
+	MinBackoff time.Duration
+	// The upper bound of the exponential backoff. Zero means no upper bound.
+	// A delay requested by the server through the Retry-After header is always honored.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable policy for the rate limits and outages of the Notion API.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 30 * time.Second,
+}
+
+// shouldRetry reports whether another attempt should be made after the given number of retries.
+// A response is only nil when the HTTP request failed before a response was received.
+// Rate limited requests are rejected before being processed so they are always safe to replay,
+// other failures are only replayed when the request is idempotent.
+func (p RetryPolicy) shouldRetry(retries int, idempotent bool, resp *http.Response) bool {
+	if retries >= p.MaxRetries {
+		return false
+	}
+
+	if resp == nil {
+		return idempotent
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the next retry.
+func (p RetryPolicy) backoff(retries int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := RetryAfter(resp.Header); ok {
+			return d
+		}
+	}
+
+	d := p.MinBackoff
+	for i := 0; i < retries && d < math.MaxInt64/2; i++ {
+		d *= 2
+	}
+
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	// Equal jitter: keep half of the backoff and randomize the other half.
+	half := d / 2
+
+	return half + time.Duration(rand.Int63n(int64(d-half)+1)) // nolint:gosec
+}
+
+// RetryAfter returns the delay requested by the Retry-After header, if any.
+func RetryAfter(header http.Header) (time.Duration, bool) {
+	return parseRetryAfter(header.Get("Retry-After"), time.Now())
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+
+	return 0, true
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() // nolint:wrapcheck
+
+	case <-timer.C:
+		return nil
+	}
+}
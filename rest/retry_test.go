@@ -0,0 +1,178 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFailure struct {
+	Code string `json:"code"`
+}
+
+func (e *testFailure) Error() string {
+	return e.Code
+}
+
+func TestRestClient_Receive_Retry(t *testing.T) {
+	retryPolicy := RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	type wants struct {
+		attempts int32
+		err      error
+	}
+
+	tests := []struct {
+		name        string
+		statusCodes []int
+		retryAfter  string
+		build       func(r Interface) Interface
+		wants       wants
+	}{
+		{
+			name:        "Retry a rate limited POST request until it succeeds",
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			build:       func(r Interface) Interface { return r.Post() },
+			wants:       wants{attempts: 3},
+		},
+		{
+			name:        "Honor the Retry-After header",
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:  "0",
+			build:       func(r Interface) Interface { return r.Post() },
+			wants:       wants{attempts: 2},
+		},
+		{
+			name:        "Retry a GET request after a server error",
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK},
+			build:       func(r Interface) Interface { return r.Get() },
+			wants:       wants{attempts: 3},
+		},
+		{
+			name:        "Retry an idempotent POST request after a server error",
+			statusCodes: []int{http.StatusBadGateway, http.StatusOK},
+			build:       func(r Interface) Interface { return r.Post().Idempotent() },
+			wants:       wants{attempts: 2},
+		},
+		{
+			name:        "Do not replay a POST request after a server error",
+			statusCodes: []int{http.StatusInternalServerError, http.StatusOK},
+			build:       func(r Interface) Interface { return r.Post() },
+			wants:       wants{attempts: 1, err: &testFailure{Code: "500"}},
+		},
+		{
+			name:        "Do not retry client errors",
+			statusCodes: []int{http.StatusBadRequest, http.StatusOK},
+			build:       func(r Interface) Interface { return r.Get() },
+			wants:       wants{attempts: 1, err: &testFailure{Code: "400"}},
+		},
+		{
+			name:        "Give up after the maximum number of retries",
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			build:       func(r Interface) Interface { return r.Get() },
+			wants:       wants{attempts: 3, err: &testFailure{Code: "429"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+
+			mockHTTPServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				statusCode := tt.statusCodes[atomic.AddInt32(&attempts, 1)-1]
+
+				if tt.retryAfter != "" {
+					writer.Header().Set("Retry-After", tt.retryAfter)
+				}
+
+				writer.WriteHeader(statusCode)
+				_, err := fmt.Fprintf(writer, `{"code": "%d"}`, statusCode)
+				assert.NoError(t, err)
+			}))
+			defer mockHTTPServer.Close()
+
+			sut := New().BaseURL(mockHTTPServer.URL).RetryPolicy(retryPolicy)
+
+			var failure testFailure
+
+			err := tt.build(sut.New()).Endpoint("/").Receive(context.Background(), nil, &failure)
+
+			assert.Equal(t, tt.wants.attempts, atomic.LoadInt32(&attempts))
+
+			if tt.wants.err != nil {
+				assert.Equal(t, tt.wants.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRestClient_Receive_RetryCanceled(t *testing.T) {
+	mockHTTPServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Retry-After", "60")
+		writer.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer mockHTTPServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	sut := New().BaseURL(mockHTTPServer.URL).RetryPolicy(DefaultRetryPolicy)
+
+	err := sut.New().Get().Endpoint("/").Receive(ctx, nil, nil)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	retryPolicy := RetryPolicy{MaxRetries: 10, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for retries, want := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		got := retryPolicy.backoff(retries, nil)
+
+		assert.GreaterOrEqual(t, int64(got), int64(want/2))
+		assert.LessOrEqual(t, int64(got), int64(want))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 5, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "", want: 0, wantOK: false},
+		{value: "3", want: 3 * time.Second, wantOK: true},
+		{value: "-1", want: 0, wantOK: false},
+		{value: "Wed, 19 May 2021 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{value: "Wed, 19 May 2021 11:00:00 GMT", want: 0, wantOK: true},
+		{value: "soon", want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
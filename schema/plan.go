@@ -0,0 +1,362 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mkfsn/notion-go"
+)
+
+// ChangeKind is the kind of a change of a property.
+type ChangeKind string
+
+const (
+	// ChangeAdd adds a property to the database.
+	ChangeAdd ChangeKind = "add"
+	// ChangeRemove removes a property from the database, with its values.
+	ChangeRemove ChangeKind = "remove"
+	// ChangeRetype changes the type of a property.
+	ChangeRetype ChangeKind = "retype"
+	// ChangeRename renames the title property, a database always has one so it cannot be removed and added.
+	ChangeRename ChangeKind = "rename"
+	// ChangeUpdate changes the configuration of a property, e.g. the options of a select property.
+	ChangeUpdate ChangeKind = "update"
+)
+
+// Change is a change of a property of the database.
+type Change struct {
+	Kind ChangeKind
+	// Name of the property in the database, before the change.
+	Property string
+	// New name of the property, for ChangeRename.
+	NewName string
+	// Type of the property before the change, empty for ChangeAdd.
+	From notion.PropertyType
+	// Type of the property after the change, empty for ChangeRemove.
+	To notion.PropertyType
+	// Options added to and removed from a select or multi select property.
+	AddedOptions   []string
+	RemovedOptions []string
+	// Other changes of the configuration of the property, e.g. `format: dollar -> euro`.
+	Details []string
+
+	// update is the value of the property in the parameters of Databases().Update.
+	update notion.Property
+}
+
+// String describes the change on a line, e.g. `~ Status (select): add options "Blocked"`.
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdd:
+		return fmt.Sprintf("+ %s (%s)", c.Property, c.To)
+	case ChangeRemove:
+		return fmt.Sprintf("- %s (%s)", c.Property, c.From)
+	case ChangeRetype:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Property, c.From, c.To)
+	case ChangeRename:
+		return fmt.Sprintf("~ %s (%s): rename to %q", c.Property, c.From, c.NewName)
+	case ChangeUpdate:
+	}
+
+	var details []string
+
+	if len(c.AddedOptions) > 0 {
+		details = append(details, "add options "+quoteAll(c.AddedOptions))
+	}
+
+	if len(c.RemovedOptions) > 0 {
+		details = append(details, "remove options "+quoteAll(c.RemovedOptions))
+	}
+
+	details = append(details, c.Details...)
+
+	return fmt.Sprintf("~ %s (%s): %s", c.Property, c.To, strings.Join(details, "; "))
+}
+
+// Plan is the list of changes turning a database into the desired schema, sorted by property name.
+type Plan struct {
+	DatabaseID string
+	Changes    []Change
+}
+
+// String describes the changes, one per line, or reports that there is nothing to change.
+func (p *Plan) String() string {
+	if len(p.Changes) == 0 {
+		return fmt.Sprintf("Database %s is up to date.\n", p.DatabaseID)
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Database %s:\n", p.DatabaseID)
+
+	for _, change := range p.Changes {
+		fmt.Fprintf(&b, "  %s\n", change)
+	}
+
+	return b.String()
+}
+
+// Parameters returns the parameters of Databases().Update applying the changes.
+func (p *Plan) Parameters() notion.DatabasesUpdateParameters {
+	params := notion.DatabasesUpdateParameters{
+		DatabaseID: p.DatabaseID,
+		Properties: make(map[string]notion.Property, len(p.Changes)),
+	}
+
+	for _, change := range p.Changes {
+		params.Properties[change.Property] = change.update
+	}
+
+	return params
+}
+
+// Apply applies the changes with Databases().Update, it does nothing when there is no change.
+func (p *Plan) Apply(ctx context.Context, databases notion.DatabasesInterface) error {
+	if len(p.Changes) == 0 {
+		return nil
+	}
+
+	if _, err := databases.Update(ctx, p.Parameters()); err != nil {
+		return fmt.Errorf("failed to update database %s: %w", p.DatabaseID, err)
+	}
+
+	return nil
+}
+
+// Diff retrieves the database and returns the changes turning it into the desired schema.
+func Diff(ctx context.Context, databases notion.DatabasesInterface, databaseID string, desired Schema) (*Plan, error) {
+	resp, err := databases.Retrieve(ctx, notion.DatabasesRetrieveParameters{DatabaseID: databaseID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve database %s: %w", databaseID, err)
+	}
+
+	return Compare(&resp.Database, desired)
+}
+
+// Compare returns the changes turning the database into the desired schema. The properties of the database missing
+// from the schema are removed, and the title property is renamed when the schema names it differently.
+func Compare(database *notion.Database, desired Schema) (*Plan, error) {
+	if err := desired.Validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{DatabaseID: database.ID}
+	live := FromDatabase(database)
+
+	// The title property cannot be removed, it is renamed instead.
+	renamedFrom, renamedTo := live.title(), desired.title()
+	if renamedFrom == "" || renamedFrom == renamedTo {
+		renamedFrom, renamedTo = "", ""
+	}
+
+	for _, name := range mergeNames(live, desired) {
+		current, exists := live.Properties[name]
+		wanted, wants := desired.Properties[name]
+
+		switch {
+		case name == renamedFrom:
+			if wants {
+				return nil, fmt.Errorf("%w: property %q is the title property of the database, it cannot become a %s "+
+					"property while the title property is renamed to %q", ErrInvalidSchema, name, wanted.Type, renamedTo)
+			}
+
+			plan.Changes = append(plan.Changes, Change{
+				Kind: ChangeRename, Property: name, NewName: renamedTo, From: current.Type, To: current.Type,
+				update: notion.RenameProperty(renamedTo),
+			})
+		case name == renamedTo:
+			if exists {
+				return nil, fmt.Errorf("%w: the title property %q cannot be renamed to %q, a %s property has this name",
+					ErrInvalidSchema, renamedFrom, name, current.Type)
+			}
+		case !wants:
+			plan.Changes = append(plan.Changes, Change{Kind: ChangeRemove, Property: name, From: current.Type})
+		case !exists:
+			plan.Changes = append(plan.Changes, Change{
+				Kind: ChangeAdd, Property: name, To: wanted.Type, update: wanted.property(nil),
+			})
+		case current.Type != wanted.Type:
+			plan.Changes = append(plan.Changes, Change{
+				Kind: ChangeRetype, Property: name, From: current.Type, To: wanted.Type, update: wanted.property(nil),
+			})
+		default:
+			if change, changed := compareProperty(name, current, wanted, database.Properties[name]); changed {
+				plan.Changes = append(plan.Changes, change)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// compareProperty compares two properties of the same type, the live property is used to keep the IDs of the
+// existing options.
+func compareProperty(name string, current, wanted Property, property notion.Property) (Change, bool) {
+	change := Change{Kind: ChangeUpdate, Property: name, From: current.Type, To: wanted.Type}
+
+	detail := func(field string, from, to interface{}) {
+		change.Details = append(change.Details, fmt.Sprintf("%s: %v -> %v", field, from, to))
+	}
+
+	switch wanted.Type { // nolint:exhaustive
+	case notion.PropertyTypeSelect, notion.PropertyTypeMultiSelect:
+		change.AddedOptions = missingOptions(wanted.Options, current.Options)
+		change.RemovedOptions = missingOptions(current.Options, wanted.Options)
+	case notion.PropertyTypeNumber:
+		if from, to := numberFormat(current.Format), numberFormat(wanted.Format); from != to {
+			detail("format", from, to)
+		}
+	case notion.PropertyTypeFormula:
+		if current.Expression != wanted.Expression {
+			detail("expression", fmt.Sprintf("%q", current.Expression), fmt.Sprintf("%q", wanted.Expression))
+		}
+	case notion.PropertyTypeRelation:
+		if current.DatabaseID != wanted.DatabaseID {
+			detail("database_id", current.DatabaseID, wanted.DatabaseID)
+		}
+	case notion.PropertyTypeRollup:
+		if current.RelationProperty != wanted.RelationProperty {
+			detail("relation_property", fmt.Sprintf("%q", current.RelationProperty), fmt.Sprintf("%q", wanted.RelationProperty))
+		}
+
+		if current.RollupProperty != wanted.RollupProperty {
+			detail("rollup_property", fmt.Sprintf("%q", current.RollupProperty), fmt.Sprintf("%q", wanted.RollupProperty))
+		}
+
+		if current.Function != wanted.Function {
+			detail("function", current.Function, wanted.Function)
+		}
+	}
+
+	if len(change.AddedOptions) == 0 && len(change.RemovedOptions) == 0 && len(change.Details) == 0 {
+		return change, false
+	}
+
+	change.update = wanted.property(property)
+
+	return change, true
+}
+
+// property returns the property to send to Databases().Update, keeping the IDs of the options of the live property.
+func (p Property) property(live notion.Property) notion.Property { // nolint:cyclop
+	ids := make(map[string]string)
+
+	switch live := live.(type) {
+	case *notion.SelectProperty:
+		for _, option := range live.Select.Options {
+			ids[option.Name] = option.ID
+		}
+	case *notion.MultiSelectProperty:
+		for _, option := range live.MultiSelect.Options {
+			ids[option.Name] = option.ID
+		}
+	}
+
+	switch p.Type { // nolint:exhaustive
+	case notion.PropertyTypeNumber:
+		return notion.NumberProperty{Number: notion.NumberPropertyOption{Format: p.Format}}
+	case notion.PropertyTypeSelect:
+		options := make([]notion.SelectOption, 0, len(p.Options))
+		for _, option := range p.Options {
+			options = append(options, notion.SelectOption{ID: ids[option.Name], Name: option.Name, Color: optionColor(option, ids)})
+		}
+
+		return notion.SelectProperty{Select: notion.SelectPropertyOption{Options: options}}
+	case notion.PropertyTypeMultiSelect:
+		options := make([]notion.MultiSelectOption, 0, len(p.Options))
+		for _, option := range p.Options {
+			options = append(options, notion.MultiSelectOption{ID: ids[option.Name], Name: option.Name, Color: optionColor(option, ids)})
+		}
+
+		return notion.MultiSelectProperty{MultiSelect: notion.MultiSelectPropertyOption{Options: options}}
+	case notion.PropertyTypeFormula:
+		return notion.FormulaProperty{Formula: notion.Formula{Expression: p.Expression}}
+	case notion.PropertyTypeRelation:
+		return notion.RelationProperty{Relation: notion.Relation{DatabaseID: p.DatabaseID}}
+	case notion.PropertyTypeRollup:
+		return notion.RollupProperty{Rollup: notion.RollupPropertyOption{
+			RelationPropertyName: p.RelationProperty,
+			RollupPropertyName:   p.RollupProperty,
+			Function:             p.Function,
+		}}
+	}
+
+	return emptyProperties[p.Type]
+}
+
+// emptyProperties are the properties of the types without configuration.
+var emptyProperties = map[notion.PropertyType]notion.Property{ // nolint:gochecknoglobals
+	notion.PropertyTypeTitle:          notion.TitleProperty{},
+	notion.PropertyTypeRichText:       notion.RichTextProperty{},
+	notion.PropertyTypeDate:           notion.DateProperty{},
+	notion.PropertyTypePeople:         notion.PeopleProperty{},
+	notion.PropertyTypeFile:           notion.FileProperty{},
+	notion.PropertyTypeCheckbox:       notion.CheckboxProperty{},
+	notion.PropertyTypeURL:            notion.URLProperty{},
+	notion.PropertyTypeEmail:          notion.EmailProperty{},
+	notion.PropertyTypePhoneNumber:    notion.PhoneNumberProperty{},
+	notion.PropertyTypeCreatedTime:    notion.CreatedTimeProperty{},
+	notion.PropertyTypeCreatedBy:      notion.CreatedByProperty{},
+	notion.PropertyTypeLastEditedTime: notion.LastEditedTimeProperty{},
+	notion.PropertyTypeLastEditedBy:   notion.LastEditedByProperty{},
+}
+
+// optionColor returns the color of a new option, the color of an existing option cannot be changed.
+func optionColor(option Option, ids map[string]string) notion.Color {
+	if ids[option.Name] != "" {
+		return ""
+	}
+
+	return option.Color
+}
+
+// missingOptions returns the names of the options missing from others.
+func missingOptions(options, others []Option) []string {
+	names := make(map[string]bool, len(others))
+	for _, option := range others {
+		names[option.Name] = true
+	}
+
+	var missing []string
+
+	for _, option := range options {
+		if !names[option.Name] {
+			missing = append(missing, option.Name)
+		}
+	}
+
+	return missing
+}
+
+func numberFormat(format notion.NumberFormat) notion.NumberFormat {
+	if format == "" {
+		return notion.NumberFormatNumber
+	}
+
+	return format
+}
+
+// mergeNames returns the names of the properties of both schemas, sorted.
+func mergeNames(a, b Schema) []string {
+	merged := Schema{Properties: make(map[string]Property, len(a.Properties)+len(b.Properties))}
+
+	for name, property := range a.Properties {
+		merged.Properties[name] = property
+	}
+
+	for name, property := range b.Properties {
+		merged.Properties[name] = property
+	}
+
+	return merged.names()
+}
+
+func quoteAll(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, fmt.Sprintf("%q", name))
+	}
+
+	return strings.Join(quoted, ", ")
+}
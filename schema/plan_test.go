@@ -0,0 +1,246 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tasksDatabaseJSON = `{
+	"object": "database",
+	"id": "def72422-ea36-4c8a-a6f1-a34e11a7fe54",
+	"created_time": "2021-04-27T20:38:19.437Z",
+	"last_edited_time": "2021-05-12T06:07:00.000Z",
+	"title": [{"type": "text", "text": {"content": "Tasks"}, "plain_text": "Tasks"}],
+	"properties": {
+		"Name": {"id": "title", "type": "title", "title": {}},
+		"Status": {"id": "^OE@", "type": "select", "select": {"options": [
+			{"id": "s1", "name": "Done", "color": "green"},
+			{"id": "s2", "name": "Old", "color": "gray"}
+		]}},
+		"Price": {"id": "p;zh", "type": "number", "number": {"format": "number"}},
+		"Cost": {"id": "c;sk", "type": "formula", "formula": {"expression": "prop(\"Price\") * 2"}},
+		"Project": {"id": "r;jk", "type": "relation", "relation": {
+			"database_id": "668d797c-76fa-4934-9b05-ad288df2d136",
+			"synced_property_name": "Tasks"
+		}},
+		"Photo": {"id": "f;ph", "type": "files", "files": {}}
+	}
+}`
+
+func TestCompare(t *testing.T) {
+	var database notion.Database
+
+	require.NoError(t, json.Unmarshal([]byte(tasksDatabaseJSON), &database))
+
+	with := func(changes map[string]*Property) Schema {
+		s := Schema{Properties: make(map[string]Property)}
+
+		for name, property := range FromDatabase(&database).Properties {
+			s.Properties[name] = property
+		}
+
+		for name, property := range changes {
+			if property == nil {
+				delete(s.Properties, name)
+			} else {
+				s.Properties[name] = *property
+			}
+		}
+
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		desired Schema
+		want    string
+		params  string
+	}{
+		{
+			name:    "Tasks",
+			desired: tasksSchema,
+			want: "Database def72422-ea36-4c8a-a6f1-a34e11a7fe54:\n" +
+				"  + Budget (rollup)\n" +
+				"  - Photo (files)\n" +
+				"  ~ Price (number): format: number -> dollar\n" +
+				"  ~ Status (select): add options \"Blocked\"; remove options \"Old\"\n",
+			params: `{"properties": {
+				"Budget": {"type": "rollup", "rollup": {"relation_property_name": "Project", "rollup_property_name": "Budget", "function": "sum"}},
+				"Photo": null,
+				"Price": {"type": "number", "number": {"format": "dollar"}},
+				"Status": {"type": "select", "select": {"options": [{"id": "s1", "name": "Done"}, {"name": "Blocked"}]}}
+			}}`,
+		},
+		{
+			name:    "Up to date",
+			desired: FromDatabase(&database),
+			want:    "Database def72422-ea36-4c8a-a6f1-a34e11a7fe54 is up to date.\n",
+			params:  `{}`,
+		},
+		{
+			name: "Retype and change the configuration",
+			desired: with(map[string]*Property{
+				"Status":  {Type: notion.PropertyTypeRichText},
+				"Cost":    {Type: notion.PropertyTypeFormula, Expression: `prop("Price") * 3`},
+				"Project": {Type: notion.PropertyTypeRelation, DatabaseID: "0f3e8d4c-1a2b-4c5d-8e9f-a0b1c2d3e4f5"},
+			}),
+			want: "Database def72422-ea36-4c8a-a6f1-a34e11a7fe54:\n" +
+				"  ~ Cost (formula): expression: \"prop(\\\"Price\\\") * 2\" -> \"prop(\\\"Price\\\") * 3\"\n" +
+				"  ~ Project (relation): database_id: 668d797c-76fa-4934-9b05-ad288df2d136 -> 0f3e8d4c-1a2b-4c5d-8e9f-a0b1c2d3e4f5\n" +
+				"  ~ Status: select -> rich_text\n",
+			params: `{"properties": {
+				"Cost": {"type": "formula", "formula": {"expression": "prop(\"Price\") * 3"}},
+				"Project": {"type": "relation", "relation": {"database_id": "0f3e8d4c-1a2b-4c5d-8e9f-a0b1c2d3e4f5"}},
+				"Status": {"type": "rich_text", "rich_text": {}}
+			}}`,
+		},
+		{
+			name: "Rename the title property",
+			desired: with(map[string]*Property{
+				"Name":  nil,
+				"Title": {Type: notion.PropertyTypeTitle},
+			}),
+			want: "Database def72422-ea36-4c8a-a6f1-a34e11a7fe54:\n" +
+				"  ~ Name (title): rename to \"Title\"\n",
+			params: `{"properties": {"Name": {"name": "Title"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := Compare(&database, tt.desired)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, plan.String())
+
+			params, err := json.Marshal(plan.Parameters())
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.params, string(params))
+		})
+	}
+}
+
+func TestCompare_Errors(t *testing.T) {
+	var database notion.Database
+
+	require.NoError(t, json.Unmarshal([]byte(tasksDatabaseJSON), &database))
+
+	tests := []struct {
+		name    string
+		desired Schema
+		want    string
+	}{
+		{
+			name:    "Invalid schema",
+			desired: Schema{},
+			want:    "schema: invalid schema: no title property",
+		},
+		{
+			name: "Title property retyped",
+			desired: Schema{Properties: map[string]Property{
+				"Name":  {Type: notion.PropertyTypeRichText},
+				"Title": {Type: notion.PropertyTypeTitle},
+			}},
+			want: `schema: invalid schema: property "Name" is the title property of the database, it cannot become ` +
+				`a rich_text property while the title property is renamed to "Title"`,
+		},
+		{
+			name: "Title property renamed to an existing property",
+			desired: Schema{Properties: map[string]Property{
+				"Price": {Type: notion.PropertyTypeTitle},
+			}},
+			want: `schema: invalid schema: the title property "Name" cannot be renamed to "Price", a number property has this name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compare(&database, tt.desired)
+			assert.ErrorIs(t, err, ErrInvalidSchema)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+// newFakeServer returns a server serving the tasks database, and recording the bodies of the updates.
+func newFakeServer(t *testing.T, updates *[]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/v1/databases/def72422-ea36-4c8a-a6f1-a34e11a7fe54", request.URL.Path)
+
+		switch request.Method {
+		case http.MethodGet:
+		case http.MethodPatch:
+			b, err := io.ReadAll(request.Body)
+			assert.NoError(t, err)
+
+			*updates = append(*updates, string(b))
+		default:
+			t.Errorf("unexpected %s request", request.Method)
+		}
+
+		_, err := writer.Write([]byte(tasksDatabaseJSON))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestDiff(t *testing.T) {
+	var updates []string
+
+	server := newFakeServer(t, &updates)
+	databases := notion.New("token", notion.WithBaseURL(server.URL)).Databases()
+
+	// A dry run only retrieves the database.
+	plan, err := Diff(context.Background(), databases, "def72422-ea36-4c8a-a6f1-a34e11a7fe54", tasksSchema)
+	require.NoError(t, err)
+	assert.Len(t, plan.Changes, 4)
+	assert.Empty(t, updates)
+
+	require.NoError(t, plan.Apply(context.Background(), databases))
+
+	require.Len(t, updates, 1)
+	assert.JSONEq(t, `{"properties": {
+		"Budget": {"type": "rollup", "rollup": {"relation_property_name": "Project", "rollup_property_name": "Budget", "function": "sum"}},
+		"Photo": null,
+		"Price": {"type": "number", "number": {"format": "dollar"}},
+		"Status": {"type": "select", "select": {"options": [{"id": "s1", "name": "Done"}, {"name": "Blocked"}]}}
+	}}`, updates[0])
+}
+
+func TestPlan_Apply_NoChange(t *testing.T) {
+	var updates []string
+
+	server := newFakeServer(t, &updates)
+	databases := notion.New("token", notion.WithBaseURL(server.URL)).Databases()
+
+	plan := &Plan{DatabaseID: "def72422-ea36-4c8a-a6f1-a34e11a7fe54"}
+
+	require.NoError(t, plan.Apply(context.Background(), databases))
+	assert.Empty(t, updates)
+}
+
+func TestDiff_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+
+		_, err := writer.Write([]byte(`{"object": "error", "status": 404, "code": "object_not_found", "message": "Not found."}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	databases := notion.New("token", notion.WithBaseURL(server.URL)).Databases()
+
+	_, err := Diff(context.Background(), databases, "def72422-ea36-4c8a-a6f1-a34e11a7fe54", tasksSchema)
+	assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+}
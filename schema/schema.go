@@ -0,0 +1,268 @@
+// Package schema applies a declarative schema to a Notion database. The desired properties of the database, written
+// in Go or read from a YAML or JSON file, are compared with the properties of the live database, and the differences
+// are applied with Databases().Update:
+//
+//	desired, err := schema.Load("tasks.yaml")
+//	plan, err := schema.Diff(ctx, c.Databases(), "<DATABASE_ID>", *desired)
+//	fmt.Print(plan)
+//	err = plan.Apply(ctx, c.Databases())
+//
+// A schema file lists the properties by name, with their type and the configuration of the type:
+//
+//	properties:
+//	  Name:
+//	    type: title
+//	  Status:
+//	    type: select
+//	    options:
+//	      - name: Done
+//	        color: green
+//	      - name: Blocked
+//	  Price:
+//	    type: number
+//	    format: dollar
+//	  Cost:
+//	    type: formula
+//	    expression: prop("Price") * 2
+//	  Project:
+//	    type: relation
+//	    database_id: 668d797c-76fa-4934-9b05-ad288df2d136
+//	  Budget:
+//	    type: rollup
+//	    relation_property: Project
+//	    rollup_property: Budget
+//	    function: sum
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/mkfsn/notion-go"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidSchema is returned when a schema cannot be applied to a database, e.g. when it has no title property.
+var ErrInvalidSchema = errors.New("schema: invalid schema")
+
+// Schema is the desired schema of a database.
+type Schema struct {
+	// The properties of the database by name. The database must have exactly one title property.
+	Properties map[string]Property `json:"properties" yaml:"properties"`
+}
+
+// Property is the desired state of a property of a database. Only the fields applying to its type are used.
+type Property struct {
+	Type notion.PropertyType `json:"type" yaml:"type"`
+	// Options of a select or multi select property. The options of the database missing from the list are removed,
+	// the colors of the existing options are left as they are.
+	Options []Option `json:"options,omitempty" yaml:"options,omitempty"`
+	// Format of a number property, number by default.
+	Format notion.NumberFormat `json:"format,omitempty" yaml:"format,omitempty"`
+	// Expression of a formula property.
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+	// ID of the related database of a relation property.
+	DatabaseID string `json:"database_id,omitempty" yaml:"database_id,omitempty"`
+	// Name of the relation property of a rollup property.
+	RelationProperty string `json:"relation_property,omitempty" yaml:"relation_property,omitempty"`
+	// Name of the property of the related pages rolled up by a rollup property.
+	RollupProperty string `json:"rollup_property,omitempty" yaml:"rollup_property,omitempty"`
+	// Function of a rollup property.
+	Function notion.RollupFunction `json:"function,omitempty" yaml:"function,omitempty"`
+}
+
+// Option is an option of a select or multi select property.
+type Option struct {
+	Name string `json:"name" yaml:"name"`
+	// (Optional) Color of a new option, chosen by Notion by default.
+	Color notion.Color `json:"color,omitempty" yaml:"color,omitempty"`
+}
+
+// Parse reads a schema from YAML or JSON.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse the schema: %w", err)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Load reads a schema from a YAML or JSON file.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the schema: %w", err)
+	}
+
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// FromDatabase returns the schema of a database, e.g. to write the first version of a schema file.
+func FromDatabase(database *notion.Database) Schema {
+	s := Schema{Properties: make(map[string]Property, len(database.Properties))}
+
+	for name, property := range database.Properties {
+		s.Properties[name] = fromProperty(property)
+	}
+
+	return s
+}
+
+// Validate checks that the schema has exactly one title property and that the fields of every property apply to its
+// type.
+func (s Schema) Validate() error {
+	var titles []string
+
+	for _, name := range s.names() {
+		property := s.Properties[name]
+
+		if property.Type == notion.PropertyTypeTitle {
+			titles = append(titles, name)
+		}
+
+		if err := property.validate(); err != nil {
+			return fmt.Errorf("%w: property %q: %s", ErrInvalidSchema, name, err)
+		}
+	}
+
+	switch len(titles) {
+	case 0:
+		return fmt.Errorf("%w: no title property", ErrInvalidSchema)
+	case 1:
+		return nil
+	default:
+		return fmt.Errorf("%w: several title properties: %q", ErrInvalidSchema, titles)
+	}
+}
+
+// names returns the names of the properties, sorted.
+func (s Schema) names() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// title returns the name of the title property, or an empty string.
+func (s Schema) title() string {
+	for name, property := range s.Properties {
+		if property.Type == notion.PropertyTypeTitle {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// validate returns a message describing why the property is invalid, or nil.
+func (p Property) validate() error { // nolint:cyclop
+	switch p.Type {
+	case "":
+		return errors.New("no type") // nolint:goerr113
+	case notion.PropertyTypeTitle, notion.PropertyTypeRichText, notion.PropertyTypeNumber, notion.PropertyTypeSelect,
+		notion.PropertyTypeMultiSelect, notion.PropertyTypeDate, notion.PropertyTypePeople, notion.PropertyTypeFile,
+		notion.PropertyTypeCheckbox, notion.PropertyTypeURL, notion.PropertyTypeEmail, notion.PropertyTypePhoneNumber,
+		notion.PropertyTypeFormula, notion.PropertyTypeRelation, notion.PropertyTypeRollup, notion.PropertyTypeCreatedTime,
+		notion.PropertyTypeCreatedBy, notion.PropertyTypeLastEditedTime, notion.PropertyTypeLastEditedBy:
+	default:
+		return fmt.Errorf("unknown type %q", p.Type) // nolint:goerr113
+	}
+
+	var misplaced []string
+
+	check := func(field string, set bool, types ...notion.PropertyType) {
+		for _, t := range types {
+			if p.Type == t {
+				return
+			}
+		}
+
+		if set {
+			misplaced = append(misplaced, field)
+		}
+	}
+
+	check("options", p.Options != nil, notion.PropertyTypeSelect, notion.PropertyTypeMultiSelect)
+	check("format", p.Format != "", notion.PropertyTypeNumber)
+	check("expression", p.Expression != "", notion.PropertyTypeFormula)
+	check("database_id", p.DatabaseID != "", notion.PropertyTypeRelation)
+	check("relation_property", p.RelationProperty != "", notion.PropertyTypeRollup)
+	check("rollup_property", p.RollupProperty != "", notion.PropertyTypeRollup)
+	check("function", p.Function != "", notion.PropertyTypeRollup)
+
+	if len(misplaced) > 0 {
+		return fmt.Errorf("%v do not apply to %s properties", misplaced, p.Type) // nolint:goerr113
+	}
+
+	switch {
+	case p.Type == notion.PropertyTypeFormula && p.Expression == "":
+		return errors.New("no expression") // nolint:goerr113
+	case p.Type == notion.PropertyTypeRelation && p.DatabaseID == "":
+		return errors.New("no database_id") // nolint:goerr113
+	case p.Type == notion.PropertyTypeRollup && (p.RelationProperty == "" || p.RollupProperty == "" || p.Function == ""):
+		return errors.New("relation_property, rollup_property and function are required") // nolint:goerr113
+	}
+
+	seen := make(map[string]bool, len(p.Options))
+
+	for _, option := range p.Options {
+		if option.Name == "" {
+			return errors.New("option without name") // nolint:goerr113
+		}
+
+		if seen[option.Name] {
+			return fmt.Errorf("duplicate option %q", option.Name) // nolint:goerr113
+		}
+
+		seen[option.Name] = true
+	}
+
+	return nil
+}
+
+// fromProperty returns the desired state matching a property of a database.
+func fromProperty(property notion.Property) Property {
+	p := Property{Type: notion.PropertyTypeOf(property)}
+
+	switch property := property.(type) {
+	case *notion.NumberProperty:
+		p.Format = property.Number.Format
+	case *notion.SelectProperty:
+		p.Options = make([]Option, 0, len(property.Select.Options))
+		for _, option := range property.Select.Options {
+			p.Options = append(p.Options, Option{Name: option.Name, Color: option.Color})
+		}
+	case *notion.MultiSelectProperty:
+		p.Options = make([]Option, 0, len(property.MultiSelect.Options))
+		for _, option := range property.MultiSelect.Options {
+			p.Options = append(p.Options, Option{Name: option.Name, Color: option.Color})
+		}
+	case *notion.FormulaProperty:
+		p.Expression = property.Formula.Expression
+	case *notion.RelationProperty:
+		p.DatabaseID = property.Relation.DatabaseID
+	case *notion.RollupProperty:
+		p.RelationProperty = property.Rollup.RelationPropertyName
+		p.RollupProperty = property.Rollup.RollupPropertyName
+		p.Function = property.Rollup.Function
+	}
+
+	return p
+}
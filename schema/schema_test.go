@@ -0,0 +1,204 @@
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mkfsn/notion-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tasksSchema = Schema{Properties: map[string]Property{
+	"Name": {Type: notion.PropertyTypeTitle},
+	"Status": {Type: notion.PropertyTypeSelect, Options: []Option{
+		{Name: "Done", Color: notion.ColorGreen},
+		{Name: "Blocked"},
+	}},
+	"Price":   {Type: notion.PropertyTypeNumber, Format: notion.NumberFormatDollar},
+	"Cost":    {Type: notion.PropertyTypeFormula, Expression: `prop("Price") * 2`},
+	"Project": {Type: notion.PropertyTypeRelation, DatabaseID: "668d797c-76fa-4934-9b05-ad288df2d136"},
+	"Budget": {
+		Type:             notion.PropertyTypeRollup,
+		RelationProperty: "Project",
+		RollupProperty:   "Budget",
+		Function:         notion.RollupFunctionSum,
+	},
+}}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "YAML",
+			data: `
+properties:
+  Name:
+    type: title
+  Status:
+    type: select
+    options:
+      - name: Done
+        color: green
+      - name: Blocked
+  Price:
+    type: number
+    format: dollar
+  Cost:
+    type: formula
+    expression: prop("Price") * 2
+  Project:
+    type: relation
+    database_id: 668d797c-76fa-4934-9b05-ad288df2d136
+  Budget:
+    type: rollup
+    relation_property: Project
+    rollup_property: Budget
+    function: sum
+`,
+		},
+		{
+			name: "JSON",
+			data: `{"properties": {
+				"Name": {"type": "title"},
+				"Status": {"type": "select", "options": [{"name": "Done", "color": "green"}, {"name": "Blocked"}]},
+				"Price": {"type": "number", "format": "dollar"},
+				"Cost": {"type": "formula", "expression": "prop(\"Price\") * 2"},
+				"Project": {"type": "relation", "database_id": "668d797c-76fa-4934-9b05-ad288df2d136"},
+				"Budget": {"type": "rollup", "relation_property": "Project", "rollup_property": "Budget", "function": "sum"}
+			}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, &tasksSchema, got)
+		})
+	}
+}
+
+func TestParse_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "Unknown event", data: "]!!binary\t\xef"},
+		{name: "Unclosed flow mapping", data: "{properties: {Name: {type: title}"},
+		{name: "Not a mapping", data: "- title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+
+			require.NotPanics(t, func() { _, err = Parse([]byte(tt.data)) })
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("properties:\n  Name:\n    type: title\n"), 0o600))
+
+	got, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, &Schema{Properties: map[string]Property{"Name": {Type: notion.PropertyTypeTitle}}}, got)
+
+	require.NoError(t, os.WriteFile(path, []byte("properties:\n  Name:\n    type: text\n"), 0o600))
+
+	_, err = Load(path)
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+	assert.EqualError(t, err, path+`: schema: invalid schema: property "Name": unknown type "text"`)
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema Schema
+		want   string
+	}{
+		{
+			name:   "No title property",
+			schema: Schema{Properties: map[string]Property{"Notes": {Type: notion.PropertyTypeRichText}}},
+			want:   "schema: invalid schema: no title property",
+		},
+		{
+			name: "Several title properties",
+			schema: Schema{Properties: map[string]Property{
+				"Name":  {Type: notion.PropertyTypeTitle},
+				"Title": {Type: notion.PropertyTypeTitle},
+			}},
+			want: `schema: invalid schema: several title properties: ["Name" "Title"]`,
+		},
+		{
+			name:   "No type",
+			schema: Schema{Properties: map[string]Property{"Name": {}}},
+			want:   `schema: invalid schema: property "Name": no type`,
+		},
+		{
+			name: "Fields of another type",
+			schema: Schema{Properties: map[string]Property{
+				"Name":  {Type: notion.PropertyTypeTitle},
+				"Price": {Type: notion.PropertyTypeRichText, Format: notion.NumberFormatDollar, Options: []Option{{Name: "a"}}},
+			}},
+			want: `schema: invalid schema: property "Price": [options format] do not apply to rich_text properties`,
+		},
+		{
+			name: "Formula without expression",
+			schema: Schema{Properties: map[string]Property{
+				"Name": {Type: notion.PropertyTypeTitle},
+				"Cost": {Type: notion.PropertyTypeFormula},
+			}},
+			want: `schema: invalid schema: property "Cost": no expression`,
+		},
+		{
+			name: "Incomplete rollup",
+			schema: Schema{Properties: map[string]Property{
+				"Name":   {Type: notion.PropertyTypeTitle},
+				"Budget": {Type: notion.PropertyTypeRollup, RelationProperty: "Project"},
+			}},
+			want: `schema: invalid schema: property "Budget": relation_property, rollup_property and function are required`,
+		},
+		{
+			name: "Duplicate option",
+			schema: Schema{Properties: map[string]Property{
+				"Name": {Type: notion.PropertyTypeTitle},
+				"Tags": {Type: notion.PropertyTypeMultiSelect, Options: []Option{{Name: "a"}, {Name: "a"}}},
+			}},
+			want: `schema: invalid schema: property "Tags": duplicate option "a"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.Validate()
+			assert.ErrorIs(t, err, ErrInvalidSchema)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestFromDatabase(t *testing.T) {
+	var database notion.Database
+
+	require.NoError(t, json.Unmarshal([]byte(tasksDatabaseJSON), &database))
+
+	assert.Equal(t, Schema{Properties: map[string]Property{
+		"Name": {Type: notion.PropertyTypeTitle},
+		"Status": {Type: notion.PropertyTypeSelect, Options: []Option{
+			{Name: "Done", Color: notion.ColorGreen},
+			{Name: "Old", Color: notion.ColorGray},
+		}},
+		"Price":   {Type: notion.PropertyTypeNumber, Format: notion.NumberFormatNumber},
+		"Cost":    {Type: notion.PropertyTypeFormula, Expression: `prop("Price") * 2`},
+		"Project": {Type: notion.PropertyTypeRelation, DatabaseID: "668d797c-76fa-4934-9b05-ad288df2d136"},
+		"Photo":   {Type: notion.PropertyTypeFile},
+	}}, FromDatabase(&database))
+}
@@ -65,8 +65,30 @@ func (s *SearchResponse) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SearchIterator iterates over every search result, starting at the cursor of the parameters.
+type SearchIterator struct {
+	*pager
+}
+
+// Value returns the current search result.
+func (s *SearchIterator) Value() SearchableObject {
+	return s.value.(SearchableObject)
+}
+
+// Collect returns all the remaining search results, or at most maxItems results if maxItems is positive.
+func (s *SearchIterator) Collect(maxItems int) ([]SearchableObject, error) {
+	var objects []SearchableObject
+
+	for (maxItems <= 0 || len(objects) < maxItems) && s.Next() {
+		objects = append(objects, s.Value())
+	}
+
+	return objects, s.Err()
+}
+
 type SearchInterface interface {
 	Search(ctx context.Context, params SearchParameters) (*SearchResponse, error)
+	SearchAll(ctx context.Context, params SearchParameters) *SearchIterator
 }
 
 type searchClient struct {
@@ -86,6 +108,7 @@ func (s *searchClient) Search(ctx context.Context, params SearchParameters) (*Se
 
 	err := s.restClient.New().
 		Post().
+		Idempotent().
 		Endpoint(APISearchEndpoint).
 		QueryStruct(params).
 		BodyJSON(params).
@@ -94,6 +117,31 @@ func (s *searchClient) Search(ctx context.Context, params SearchParameters) (*Se
 	return &result, err // nolint:wrapcheck
 }
 
+func (s *searchClient) SearchAll(ctx context.Context, params SearchParameters) *SearchIterator {
+	return NewSearchIterator(ctx, s, params)
+}
+
+// NewSearchIterator returns an iterator over every result of a search with search.
+func NewSearchIterator(ctx context.Context, search SearchInterface, params SearchParameters) *SearchIterator {
+	return &SearchIterator{
+		pager: newPager(ctx, params.StartCursor, func(ctx context.Context, startCursor string) ([]interface{}, PaginatedList, error) {
+			params.StartCursor = startCursor
+
+			resp, err := search.Search(ctx, params)
+			if err != nil {
+				return nil, PaginatedList{}, err
+			}
+
+			results := make([]interface{}, 0, len(resp.Results))
+			for _, object := range resp.Results {
+				results = append(results, object)
+			}
+
+			return results, resp.PaginatedList, nil
+		}),
+	}
+}
+
 type searchableObjectDecoder struct {
 	SearchableObject
 }
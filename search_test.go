@@ -2,6 +2,7 @@ package notion
 
 import (
 	"context"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -252,6 +253,50 @@ func Test_searchClient_Search(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Search pages by last edited time, descending",
+			fields: fields{
+				restClient: rest.New(),
+				authToken:  "39686a40-3364-4499-8639-185740546d42",
+				mockHTTPHandler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					assert.Equal(t, http.MethodPost, request.Method)
+					assert.Equal(t, "/v1/search", request.RequestURI)
+
+					b, err := ioutil.ReadAll(request.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{
+						"query": "kale",
+						"sort": {"direction": "descending", "timestamp": "last_edited_time"},
+						"filter": {"value": "page", "property": "object"}
+					}`, string(b))
+
+					writer.WriteHeader(http.StatusOK)
+
+					_, err = writer.Write([]byte(`{"object": "list", "results": [], "next_cursor": null, "has_more": false}`))
+					assert.NoError(t, err)
+				}),
+			},
+			args: args{
+				ctx: context.Background(),
+				params: SearchParameters{
+					Query: "kale",
+					Sort: SearchSort{
+						Direction: SearchSortDirectionDescending,
+						Timestamp: SearchSortTimestampLastEditedTime,
+					},
+					Filter: SearchFilter{
+						Value:    SearchFilterValuePage,
+						Property: SearchFilterPropertyObject,
+					},
+				},
+			},
+			wants: wants{
+				response: &SearchResponse{
+					PaginatedList: PaginatedList{Object: ObjectTypeList},
+					Results:       []SearchableObject{},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,183 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// TypedDatabase maps the pages of a database to structs of type T, tagged as described in UnmarshalProperties.
+// The struct is validated against the properties of the database on first use, see ValidateStruct.
+type TypedDatabase[T any] struct {
+	api        *API
+	databaseID string
+
+	mu        sync.Mutex
+	validated bool
+	err       error
+}
+
+// NewTypedDatabase returns a TypedDatabase for the database with the given ID, e.g.
+// NewTypedDatabase[Task](api, databaseID).
+func NewTypedDatabase[T any](api *API, databaseID string) *TypedDatabase[T] {
+	return &TypedDatabase[T]{
+		api:        api,
+		databaseID: databaseID,
+	}
+}
+
+// Validate retrieves the database and checks that T matches its properties. The outcome is kept once the
+// database is retrieved, so that the database is retrieved once. It is called by every other method.
+func (d *TypedDatabase[T]) Validate(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.validated {
+		return d.err
+	}
+
+	resp, err := d.api.Databases().Retrieve(ctx, DatabasesRetrieveParameters{DatabaseID: d.databaseID})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve database %s: %w", d.databaseID, err)
+	}
+
+	var value T
+
+	d.validated, d.err = true, ValidateStruct(&resp.Database, &value)
+
+	return d.err
+}
+
+// Query returns an iterator over the pages of the database matching the filter, sorted by the sorts.
+// The filter and sorts are optional.
+func (d *TypedDatabase[T]) Query(ctx context.Context, filter Filter, sorts []Sort) *TypedIterator[T] {
+	if err := d.Validate(ctx); err != nil {
+		return &TypedIterator[T]{err: err}
+	}
+
+	pages := d.api.Databases().QueryAll(ctx, DatabasesQueryParameters{
+		DatabaseID: d.databaseID,
+		Filter:     filter,
+		Sorts:      sorts,
+	})
+
+	return &TypedIterator[T]{pages: pages}
+}
+
+// Get retrieves the page with the given ID.
+func (d *TypedDatabase[T]) Get(ctx context.Context, pageID string) (T, error) {
+	var value T
+
+	if err := d.Validate(ctx); err != nil {
+		return value, err
+	}
+
+	resp, err := d.api.Pages().Retrieve(ctx, PagesRetrieveParameters{PageID: pageID})
+	if err != nil {
+		return value, err // nolint:wrapcheck
+	}
+
+	err = UnmarshalPage(resp.Page, &value)
+
+	return value, err
+}
+
+// Create creates a page in the database with the properties of the value, and returns the created page.
+func (d *TypedDatabase[T]) Create(ctx context.Context, value T) (*Page, error) {
+	if err := d.Validate(ctx); err != nil {
+		return nil, err
+	}
+
+	properties, err := MarshalProperties(value)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.api.Pages().Create(ctx, PagesCreateParameters{
+		Parent:     DatabaseParentInput{DatabaseID: d.databaseID},
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+
+	return &resp.Page, nil
+}
+
+// Update updates the properties of the page with the given ID with the properties of the value, and returns
+// the updated page. Fields tagged with omitempty are only updated when they are not zero.
+func (d *TypedDatabase[T]) Update(ctx context.Context, pageID string, value T) (*Page, error) {
+	if err := d.Validate(ctx); err != nil {
+		return nil, err
+	}
+
+	properties, err := MarshalProperties(value)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.api.Pages().Update(ctx, PagesUpdateParameters{PageID: pageID, Properties: properties})
+	if err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+
+	return &resp.Page, nil
+}
+
+// TypedIterator iterates over the pages of a database query mapped to values of type T.
+type TypedIterator[T any] struct {
+	pages *PagesIterator
+	page  Page
+	value T
+	err   error
+}
+
+// Next advances to the next page and maps it to a value of type T.
+// It returns false when there are no more pages, or when an error occurred or the context is done.
+func (i *TypedIterator[T]) Next() bool {
+	if i.err != nil || !i.pages.Next() {
+		return false
+	}
+
+	var value T
+
+	if err := UnmarshalPage(i.pages.Value(), &value); err != nil {
+		i.err = fmt.Errorf("failed to unmarshal page %s: %w", i.pages.Value().ID, err)
+
+		return false
+	}
+
+	i.page, i.value = i.pages.Value(), value
+
+	return true
+}
+
+// Value returns the current value.
+func (i *TypedIterator[T]) Value() T {
+	return i.value
+}
+
+// Page returns the current page, e.g. to get its ID.
+func (i *TypedIterator[T]) Page() Page {
+	return i.page
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (i *TypedIterator[T]) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+
+	return i.pages.Err()
+}
+
+// Collect returns all the remaining values, or at most maxItems values if maxItems is positive.
+func (i *TypedIterator[T]) Collect(maxItems int) ([]T, error) {
+	var values []T
+
+	for (maxItems <= 0 || len(values) < maxItems) && i.Next() {
+		values = append(values, i.Value())
+	}
+
+	return values, i.Err()
+}
@@ -0,0 +1,166 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type typedTask struct {
+	Name  string   `notion:"Name,title"`
+	Price float64  `notion:"Price,number"`
+	Tags  []string `notion:"Tags,multi_select"`
+	Done  bool     `notion:"Done,checkbox,omitempty"`
+}
+
+const typedTaskDatabaseJSON = `{
+	"object": "database",
+	"id": "db",
+	"title": [],
+	"properties": {
+		"Name": {"id": "title", "type": "title", "title": {}},
+		"Price": {"id": "BJXS", "type": "number", "number": {"format": "dollar"}},
+		"Tags": {"id": "cTag", "type": "multi_select", "multi_select": {"options": []}},
+		"Done": {"id": "eDon", "type": "checkbox", "checkbox": {}}
+	}
+}`
+
+func typedTaskPageJSON(id, name string, price float64) string {
+	return fmt.Sprintf(`{
+		"object": "page",
+		"id": %q,
+		"parent": {"type": "database_id", "database_id": "db"},
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": %q}, "plain_text": %q}]},
+			"Price": {"id": "BJXS", "type": "number", "number": %v},
+			"Tags": {"id": "cTag", "type": "multi_select", "multi_select": [{"id": "a", "name": "Leafy", "color": "green"}]},
+			"Done": {"id": "eDon", "type": "checkbox", "checkbox": true}
+		}
+	}`, id, name, name, price)
+}
+
+func newTypedDatabaseHandler(t *testing.T, databaseJSON string, retrievals *int) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/databases/db", func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodGet, request.Method)
+
+		*retrievals++
+
+		_, err := writer.Write([]byte(databaseJSON))
+		assert.NoError(t, err)
+	})
+
+	mux.Handle("/v1/databases/db/query", newMultiPageHandler(t, http.MethodPost, "/v1/databases/db/query", [][]string{
+		{typedTaskPageJSON("1", "Lacinato kale", 2.5)},
+		{typedTaskPageJSON("2", "Curly kale", 1.5)},
+	}))
+
+	mux.HandleFunc("/v1/pages/1", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPatch {
+			body, err := ioutil.ReadAll(request.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"properties": {
+				"Name": {"type": "title", "title": [{"type": "text", "text": {"content": "Lacinato kale"}}]},
+				"Price": {"type": "number", "number": 3},
+				"Tags": {"type": "multi_select", "multi_select": []}
+			}}`, string(body))
+		}
+
+		_, err := writer.Write([]byte(typedTaskPageJSON("1", "Lacinato kale", 3)))
+		assert.NoError(t, err)
+	})
+
+	mux.HandleFunc("/v1/pages", func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodPost, request.Method)
+
+		body, err := ioutil.ReadAll(request.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"parent": {"database_id": "db"},
+			"properties": {
+				"Name": {"type": "title", "title": [{"type": "text", "text": {"content": "Kale chips"}}]},
+				"Price": {"type": "number", "number": 4},
+				"Tags": {"type": "multi_select", "multi_select": [{"name": "Snack"}]},
+				"Done": {"type": "checkbox", "checkbox": true}
+			}
+		}`, string(body))
+
+		_, err = writer.Write([]byte(typedTaskPageJSON("3", "Kale chips", 4)))
+		assert.NoError(t, err)
+	})
+
+	return mux
+}
+
+func TestTypedDatabase(t *testing.T) {
+	var retrievals int
+
+	mockHTTPServer := httptest.NewServer(newTypedDatabaseHandler(t, typedTaskDatabaseJSON, &retrievals))
+	defer mockHTTPServer.Close()
+
+	sut := NewTypedDatabase[typedTask](New("token", WithBaseURL(mockHTTPServer.URL)), "db")
+	ctx := context.Background()
+
+	iterator := sut.Query(ctx, nil, nil)
+
+	tasks, err := iterator.Collect(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []typedTask{
+		{Name: "Lacinato kale", Price: 2.5, Tags: []string{"Leafy"}, Done: true},
+		{Name: "Curly kale", Price: 1.5, Tags: []string{"Leafy"}, Done: true},
+	}, tasks)
+	assert.Equal(t, "2", iterator.Page().ID)
+
+	task, err := sut.Get(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, typedTask{Name: "Lacinato kale", Price: 3, Tags: []string{"Leafy"}, Done: true}, task)
+
+	page, err := sut.Create(ctx, typedTask{Name: "Kale chips", Price: 4, Tags: []string{"Snack"}, Done: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", page.ID)
+
+	page, err = sut.Update(ctx, "1", typedTask{Name: "Lacinato kale", Price: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", page.ID)
+
+	assert.Equal(t, 1, retrievals, "the database is retrieved once")
+}
+
+func TestTypedDatabase_SchemaMismatch(t *testing.T) {
+	type wrongTask struct {
+		Name    int      `notion:"Name"`
+		Price   float64  `notion:"Price,rich_text"`
+		Missing string   `notion:"Missing"`
+		Tags    []string `notion:"Tags"`
+	}
+
+	var retrievals int
+
+	mockHTTPServer := httptest.NewServer(newTypedDatabaseHandler(t, typedTaskDatabaseJSON, &retrievals))
+	defer mockHTTPServer.Close()
+
+	sut := NewTypedDatabase[wrongTask](New("token", WithBaseURL(mockHTTPServer.URL)), "db")
+
+	_, err := sut.Query(context.Background(), nil, nil).Collect(0)
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+	assert.Equal(t, &SchemaMismatchError{
+		DatabaseID: "db",
+		Type:       reflect.TypeOf(wrongTask{}),
+		Mismatches: []string{
+			`Name: int cannot hold the values of the title property "Name"`,
+			`Price: property "Price" is a number property, not a rich_text property`,
+			`Missing: no property "Missing"`,
+		},
+	}, err)
+
+	_, err = sut.Get(context.Background(), "1")
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+	assert.Equal(t, 1, retrievals, "the outcome of the validation is kept")
+}
@@ -39,6 +39,16 @@ type BotUser struct {
 	Bot Bot `json:"bot"`
 }
 
+// PartialUser is a user of which only the ID is known, e.g. the people of a page property value, or a user
+// referenced when creating a mention or updating a people property value.
+type PartialUser struct {
+	// Always "user".
+	Object ObjectType `json:"object"`
+	ID     string     `json:"id"`
+}
+
+func (p PartialUser) isUser() {}
+
 type UsersRetrieveParameters struct {
 	UserID string `json:"-" url:"-"`
 }
@@ -91,9 +101,31 @@ func (u *UsersListResponse) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UsersIterator iterates over every user, starting at the cursor of the parameters.
+type UsersIterator struct {
+	*pager
+}
+
+// Value returns the current user.
+func (u *UsersIterator) Value() User {
+	return u.value.(User)
+}
+
+// Collect returns all the remaining users, or at most maxItems users if maxItems is positive.
+func (u *UsersIterator) Collect(maxItems int) ([]User, error) {
+	var users []User
+
+	for (maxItems <= 0 || len(users) < maxItems) && u.Next() {
+		users = append(users, u.Value())
+	}
+
+	return users, u.Err()
+}
+
 type UsersInterface interface {
 	Retrieve(ctx context.Context, params UsersRetrieveParameters) (*UsersRetrieveResponse, error)
 	List(ctx context.Context, params UsersListParameters) (*UsersListResponse, error)
+	ListAll(ctx context.Context, params UsersListParameters) *UsersIterator
 }
 
 type usersClient struct {
@@ -131,6 +163,31 @@ func (u *usersClient) List(ctx context.Context, params UsersListParameters) (*Us
 	return &result, err // nolint:wrapcheck
 }
 
+func (u *usersClient) ListAll(ctx context.Context, params UsersListParameters) *UsersIterator {
+	return NewUsersIterator(ctx, u, params)
+}
+
+// NewUsersIterator returns an iterator over every user listed by users.
+func NewUsersIterator(ctx context.Context, users UsersInterface, params UsersListParameters) *UsersIterator {
+	return &UsersIterator{
+		pager: newPager(ctx, params.StartCursor, func(ctx context.Context, startCursor string) ([]interface{}, PaginatedList, error) {
+			params.StartCursor = startCursor
+
+			resp, err := users.List(ctx, params)
+			if err != nil {
+				return nil, PaginatedList{}, err
+			}
+
+			results := make([]interface{}, 0, len(resp.Results))
+			for _, user := range resp.Results {
+				results = append(results, user)
+			}
+
+			return results, resp.PaginatedList, nil
+		}),
+	}
+}
+
 type userDecoder struct {
 	User
 }
@@ -150,6 +207,9 @@ func (u *userDecoder) UnmarshalJSON(data []byte) error {
 
 	case UserTypeBot:
 		u.User = &BotUser{}
+
+	default:
+		u.User = &PartialUser{}
 	}
 
 	return json.Unmarshal(data, u.User)